@@ -0,0 +1,443 @@
+package graphs
+
+import (
+	"sort"
+
+	"github.com/gpahal/go-algos/ds/graph"
+)
+
+// Isomorphism checks whether the graphs g1 and g2 are isomorphic using the VF2 algorithm. Two
+// graphs are isomorphic if there is a one-to-one mapping between their nodes such that there is
+// an edge between two nodes of g1 if and only if there is an edge between the corresponding nodes
+// of g2.
+//
+// nodeMatch is called with the values of a node of g1 and a node of g2, and edgeMatch with the
+// weights of an edge of g1 and an edge of g2. They should return true if the nodes or edges are
+// equivalent. If nil, all nodes or edges are considered equivalent.
+//
+// If the graphs are isomorphic, the mapping from the node ids of g1 to the node ids of g2 is
+// returned. Otherwise, the second return value is false.
+func Isomorphism(g1, g2 *graph.Graph, nodeMatch, edgeMatch func(a, b int) bool) (map[int]int, bool) {
+	var mapping map[int]int
+	EachIsomorphism(g1, g2, nodeMatch, edgeMatch, func(m map[int]int) bool {
+		mapping = m
+		return true
+	})
+
+	return mapping, mapping != nil
+}
+
+// EachIsomorphism iterates over all the isomorphisms between the graphs g1 and g2 using the VF2
+// algorithm. fn is called with the mapping from the node ids of g1 to the node ids of g2 for every
+// isomorphism, and the iteration stops if it returns true. See Isomorphism for the meaning of
+// nodeMatch and edgeMatch.
+func EachIsomorphism(
+	g1, g2 *graph.Graph,
+	nodeMatch, edgeMatch func(a, b int) bool,
+	fn func(mapping map[int]int) bool,
+) {
+	vg1 := newVF2Graph(g1)
+	vg2 := newVF2Graph(g2)
+	if len(vg1.ids) != len(vg2.ids) || vg1.edgeCount != vg2.edgeCount {
+		return
+	}
+
+	s := newVF2State(vg1, vg2, false, nodeMatch, edgeMatch)
+	s.match(func() bool {
+		mapping := make(map[int]int, len(vg1.ids))
+		for n1, n2 := range s.core1 {
+			mapping[vg1.ids[n1]] = vg2.ids[n2]
+		}
+
+		return fn(mapping)
+	})
+}
+
+// SubgraphIsomorphism checks whether the graph pattern is isomorphic to a node-induced subgraph of
+// the graph g using the VF2 algorithm. A node-induced subgraph consists of a subset of the nodes
+// of g and all the edges of g between those nodes.
+//
+// If such a subgraph exists, the mapping from the node ids of pattern to the node ids of g is
+// returned. Otherwise, the second return value is false. See Isomorphism for the meaning of
+// nodeMatch and edgeMatch. They are called with the value or weight from g as the first argument.
+func SubgraphIsomorphism(
+	g, pattern *graph.Graph,
+	nodeMatch, edgeMatch func(a, b int) bool,
+) (map[int]int, bool) {
+	var mapping map[int]int
+	EachSubgraphIsomorphism(g, pattern, nodeMatch, edgeMatch, func(m map[int]int) bool {
+		mapping = m
+		return true
+	})
+
+	return mapping, mapping != nil
+}
+
+// EachSubgraphIsomorphism iterates over all the isomorphisms between the graph pattern and the
+// node-induced subgraphs of the graph g using the VF2 algorithm. fn is called with the mapping
+// from the node ids of pattern to the node ids of g for every isomorphism, and the iteration stops
+// if it returns true. See SubgraphIsomorphism for the meaning of nodeMatch and edgeMatch.
+func EachSubgraphIsomorphism(
+	g, pattern *graph.Graph,
+	nodeMatch, edgeMatch func(a, b int) bool,
+	fn func(mapping map[int]int) bool,
+) {
+	vg1 := newVF2Graph(g)
+	vg2 := newVF2Graph(pattern)
+	if len(vg1.ids) < len(vg2.ids) || vg1.edgeCount < vg2.edgeCount {
+		return
+	}
+
+	s := newVF2State(vg1, vg2, true, nodeMatch, edgeMatch)
+	s.match(func() bool {
+		mapping := make(map[int]int, len(vg2.ids))
+		for n2, n1 := range s.core2 {
+			mapping[vg2.ids[n2]] = vg1.ids[n1]
+		}
+
+		return fn(mapping)
+	})
+}
+
+// vf2Graph is a representation of a graph.Graph in which nodes are identified by their index in
+// the sorted slice of node ids. It makes the state of the VF2 algorithm cheap to store in slices.
+type vf2Graph struct {
+	ids       []int
+	values    []int
+	out       []map[int]int
+	in        []map[int]int
+	edgeCount int
+}
+
+func newVF2Graph(g *graph.Graph) *vf2Graph {
+	ids := sortedNodeIDs(g)
+	idxs := make(map[int]int, len(ids))
+	for idx, id := range ids {
+		idxs[id] = idx
+	}
+
+	vg := &vf2Graph{
+		ids:    ids,
+		values: make([]int, len(ids)),
+		out:    make([]map[int]int, len(ids)),
+		in:     make([]map[int]int, len(ids)),
+	}
+	for idx, id := range ids {
+		vg.values[idx] = g.Node(id).Value
+		vg.out[idx] = make(map[int]int, len(g.NodeOutgoingEdges(id)))
+		vg.in[idx] = make(map[int]int, len(g.NodeIncomingEdges(id)))
+	}
+
+	g.EachEdge(func(e graph.Edge) bool {
+		source, target := idxs[e.SourceID], idxs[e.TargetID]
+		vg.out[source][target] = e.Weight
+		vg.in[target][source] = e.Weight
+		vg.edgeCount++
+		return false
+	})
+
+	return vg
+}
+
+// sortedNodeIDs returns the ids of all the nodes of the graph in ascending order.
+func sortedNodeIDs(g *graph.Graph) []int {
+	ids := make([]int, 0, g.Len())
+	g.EachNode(func(n graph.Node) bool {
+		ids = append(ids, n.ID)
+		return false
+	})
+
+	sort.Ints(ids)
+	return ids
+}
+
+// vf2State is the state of the VF2 algorithm. It maps nodes of g2 to nodes of g1 one pair at a
+// time, backtracking when the partial mapping can't be extended.
+//
+// For every node, in and out store the depth of the search at which the node entered the set of
+// nodes that have an edge to or from a mapped node, or 0 if it is not in that set. The unmapped
+// nodes of these sets are called the terminal sets and are used to choose candidate pairs and to
+// prune the search early.
+type vf2State struct {
+	g1, g2    *vf2Graph
+	subgraph  bool
+	nodeMatch func(a, b int) bool
+	edgeMatch func(a, b int) bool
+
+	core1, core2 []int
+	in1, out1    []int
+	in2, out2    []int
+	depth        int
+}
+
+func newVF2State(g1, g2 *vf2Graph, subgraph bool, nodeMatch, edgeMatch func(a, b int) bool) *vf2State {
+	s := &vf2State{
+		g1:        g1,
+		g2:        g2,
+		subgraph:  subgraph,
+		nodeMatch: nodeMatch,
+		edgeMatch: edgeMatch,
+		core1:     make([]int, len(g1.ids)),
+		core2:     make([]int, len(g2.ids)),
+		in1:       make([]int, len(g1.ids)),
+		out1:      make([]int, len(g1.ids)),
+		in2:       make([]int, len(g2.ids)),
+		out2:      make([]int, len(g2.ids)),
+	}
+	for i := range s.core1 {
+		s.core1[i] = -1
+	}
+	for i := range s.core2 {
+		s.core2[i] = -1
+	}
+
+	return s
+}
+
+// match extends the current partial mapping in all possible ways and calls found every time all
+// the nodes of g2 are mapped. It returns true if found returned true, to stop the search.
+func (s *vf2State) match(found func() bool) bool {
+	if s.depth == len(s.g2.ids) {
+		return found()
+	}
+
+	n1s, n2 := s.candidates()
+	for _, n1 := range n1s {
+		if !s.feasible(n1, n2) {
+			continue
+		}
+
+		s.push(n1, n2)
+		stop := s.match(found)
+		s.pop(n1, n2)
+		if stop {
+			return true
+		}
+	}
+
+	return false
+}
+
+// candidates returns the candidate pairs for the next step of the search. Only a single node of
+// g2 needs to be tried at every step as all the nodes of g2 have to be mapped eventually. If a
+// terminal set of g2 has an unmapped node but the matching terminal set of g1 doesn't, that node
+// can't be mapped, so no candidates and -1 are returned.
+func (s *vf2State) candidates() ([]int, int) {
+	if n2 := s.minTerminal(s.out2, s.core2); n2 >= 0 {
+		if n1s := s.terminal(s.out1, s.core1); len(n1s) > 0 {
+			return n1s, n2
+		}
+		return nil, -1
+	}
+
+	if n2 := s.minTerminal(s.in2, s.core2); n2 >= 0 {
+		if n1s := s.terminal(s.in1, s.core1); len(n1s) > 0 {
+			return n1s, n2
+		}
+		return nil, -1
+	}
+
+	// The terminal sets of g2 are empty. For isomorphisms, the terminal sets of g1 must be empty
+	// too, but a subgraph can leave out the nodes of g1 connected to the mapped nodes, so any
+	// unmapped node can be mapped next.
+	if !s.subgraph && (s.minTerminal(s.out1, s.core1) >= 0 || s.minTerminal(s.in1, s.core1) >= 0) {
+		return nil, -1
+	}
+
+	n2 := -1
+	for n := range s.core2 {
+		if s.core2[n] < 0 {
+			n2 = n
+			break
+		}
+	}
+
+	var n1s []int
+	for n := range s.core1 {
+		if s.core1[n] < 0 {
+			n1s = append(n1s, n)
+		}
+	}
+
+	return n1s, n2
+}
+
+// minTerminal returns the unmapped node with the smallest index in the given set, or -1 if there
+// is no such node.
+func (s *vf2State) minTerminal(set, core []int) int {
+	for n := range set {
+		if set[n] > 0 && core[n] < 0 {
+			return n
+		}
+	}
+
+	return -1
+}
+
+// terminal returns all the unmapped nodes in the given set.
+func (s *vf2State) terminal(set, core []int) []int {
+	var ns []int
+	for n := range set {
+		if set[n] > 0 && core[n] < 0 {
+			ns = append(ns, n)
+		}
+	}
+
+	return ns
+}
+
+// feasible checks whether the pair (n1, n2) can be added to the current mapping.
+func (s *vf2State) feasible(n1, n2 int) bool {
+	if s.nodeMatch != nil && !s.nodeMatch(s.g1.values[n1], s.g2.values[n2]) {
+		return false
+	}
+
+	// The edges between n1 and the mapped nodes of g1 must correspond to the edges between n2 and
+	// the mapped nodes of g2, including self loops as n1 and n2 are about to be mapped.
+	if !s.edgesMatch(n1, n2, s.g1.in[n1], s.g2.in[n2]) ||
+		!s.edgesMatch(n1, n2, s.g1.out[n1], s.g2.out[n2]) {
+		return false
+	}
+
+	// Look ahead: the number of neighbours of n1 in every terminal set and outside them must be
+	// equal to (or at least, for subgraphs) that of n2, otherwise the mapping can't be completed.
+	for _, adj := range [2]struct{ e1, e2 map[int]int }{
+		{s.g1.in[n1], s.g2.in[n2]},
+		{s.g1.out[n1], s.g2.out[n2]},
+	} {
+		in1, out1, new1 := s.lookahead(adj.e1, s.in1, s.out1, s.core1)
+		in2, out2, new2 := s.lookahead(adj.e2, s.in2, s.out2, s.core2)
+		if !s.countsMatch(in1, in2) || !s.countsMatch(out1, out2) || !s.countsMatch(new1, new2) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// edgesMatch checks whether the edges e1 of n1 to mapped nodes (and n1 itself) correspond to the
+// edges e2 of n2 to mapped nodes (and n2 itself). e1 and e2 must both be incoming or both be
+// outgoing edges.
+func (s *vf2State) edgesMatch(n1, n2 int, e1, e2 map[int]int) bool {
+	for m1, w1 := range e1 {
+		m2 := s.core1[m1]
+		if m1 == n1 {
+			m2 = n2
+		} else if m2 < 0 {
+			continue
+		}
+
+		w2, ok := e2[m2]
+		if !ok {
+			// For subgraphs, an edge between the mapped nodes of g1 which is missing in g2 breaks
+			// the node-induced subgraph too, so the check is the same.
+			return false
+		}
+		if s.edgeMatch != nil && !s.edgeMatch(w1, w2) {
+			return false
+		}
+	}
+
+	for m2 := range e2 {
+		m1 := s.core2[m2]
+		if m2 == n2 {
+			m1 = n1
+		} else if m1 < 0 {
+			continue
+		}
+
+		if _, ok := e1[m1]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lookahead returns the number of unmapped neighbours of a node in the in and out terminal sets,
+// and the number of neighbours in neither of them.
+func (s *vf2State) lookahead(adj map[int]int, in, out, core []int) (int, int, int) {
+	inCount, outCount, newCount := 0, 0, 0
+	for m := range adj {
+		if core[m] >= 0 {
+			continue
+		}
+
+		if in[m] > 0 {
+			inCount++
+		}
+		if out[m] > 0 {
+			outCount++
+		}
+		if in[m] == 0 && out[m] == 0 {
+			newCount++
+		}
+	}
+
+	return inCount, outCount, newCount
+}
+
+func (s *vf2State) countsMatch(count1, count2 int) bool {
+	if s.subgraph {
+		return count1 >= count2
+	}
+
+	return count1 == count2
+}
+
+// push adds the pair (n1, n2) to the mapping and updates the terminal sets.
+func (s *vf2State) push(n1, n2 int) {
+	s.depth++
+	s.core1[n1] = n2
+	s.core2[n2] = n1
+	pushTerminal(s.depth, n1, s.g1, s.in1, s.out1)
+	pushTerminal(s.depth, n2, s.g2, s.in2, s.out2)
+}
+
+func pushTerminal(depth, n int, g *vf2Graph, in, out []int) {
+	if in[n] == 0 {
+		in[n] = depth
+	}
+	if out[n] == 0 {
+		out[n] = depth
+	}
+	for m := range g.in[n] {
+		if in[m] == 0 {
+			in[m] = depth
+		}
+	}
+	for m := range g.out[n] {
+		if out[m] == 0 {
+			out[m] = depth
+		}
+	}
+}
+
+// pop removes the pair (n1, n2) from the mapping and restores the terminal sets. It must be called
+// in the reverse order of push.
+func (s *vf2State) pop(n1, n2 int) {
+	popTerminal(s.depth, n1, s.g1, s.in1, s.out1)
+	popTerminal(s.depth, n2, s.g2, s.in2, s.out2)
+	s.core1[n1] = -1
+	s.core2[n2] = -1
+	s.depth--
+}
+
+func popTerminal(depth, n int, g *vf2Graph, in, out []int) {
+	if in[n] == depth {
+		in[n] = 0
+	}
+	if out[n] == depth {
+		out[n] = 0
+	}
+	for m := range g.in[n] {
+		if in[m] == depth {
+			in[m] = 0
+		}
+	}
+	for m := range g.out[n] {
+		if out[m] == depth {
+			out[m] = 0
+		}
+	}
+}
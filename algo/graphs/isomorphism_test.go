@@ -0,0 +1,184 @@
+package graphs_test
+
+import (
+	"testing"
+
+	"github.com/gpahal/go-algos/algo/graphs"
+	"github.com/gpahal/go-algos/ds/graph"
+)
+
+func TestIsomorphism(t *testing.T) {
+	g1, ids1 := newGraph([]int{1, 2, 3, 4}, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 3, 1}, {3, 0, 1}, {0, 2, 2}})
+	g2, ids2 := newGraph([]int{3, 4, 1, 2}, [][3]int{{2, 3, 1}, {3, 0, 1}, {0, 1, 1}, {1, 2, 1}, {2, 0, 2}})
+
+	mapping, ok := graphs.Isomorphism(g1, g2, nil, nil)
+	if !ok {
+		t.Fatalf("Isomorphism: expected graphs to be isomorphic")
+	}
+	assertIsomorphism(t, "Isomorphism", g1, g2, mapping, false)
+
+	mapping, ok = graphs.Isomorphism(g1, g2, intsEqual, intsEqual)
+	if !ok {
+		t.Fatalf("Isomorphism: expected graphs to be isomorphic with node and edge matching")
+	}
+	assertIsomorphism(t, "Isomorphism", g1, g2, mapping, true)
+	if mapping[ids1[0]] != ids2[2] {
+		t.Errorf("Isomorphism: expected node %d to map to %d, got %d", ids1[0], ids2[2], mapping[ids1[0]])
+	}
+
+	g2.UpdateEdge(ids2[2], ids2[0], 3)
+	if _, ok := graphs.Isomorphism(g1, g2, nil, intsEqual); ok {
+		t.Errorf("Isomorphism: expected graphs with different weights to not be isomorphic")
+	}
+	if _, ok := graphs.Isomorphism(g1, g2, nil, nil); !ok {
+		t.Errorf("Isomorphism: expected graphs to be isomorphic without edge matching")
+	}
+
+	g2.DeleteEdge(ids2[2], ids2[3])
+	g2.AddEdge(ids2[3], ids2[2], 1)
+	if _, ok := graphs.Isomorphism(g1, g2, nil, nil); ok {
+		t.Errorf("Isomorphism: expected graphs with a reversed edge to not be isomorphic")
+	}
+
+	// The same numbers of nodes and edges, but the edges of g1 are disjoint and those of g2 aren't.
+	g1, _ = newGraph([]int{0, 0, 0, 0}, [][3]int{{0, 1, 1}, {2, 3, 1}})
+	g2, _ = newGraph([]int{0, 0, 0, 0}, [][3]int{{0, 1, 1}, {1, 2, 1}})
+	if _, ok := graphs.Isomorphism(g1, g2, nil, nil); ok {
+		t.Errorf("Isomorphism: expected disjoint edges and a path to not be isomorphic")
+	}
+	if _, ok := graphs.Isomorphism(g2, g1, nil, nil); ok {
+		t.Errorf("Isomorphism: expected a path and disjoint edges to not be isomorphic")
+	}
+}
+
+func TestEachIsomorphism(t *testing.T) {
+	cycle, _ := newGraph([]int{0, 0, 0}, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 0, 1}})
+	count := 0
+	graphs.EachIsomorphism(cycle, cycle, nil, nil, func(mapping map[int]int) bool {
+		assertIsomorphism(t, "EachIsomorphism", cycle, cycle, mapping, false)
+		count++
+		return false
+	})
+	if count != 3 {
+		t.Errorf("EachIsomorphism: expected 3 automorphisms of a directed cycle, got %d", count)
+	}
+
+	complete, _ := newGraph([]int{0, 0, 0}, [][3]int{
+		{0, 1, 1}, {1, 0, 1}, {1, 2, 1}, {2, 1, 1}, {2, 0, 1}, {0, 2, 1},
+	})
+	count = 0
+	graphs.EachIsomorphism(complete, complete, nil, nil, func(mapping map[int]int) bool {
+		count++
+		return false
+	})
+	if count != 6 {
+		t.Errorf("EachIsomorphism: expected 6 automorphisms of a complete graph, got %d", count)
+	}
+
+	count = 0
+	graphs.EachIsomorphism(complete, complete, nil, nil, func(mapping map[int]int) bool {
+		count++
+		return true
+	})
+	if count != 1 {
+		t.Errorf("EachIsomorphism: expected iteration to stop after 1 automorphism, got %d", count)
+	}
+}
+
+func TestSubgraphIsomorphism(t *testing.T) {
+	g, _ := newGraph([]int{1, 2, 3, 4, 5}, [][3]int{
+		{0, 1, 1}, {1, 2, 1}, {2, 0, 1}, {2, 3, 1}, {3, 4, 1}, {4, 4, 1},
+	})
+
+	triangle, _ := newGraph([]int{0, 0, 0}, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 0, 1}})
+	mapping, ok := graphs.SubgraphIsomorphism(g, triangle, nil, nil)
+	if !ok {
+		t.Fatalf("SubgraphIsomorphism: expected triangle to be found")
+	}
+	assertIsomorphism(t, "SubgraphIsomorphism", triangle, g, mapping, false)
+
+	path, _ := newGraph([]int{0, 0, 0}, [][3]int{{0, 1, 1}, {1, 2, 1}})
+	count := 0
+	graphs.EachSubgraphIsomorphism(g, path, nil, nil, func(mapping map[int]int) bool {
+		assertIsomorphism(t, "EachSubgraphIsomorphism", path, g, mapping, false)
+		count++
+		return false
+	})
+	if count != 1 {
+		// Only 1->3->4 as the paths within the triangle are not node-induced and 4->5 has a
+		// self loop on 5.
+		t.Errorf("EachSubgraphIsomorphism: expected 1 induced path, got %d", count)
+	}
+
+	loop, _ := newGraph([]int{5}, [][3]int{{0, 0, 1}})
+	if _, ok := graphs.SubgraphIsomorphism(g, loop, intsEqual, nil); !ok {
+		t.Errorf("SubgraphIsomorphism: expected self loop to be found")
+	}
+
+	if _, ok := graphs.SubgraphIsomorphism(g, triangle, intsEqual, nil); ok {
+		t.Errorf("SubgraphIsomorphism: expected triangle with values 0 to not be found")
+	}
+
+	isolated, _ := newGraph([]int{0, 0}, nil)
+	count = 0
+	graphs.EachSubgraphIsomorphism(g, isolated, nil, nil, func(mapping map[int]int) bool {
+		assertIsomorphism(t, "EachSubgraphIsomorphism", isolated, g, mapping, false)
+		count++
+		return false
+	})
+	if count != 4 {
+		// The unconnected pairs without self loops are 1-4 and 2-4, in both orders.
+		t.Errorf("EachSubgraphIsomorphism: expected 4 induced pairs of isolated nodes, got %d", count)
+	}
+}
+
+func newGraph(values []int, edges [][3]int) (*graph.Graph, []int) {
+	g := graph.New()
+	ids := make([]int, len(values))
+	for i, value := range values {
+		ids[i] = g.AddNode(value)
+	}
+	for _, e := range edges {
+		g.AddEdge(ids[e[0]], ids[e[1]], e[2])
+	}
+
+	return g, ids
+}
+
+func intsEqual(a, b int) bool {
+	return a == b
+}
+
+// assertIsomorphism checks that mapping maps the nodes of g1 to the nodes of g2 such that an edge
+// of g1 exists if and only if the edge between the mapped nodes exists in g2. If g2 has more
+// nodes, only the subgraph induced by the mapped nodes is considered.
+func assertIsomorphism(t *testing.T, name string, g1, g2 *graph.Graph, mapping map[int]int, matchValues bool) {
+	t.Helper()
+
+	if len(mapping) != g1.Len() {
+		t.Errorf("%s: expected mapping to have %d nodes, got %v", name, g1.Len(), mapping)
+		return
+	}
+
+	seen := make(map[int]bool, len(mapping))
+	for n1, n2 := range mapping {
+		if seen[n2] || !g2.HasNode(n2) {
+			t.Errorf("%s: invalid mapping %v", name, mapping)
+			return
+		}
+		seen[n2] = true
+
+		if matchValues && g1.Node(n1).Value != g2.Node(n2).Value {
+			t.Errorf("%s: expected values of %d and %d to be equal in mapping %v", name, n1, n2, mapping)
+		}
+	}
+
+	for s1, s2 := range mapping {
+		for t1, t2 := range mapping {
+			e1, e2 := g1.Edge(s1, t1), g2.Edge(s2, t2)
+			if (e1 == nil) != (e2 == nil) || (matchValues && e1 != nil && e1.Weight != e2.Weight) {
+				t.Errorf("%s: edge %d-%d doesn't match edge %d-%d in mapping %v", name, s1, t1, s2, t2, mapping)
+			}
+		}
+	}
+}
@@ -0,0 +1,675 @@
+package graphs
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/gpahal/go-algos/ds/graph"
+)
+
+// HeldKarpMaxNodes is the maximum number of nodes of a graph supported by HeldKarp. The memory
+// used by the algorithm grows as O(2^n * n), which is about 80MB for 20 nodes.
+const HeldKarpMaxNodes = 20
+
+// christofidesExactMatchingMaxNodes is the maximum number of odd degree nodes for which
+// Christofides finds the minimum weight perfect matching exactly.
+const christofidesExactMatchingMaxNodes = 20
+
+var (
+	// ErrNoTour is returned when a graph doesn't have a tour, or when a heuristic fails to find
+	// one because the graph is not complete.
+	ErrNoTour = errors.New("graphs: no tour found")
+
+	// ErrTooManyNodes is returned by HeldKarp when the graph has more than HeldKarpMaxNodes nodes.
+	ErrTooManyNodes = errors.New("graphs: too many nodes")
+
+	// ErrNodeNotFound is returned when a node id passed as an argument is not in the graph.
+	ErrNodeNotFound = errors.New("graphs: node not found")
+
+	// ErrInvalidTour is returned when a tour passed as an argument doesn't visit every node of the
+	// graph exactly once using the edges of the graph.
+	ErrInvalidTour = errors.New("graphs: invalid tour")
+
+	// ErrNotSymmetric is returned by Christofides when the graph has an edge whose reverse edge
+	// has a different weight.
+	ErrNotSymmetric = errors.New("graphs: graph is not symmetric")
+)
+
+// Tour represents a closed tour of a graph that visits every node exactly once and returns to the
+// first node.
+type Tour struct {
+	// Nodes are the ids of the nodes in the order they are visited. The edge from the last node
+	// back to the first one is implied.
+	Nodes []int
+
+	// Weight is the total weight of the edges of the tour, including the edge from the last node
+	// back to the first one.
+	Weight int
+}
+
+// HeldKarp finds the minimum weight tour of the graph (the traveling salesman problem) using the
+// Held-Karp dynamic programming algorithm, which runs in O(2^n * n^2) time. Edges are directed, so
+// asymmetric instances are supported. The tour starts at the node with the smallest id.
+//
+// If the graph has more than HeldKarpMaxNodes nodes, ErrTooManyNodes is returned. If the graph
+// doesn't have a tour, ErrNoTour is returned. If ctx is done before the tour is found, ctx.Err()
+// is returned.
+func HeldKarp(ctx context.Context, g *graph.Graph) (Tour, error) {
+	inst := newTSPInstance(g)
+	n := len(inst.ids)
+	if n > HeldKarpMaxNodes {
+		return Tour{}, ErrTooManyNodes
+	}
+	if n <= 1 {
+		return inst.tour(inst.identity())
+	}
+
+	// Node 0 is the start of the tour. dp[mask*m+j] is the minimum weight of a path that starts at
+	// node 0, visits exactly the nodes in mask and ends at node j+1, where bit j of mask
+	// represents node j+1.
+	m := n - 1
+	full := 1<<m - 1
+	dp := make([]int, (full+1)*m)
+	for i := range dp {
+		dp[i] = inf
+	}
+	for j := 0; j < m; j++ {
+		dp[(1<<j)*m+j] = inst.cost[0][j+1]
+	}
+
+	for mask := 1; mask <= full; mask++ {
+		if mask&1023 == 0 {
+			if err := ctx.Err(); err != nil {
+				return Tour{}, err
+			}
+		}
+
+		for j := 0; j < m; j++ {
+			curr := dp[mask*m+j]
+			if mask&(1<<j) == 0 || curr == inf {
+				continue
+			}
+
+			// Extend the path ending at j+1 to every node k+1 not in mask.
+			for k := 0; k < m; k++ {
+				if mask&(1<<k) != 0 {
+					continue
+				}
+
+				w := addCost(curr, inst.cost[j+1][k+1])
+				next := (mask|1<<k)*m + k
+				if w < dp[next] {
+					dp[next] = w
+				}
+			}
+		}
+	}
+
+	best, last := inf, -1
+	for j := 0; j < m; j++ {
+		w := addCost(dp[full*m+j], inst.cost[j+1][0])
+		if w < best {
+			best, last = w, j
+		}
+	}
+	if last < 0 {
+		return Tour{}, ErrNoTour
+	}
+
+	// Reconstruct the tour backwards by finding the predecessor that gives the optimal weight.
+	order := make([]int, n)
+	mask := full
+	for pos := n - 1; pos >= 1; pos-- {
+		order[pos] = last + 1
+		prevMask := mask &^ (1 << last)
+		if prevMask == 0 {
+			break
+		}
+
+		for k := 0; k < m; k++ {
+			if prevMask&(1<<k) != 0 && addCost(dp[prevMask*m+k], inst.cost[k+1][last+1]) == dp[mask*m+last] {
+				mask, last = prevMask, k
+				break
+			}
+		}
+	}
+
+	return inst.tour(order)
+}
+
+// NearestNeighbour finds a tour of the graph using the nearest neighbour heuristic, starting at
+// the node with the given id and repeatedly moving to the closest unvisited node. It runs in
+// O(n^2) time and is usually used as the starting point of TwoOpt or OrOpt.
+//
+// If the node doesn't exist, ErrNodeNotFound is returned. If the heuristic gets stuck because the
+// graph is not complete, ErrNoTour is returned.
+func NearestNeighbour(g *graph.Graph, startID int) (Tour, error) {
+	inst := newTSPInstance(g)
+	start, ok := inst.idxs[startID]
+	if !ok {
+		return Tour{}, ErrNodeNotFound
+	}
+
+	n := len(inst.ids)
+	visited := make([]bool, n)
+	order := make([]int, 0, n)
+	curr := start
+	for {
+		visited[curr] = true
+		order = append(order, curr)
+		if len(order) == n {
+			break
+		}
+
+		next := -1
+		for j := 0; j < n; j++ {
+			if !visited[j] && inst.cost[curr][j] != inf && (next < 0 || inst.cost[curr][j] < inst.cost[curr][next]) {
+				next = j
+			}
+		}
+		if next < 0 {
+			return Tour{}, ErrNoTour
+		}
+
+		curr = next
+	}
+
+	return inst.tour(order)
+}
+
+// TwoOpt improves the given tour of the graph using the 2-opt local search. It repeatedly reverses
+// a segment of the tour, replacing two edges of the tour with two new ones, as long as that
+// decreases the weight of the tour. Reversing a segment changes the direction of its edges, which
+// is taken into account for asymmetric instances.
+//
+// If the tour is not valid for the graph, ErrInvalidTour is returned. If ctx is done before the
+// search converges, the best tour found so far is returned along with ctx.Err().
+func TwoOpt(ctx context.Context, g *graph.Graph, tour Tour) (Tour, error) {
+	inst := newTSPInstance(g)
+	order, err := inst.order(tour)
+	if err != nil {
+		return Tour{}, err
+	}
+
+	n := len(order)
+	fwd := make([]int, n+1)
+	bwd := make([]int, n+1)
+	bwdMissing := make([]int, n+1)
+	computePrefixes := func() {
+		// fwd[k] is the weight of the path order[0..k], bwd[k] the weight of the same path in
+		// reverse and bwdMissing[k] the number of missing edges in the reverse path.
+		for p := 0; p < n; p++ {
+			a, b := order[p], order[(p+1)%n]
+			fwd[p+1] = fwd[p] + inst.cost[a][b]
+			bwd[p+1], bwdMissing[p+1] = bwd[p], bwdMissing[p]
+			if inst.cost[b][a] == inf {
+				bwdMissing[p+1]++
+			} else {
+				bwd[p+1] += inst.cost[b][a]
+			}
+		}
+	}
+	computePrefixes()
+
+	improved := true
+	for improved {
+		improved = false
+		for i := 0; i < n-2; i++ {
+			if err := ctx.Err(); err != nil {
+				t, _ := inst.tour(order)
+				return t, err
+			}
+
+			for j := i + 2; j < n; j++ {
+				// Reverse order[i+1..j]: edges (a, b) and (c, d) are replaced by (a, c) and (b, d).
+				a, b, c, d := order[i], order[i+1], order[j], order[(j+1)%n]
+				if inst.cost[a][c] == inf || inst.cost[b][d] == inf ||
+					bwdMissing[j]-bwdMissing[i+1] > 0 {
+					continue
+				}
+
+				delta := inst.cost[a][c] + inst.cost[b][d] + (bwd[j] - bwd[i+1]) -
+					inst.cost[a][b] - inst.cost[c][d] - (fwd[j] - fwd[i+1])
+				if delta < 0 {
+					for l, r := i+1, j; l < r; l, r = l+1, r-1 {
+						order[l], order[r] = order[r], order[l]
+					}
+
+					computePrefixes()
+					improved = true
+				}
+			}
+		}
+	}
+
+	return inst.tour(order)
+}
+
+// OrOpt improves the given tour of the graph using the Or-opt local search. It repeatedly moves a
+// segment of 1 to 3 consecutive nodes of the tour to another position, keeping their order, as
+// long as that decreases the weight of the tour. The first node of the tour is never moved.
+//
+// If the tour is not valid for the graph, ErrInvalidTour is returned. If ctx is done before the
+// search converges, the best tour found so far is returned along with ctx.Err().
+func OrOpt(ctx context.Context, g *graph.Graph, tour Tour) (Tour, error) {
+	inst := newTSPInstance(g)
+	order, err := inst.order(tour)
+	if err != nil {
+		return Tour{}, err
+	}
+
+	n := len(order)
+	improved := true
+	for improved {
+		improved = false
+		for segLen := 1; segLen <= 3; segLen++ {
+			for i := 1; i+segLen <= n; i++ {
+				if err := ctx.Err(); err != nil {
+					t, _ := inst.tour(order)
+					return t, err
+				}
+
+				// Remove order[i..i+segLen-1] from between p and q.
+				first, last := order[i], order[i+segLen-1]
+				p, q := order[i-1], order[(i+segLen)%n]
+				if inst.cost[p][q] == inf || p == q {
+					continue
+				}
+				gain := inst.cost[p][first] + inst.cost[last][q] - inst.cost[p][q]
+
+				// Insert it between order[k] and order[k+1] outside the segment.
+				bestK, bestDelta := -1, 0
+				for k := 0; k < n; k++ {
+					if k >= i-1 && k < i+segLen {
+						continue
+					}
+
+					a, b := order[k], order[(k+1)%n]
+					if inst.cost[a][first] == inf || inst.cost[last][b] == inf {
+						continue
+					}
+
+					delta := inst.cost[a][first] + inst.cost[last][b] - inst.cost[a][b] - gain
+					if delta < bestDelta {
+						bestK, bestDelta = k, delta
+					}
+				}
+				if bestK < 0 {
+					continue
+				}
+
+				order = moveSegment(order, i, segLen, bestK)
+				improved = true
+			}
+		}
+	}
+
+	return inst.tour(order)
+}
+
+// moveSegment returns a new order with order[i..i+segLen-1] moved after order[k].
+func moveSegment(order []int, i, segLen, k int) []int {
+	segment := order[i : i+segLen]
+	newOrder := make([]int, 0, len(order))
+	for p := 0; p < len(order); p++ {
+		if p >= i && p < i+segLen {
+			continue
+		}
+
+		newOrder = append(newOrder, order[p])
+		if p == k {
+			newOrder = append(newOrder, segment...)
+		}
+	}
+
+	return newOrder
+}
+
+// Christofides finds a tour of the graph using the Christofides algorithm. The graph is treated as
+// undirected: every pair of nodes must be connected by an edge in at least one direction, and if
+// both directions exist their weights must be equal. For metric instances, where the weights
+// satisfy the triangle inequality, the weight of the tour is at most 1.5 times the optimal one.
+//
+// The algorithm builds a minimum spanning tree, adds a minimum weight perfect matching of the odd
+// degree nodes of the tree, finds an Euler circuit of the resulting multigraph and shortcuts the
+// nodes that are visited more than once. The matching is found exactly when there are at most 20
+// odd degree nodes. Beyond that, a greedy matching is used and the approximation guarantee no
+// longer holds.
+//
+// If the graph is not complete, ErrNoTour is returned. If the weights are not symmetric,
+// ErrNotSymmetric is returned. If ctx is done before the tour is found, ctx.Err() is returned.
+func Christofides(ctx context.Context, g *graph.Graph) (Tour, error) {
+	inst := newTSPInstance(g)
+	n := len(inst.ids)
+	if n <= 1 {
+		return inst.tour(inst.identity())
+	}
+
+	// Build the undirected cost matrix.
+	cost := make([][]int, n)
+	for i := range cost {
+		cost[i] = make([]int, n)
+		for j := range cost[i] {
+			if i == j {
+				continue
+			}
+
+			w1, w2 := inst.cost[i][j], inst.cost[j][i]
+			switch {
+			case w1 == inf && w2 == inf:
+				return Tour{}, ErrNoTour
+			case w1 != inf && w2 != inf && w1 != w2:
+				return Tour{}, ErrNotSymmetric
+			case w1 == inf:
+				cost[i][j] = w2
+			default:
+				cost[i][j] = w1
+			}
+		}
+	}
+
+	// Minimum spanning tree using Prim's algorithm. adj is the adjacency list of the multigraph
+	// that is later used to find the Euler circuit.
+	adj := make([][]int, n)
+	inTree := make([]bool, n)
+	dist := make([]int, n)
+	parent := make([]int, n)
+	for i := range dist {
+		dist[i] = inf
+	}
+	dist[0] = 0
+	parent[0] = -1
+	for iter := 0; iter < n; iter++ {
+		u := -1
+		for v := 0; v < n; v++ {
+			if !inTree[v] && (u < 0 || dist[v] < dist[u]) {
+				u = v
+			}
+		}
+
+		inTree[u] = true
+		if parent[u] >= 0 {
+			adj[u] = append(adj[u], parent[u])
+			adj[parent[u]] = append(adj[parent[u]], u)
+		}
+		for v := 0; v < n; v++ {
+			if !inTree[v] && cost[u][v] < dist[v] {
+				dist[v] = cost[u][v]
+				parent[v] = u
+			}
+		}
+	}
+
+	// Minimum weight perfect matching of the odd degree nodes. There is always an even number of
+	// them.
+	var odd []int
+	for v := 0; v < n; v++ {
+		if len(adj[v])%2 == 1 {
+			odd = append(odd, v)
+		}
+	}
+
+	pairs, err := minWeightPerfectMatching(ctx, odd, cost)
+	if err != nil {
+		return Tour{}, err
+	}
+	for _, p := range pairs {
+		adj[p[0]] = append(adj[p[0]], p[1])
+		adj[p[1]] = append(adj[p[1]], p[0])
+	}
+
+	// Euler circuit using Hierholzer's algorithm, shortcutting nodes that were already visited.
+	used := make([]map[int]int, n)
+	for v := range used {
+		used[v] = make(map[int]int)
+	}
+	next := make([]int, n)
+	visited := make([]bool, n)
+	order := make([]int, 0, n)
+	stack := []int{0}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		for next[v] < len(adj[v]) && used[v][adj[v][next[v]]] > 0 {
+			// The edge was already traversed from the other end.
+			used[v][adj[v][next[v]]]--
+			next[v]++
+		}
+
+		if next[v] == len(adj[v]) {
+			stack = stack[:len(stack)-1]
+			if !visited[v] {
+				visited[v] = true
+				order = append(order, v)
+			}
+			continue
+		}
+
+		u := adj[v][next[v]]
+		next[v]++
+		used[u][v]++
+		stack = append(stack, u)
+	}
+
+	// Nodes are collected in the reverse order of the circuit, which is also a valid tour. The
+	// first node to be completed is always node 0, where the circuit starts and ends.
+	return inst.undirectedTour(order, cost), nil
+}
+
+// minWeightPerfectMatching returns a minimum weight perfect matching of the given nodes using
+// dynamic programming over subsets if there are at most christofidesExactMatchingMaxNodes nodes,
+// or a greedy matching otherwise.
+func minWeightPerfectMatching(ctx context.Context, nodes []int, cost [][]int) ([][2]int, error) {
+	k := len(nodes)
+	if k == 0 {
+		return nil, nil
+	}
+
+	if k > christofidesExactMatchingMaxNodes {
+		return greedyMatching(nodes, cost), nil
+	}
+
+	// dp[mask] is the minimum weight of a perfect matching of the nodes in mask. The lowest node in
+	// mask is always matched first, so every matching is only considered once.
+	full := 1<<k - 1
+	dp := make([]int, full+1)
+	choice := make([]int, full+1)
+	for mask := 1; mask <= full; mask++ {
+		dp[mask] = inf
+		if mask&1023 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		// Masks with an odd number of nodes can't be matched perfectly.
+		if bitCount(mask)%2 == 1 {
+			continue
+		}
+
+		i := 0
+		for mask&(1<<i) == 0 {
+			i++
+		}
+		for j := i + 1; j < k; j++ {
+			if mask&(1<<j) == 0 {
+				continue
+			}
+
+			w := addCost(dp[mask&^(1<<i|1<<j)], cost[nodes[i]][nodes[j]])
+			if w < dp[mask] {
+				dp[mask] = w
+				choice[mask] = j
+			}
+		}
+	}
+
+	pairs := make([][2]int, 0, k/2)
+	for mask := full; mask != 0; {
+		i := 0
+		for mask&(1<<i) == 0 {
+			i++
+		}
+		j := choice[mask]
+		pairs = append(pairs, [2]int{nodes[i], nodes[j]})
+		mask &^= 1<<i | 1<<j
+	}
+
+	return pairs, nil
+}
+
+// greedyMatching returns a perfect matching of the given nodes by repeatedly matching the two
+// closest unmatched nodes.
+func greedyMatching(nodes []int, cost [][]int) [][2]int {
+	var candidates [][2]int
+	for i := 0; i < len(nodes); i++ {
+		for j := i + 1; j < len(nodes); j++ {
+			candidates = append(candidates, [2]int{nodes[i], nodes[j]})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return cost[candidates[i][0]][candidates[i][1]] < cost[candidates[j][0]][candidates[j][1]]
+	})
+
+	matched := make(map[int]bool, len(nodes))
+	pairs := make([][2]int, 0, len(nodes)/2)
+	for _, c := range candidates {
+		if !matched[c[0]] && !matched[c[1]] {
+			matched[c[0]], matched[c[1]] = true, true
+			pairs = append(pairs, c)
+		}
+	}
+
+	return pairs
+}
+
+func bitCount(mask int) int {
+	count := 0
+	for ; mask != 0; mask &= mask - 1 {
+		count++
+	}
+
+	return count
+}
+
+// inf is the cost of a missing edge.
+const inf = math.MaxInt
+
+// addCost adds two costs, either of which can be inf.
+func addCost(a, b int) int {
+	if a == inf || b == inf {
+		return inf
+	}
+
+	return a + b
+}
+
+// tspInstance is a representation of a graph.Graph as a cost matrix, in which nodes are identified
+// by their index in the sorted slice of node ids.
+type tspInstance struct {
+	ids  []int
+	idxs map[int]int
+	cost [][]int
+}
+
+func newTSPInstance(g *graph.Graph) *tspInstance {
+	ids := sortedNodeIDs(g)
+	inst := &tspInstance{
+		ids:  ids,
+		idxs: make(map[int]int, len(ids)),
+		cost: make([][]int, len(ids)),
+	}
+	for idx, id := range ids {
+		inst.idxs[id] = idx
+		inst.cost[idx] = make([]int, len(ids))
+		for j := range inst.cost[idx] {
+			inst.cost[idx][j] = inf
+		}
+	}
+
+	// Self loops are never part of a tour.
+	g.EachEdge(func(e graph.Edge) bool {
+		if e.SourceID != e.TargetID {
+			inst.cost[inst.idxs[e.SourceID]][inst.idxs[e.TargetID]] = e.Weight
+		}
+		return false
+	})
+
+	return inst
+}
+
+// identity returns the order that visits the nodes in ascending order of their ids.
+func (inst *tspInstance) identity() []int {
+	order := make([]int, len(inst.ids))
+	for i := range order {
+		order[i] = i
+	}
+
+	return order
+}
+
+// tour converts an order of node indices to a Tour. If an edge of the tour is missing, ErrNoTour
+// is returned.
+func (inst *tspInstance) tour(order []int) (Tour, error) {
+	t := Tour{Nodes: make([]int, len(order))}
+	for i, idx := range order {
+		t.Nodes[i] = inst.ids[idx]
+	}
+	if len(order) <= 1 {
+		return t, nil
+	}
+
+	for i, idx := range order {
+		w := inst.cost[idx][order[(i+1)%len(order)]]
+		if w == inf {
+			return Tour{}, ErrNoTour
+		}
+
+		t.Weight += w
+	}
+
+	return t, nil
+}
+
+// undirectedTour converts an order of node indices to a Tour using the given undirected cost
+// matrix.
+func (inst *tspInstance) undirectedTour(order []int, cost [][]int) Tour {
+	t := Tour{Nodes: make([]int, len(order))}
+	for i, idx := range order {
+		t.Nodes[i] = inst.ids[idx]
+		t.Weight += cost[idx][order[(i+1)%len(order)]]
+	}
+
+	return t
+}
+
+// order converts a Tour to an order of node indices. If the tour is not valid, ErrInvalidTour is
+// returned.
+func (inst *tspInstance) order(t Tour) ([]int, error) {
+	if len(t.Nodes) != len(inst.ids) {
+		return nil, ErrInvalidTour
+	}
+
+	order := make([]int, len(t.Nodes))
+	seen := make([]bool, len(inst.ids))
+	for i, id := range t.Nodes {
+		idx, ok := inst.idxs[id]
+		if !ok || seen[idx] {
+			return nil, ErrInvalidTour
+		}
+
+		seen[idx] = true
+		order[i] = idx
+	}
+
+	if len(order) > 1 {
+		for i, idx := range order {
+			if inst.cost[idx][order[(i+1)%len(order)]] == inf {
+				return nil, ErrInvalidTour
+			}
+		}
+	}
+
+	return order, nil
+}
@@ -0,0 +1,255 @@
+package graphs_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gpahal/go-algos/algo/graphs"
+	"github.com/gpahal/go-algos/ds/graph"
+)
+
+func TestHeldKarp(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 1; n <= 7; n++ {
+		g, ids := newCompleteGraph(n, func(i, j int) int { return r.Intn(100) })
+		tour, err := graphs.HeldKarp(context.Background(), g)
+		if err != nil {
+			t.Fatalf("HeldKarp %d: expected no error, got %v", n, err)
+		}
+
+		assertTour(t, "HeldKarp", g, tour)
+		if expected := bruteForceTSP(g, ids); tour.Weight != expected {
+			t.Errorf("HeldKarp %d: expected Weight to be %d, got %d", n, expected, tour.Weight)
+		}
+	}
+
+	g, ids := newGraph([]int{0, 0, 0, 0}, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 3, 1}, {3, 0, 1}, {1, 0, 1}})
+	tour, err := graphs.HeldKarp(context.Background(), g)
+	if err != nil || tour.Weight != 4 || tour.Nodes[0] != ids[0] || tour.Nodes[1] != ids[1] {
+		t.Errorf("HeldKarp: expected the directed cycle with Weight 4, got %v, %v", tour, err)
+	}
+
+	g.DeleteEdge(ids[3], ids[0])
+	if _, err := graphs.HeldKarp(context.Background(), g); err != graphs.ErrNoTour {
+		t.Errorf("HeldKarp: expected ErrNoTour, got %v", err)
+	}
+
+	g, _ = newCompleteGraph(graphs.HeldKarpMaxNodes+1, func(i, j int) int { return 1 })
+	if _, err := graphs.HeldKarp(context.Background(), g); err != graphs.ErrTooManyNodes {
+		t.Errorf("HeldKarp: expected ErrTooManyNodes, got %v", err)
+	}
+
+	g, _ = newCompleteGraph(16, func(i, j int) int { return 1 })
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := graphs.HeldKarp(ctx, g); err != context.Canceled {
+		t.Errorf("HeldKarp: expected context.Canceled, got %v", err)
+	}
+}
+
+func TestNearestNeighbour(t *testing.T) {
+	g, ids := newGraph([]int{0, 0, 0, 0}, [][3]int{
+		{0, 1, 1}, {0, 2, 5}, {0, 3, 9}, {1, 2, 2}, {1, 3, 9}, {2, 3, 3}, {3, 0, 4}, {2, 0, 9},
+	})
+	tour, err := graphs.NearestNeighbour(g, ids[0])
+	if err != nil {
+		t.Fatalf("NearestNeighbour: expected no error, got %v", err)
+	}
+
+	assertTour(t, "NearestNeighbour", g, tour)
+	if tour.Weight != 10 {
+		t.Errorf("NearestNeighbour: expected Weight to be 10, got %d", tour.Weight)
+	}
+
+	if _, err := graphs.NearestNeighbour(g, -1); err != graphs.ErrNodeNotFound {
+		t.Errorf("NearestNeighbour: expected ErrNodeNotFound, got %v", err)
+	}
+
+	g.DeleteEdge(ids[3], ids[0])
+	if _, err := graphs.NearestNeighbour(g, ids[0]); err != graphs.ErrNoTour {
+		t.Errorf("NearestNeighbour: expected ErrNoTour, got %v", err)
+	}
+}
+
+func TestTwoOpt(t *testing.T) {
+	testLocalSearch(t, "TwoOpt", graphs.TwoOpt)
+}
+
+func TestOrOpt(t *testing.T) {
+	testLocalSearch(t, "OrOpt", graphs.OrOpt)
+}
+
+func testLocalSearch(t *testing.T, name string, fn func(context.Context, *graph.Graph, graphs.Tour) (graphs.Tour, error)) {
+	t.Helper()
+
+	r := rand.New(rand.NewSource(2))
+	for iter := 0; iter < 20; iter++ {
+		g, ids := newEuclideanGraph(r, 8)
+		initial, err := graphs.NearestNeighbour(g, ids[0])
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", name, err)
+		}
+
+		tour, err := fn(context.Background(), g, initial)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", name, err)
+		}
+
+		assertTour(t, name, g, tour)
+		if tour.Weight > initial.Weight {
+			t.Errorf("%s: expected Weight to be at most %d, got %d", name, initial.Weight, tour.Weight)
+		}
+		if optimal := bruteForceTSP(g, ids); tour.Weight < optimal {
+			t.Errorf("%s: expected Weight to be at least %d, got %d", name, optimal, tour.Weight)
+		}
+	}
+
+	g, ids := newEuclideanGraph(r, 5)
+	if _, err := fn(context.Background(), g, graphs.Tour{Nodes: ids[1:]}); err != graphs.ErrInvalidTour {
+		t.Errorf("%s: expected ErrInvalidTour, got %v", name, err)
+	}
+
+	initial, _ := graphs.NearestNeighbour(g, ids[0])
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tour, err := fn(ctx, g, initial)
+	if err != context.Canceled {
+		t.Errorf("%s: expected context.Canceled, got %v", name, err)
+	}
+	assertTour(t, name, g, tour)
+}
+
+func TestChristofides(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for iter := 0; iter < 20; iter++ {
+		g, ids := newEuclideanGraph(r, 8)
+		tour, err := graphs.Christofides(context.Background(), g)
+		if err != nil {
+			t.Fatalf("Christofides: expected no error, got %v", err)
+		}
+
+		assertTour(t, "Christofides", g, tour)
+		if optimal := bruteForceTSP(g, ids); 2*tour.Weight > 3*optimal {
+			t.Errorf("Christofides: expected Weight to be at most 1.5 * %d, got %d", optimal, tour.Weight)
+		}
+	}
+
+	g, ids := newEuclideanGraph(r, 4)
+	g.UpdateEdge(ids[0], ids[1], 1000)
+	if _, err := graphs.Christofides(context.Background(), g); err != graphs.ErrNotSymmetric {
+		t.Errorf("Christofides: expected ErrNotSymmetric, got %v", err)
+	}
+
+	g.DeleteEdge(ids[0], ids[1])
+	if _, err := graphs.Christofides(context.Background(), g); err != nil {
+		t.Errorf("Christofides: expected a single edge direction to be enough, got %v", err)
+	}
+
+	g.DeleteEdge(ids[1], ids[0])
+	if _, err := graphs.Christofides(context.Background(), g); err != graphs.ErrNoTour {
+		t.Errorf("Christofides: expected ErrNoTour, got %v", err)
+	}
+}
+
+// newCompleteGraph returns a complete directed graph with n nodes whose edge weights are given by
+// weight.
+func newCompleteGraph(n int, weight func(i, j int) int) (*graph.Graph, []int) {
+	var edges [][3]int
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j {
+				edges = append(edges, [3]int{i, j, weight(i, j)})
+			}
+		}
+	}
+
+	return newGraph(make([]int, n), edges)
+}
+
+// newEuclideanGraph returns a complete symmetric graph of n random points in the plane, whose edge
+// weights are the rounded up distances between them.
+func newEuclideanGraph(r *rand.Rand, n int) (*graph.Graph, []int) {
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	for i := range xs {
+		xs[i], ys[i] = r.Float64()*100, r.Float64()*100
+	}
+
+	return newCompleteGraph(n, func(i, j int) int {
+		return int(math.Ceil(math.Hypot(xs[i]-xs[j], ys[i]-ys[j])))
+	})
+}
+
+// bruteForceTSP returns the weight of the minimum weight tour by trying all the permutations of
+// the nodes.
+func bruteForceTSP(g *graph.Graph, ids []int) int {
+	if len(ids) <= 1 {
+		return 0
+	}
+
+	best := math.MaxInt
+	order := append([]int{}, ids...)
+	var permute func(k int)
+	permute = func(k int) {
+		if k == len(order) {
+			w := 0
+			for i := range order {
+				e := g.Edge(order[i], order[(i+1)%len(order)])
+				if e == nil {
+					return
+				}
+				w += e.Weight
+			}
+			if w < best {
+				best = w
+			}
+			return
+		}
+
+		for i := k; i < len(order); i++ {
+			order[k], order[i] = order[i], order[k]
+			permute(k + 1)
+			order[k], order[i] = order[i], order[k]
+		}
+	}
+	permute(1)
+
+	return best
+}
+
+func assertTour(t *testing.T, name string, g *graph.Graph, tour graphs.Tour) {
+	t.Helper()
+
+	if len(tour.Nodes) != g.Len() {
+		t.Errorf("%s: expected tour to have %d nodes, got %v", name, g.Len(), tour.Nodes)
+		return
+	}
+
+	seen := make(map[int]bool, len(tour.Nodes))
+	weight := 0
+	for i, id := range tour.Nodes {
+		if seen[id] || !g.HasNode(id) {
+			t.Errorf("%s: invalid tour %v", name, tour.Nodes)
+			return
+		}
+		seen[id] = true
+
+		if len(tour.Nodes) > 1 {
+			e := g.Edge(id, tour.Nodes[(i+1)%len(tour.Nodes)])
+			if e == nil {
+				e = g.Edge(tour.Nodes[(i+1)%len(tour.Nodes)], id)
+			}
+			if e == nil {
+				t.Errorf("%s: missing edge in tour %v", name, tour.Nodes)
+				return
+			}
+			weight += e.Weight
+		}
+	}
+
+	if weight != tour.Weight {
+		t.Errorf("%s: expected Weight of tour %v to be %d, got %d", name, tour.Nodes, weight, tour.Weight)
+	}
+}
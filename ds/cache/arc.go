@@ -0,0 +1,258 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/gpahal/go-algos/ds/list"
+)
+
+// ARC represents a cache using the Adaptive Replacement Cache policy of Megiddo and Modha, which
+// balances between recency and frequency depending on the workload.
+//
+// The entries are split into two lists kept in LRU order: t1 holds the entries used once since
+// they were added, and t2 the entries used at least twice. Two ghost lists, b1 and b2, remember the
+// keys, but not the values, of the entries recently evicted from t1 and t2. Setting a key found in
+// b1 means t1 was too small, so the target size p of t1 grows, and setting a key found in b2 means
+// the opposite. An entry is evicted from t1 if it is larger than p, or from t2 otherwise. Unlike
+// LRU, a scan over many keys used once only flushes t1 and leaves the frequently used entries of t2
+// in the cache.
+//
+// Every operation takes O(1) time. A miss on Get doesn't adapt p: it happens when the value is set
+// afterwards.
+type ARC[K comparable, V any] struct {
+	core[K, V]
+
+	// items holds the entries of all the four lists.
+	items map[K]*arcEntry[K, V]
+
+	t1, t2 *list.DoublyLinkedList[*arcEntry[K, V]]
+	b1, b2 *list.DoublyLinkedList[*arcEntry[K, V]]
+
+	// p is the target size of t1.
+	p int
+}
+
+type arcEntry[K comparable, V any] struct {
+	entry[K, V]
+
+	// l is the list holding the entry.
+	l  *list.DoublyLinkedList[*arcEntry[K, V]]
+	el *list.Element[*arcEntry[K, V]]
+}
+
+// NewARC returns a new ARC cache instance holding up to capacity entries. It also remembers the
+// keys of up to capacity recently evicted entries. If capacity is less than 1, 1 is used.
+func NewARC[K comparable, V any](capacity int) Interface[K, V] {
+	return &ARC[K, V]{
+		core:  newCore[K, V](capacity),
+		items: make(map[K]*arcEntry[K, V]),
+		t1:    &list.DoublyLinkedList[*arcEntry[K, V]]{},
+		t2:    &list.DoublyLinkedList[*arcEntry[K, V]]{},
+		b1:    &list.DoublyLinkedList[*arcEntry[K, V]]{},
+		b2:    &list.DoublyLinkedList[*arcEntry[K, V]]{},
+	}
+}
+
+// Len returns the number of entries in the cache, including expired entries that haven't been
+// removed yet.
+func (c *ARC[K, V]) Len() int {
+	return c.t1.Len() + c.t2.Len()
+}
+
+// Empty checks whether the cache is empty.
+func (c *ARC[K, V]) Empty() bool {
+	return c.Len() == 0
+}
+
+// Clear deletes all the entries from the cache and forgets the evicted keys. The eviction callback
+// is called for every entry with the reason EvictDeleted.
+func (c *ARC[K, V]) Clear() {
+	var entries []*arcEntry[K, V]
+	for _, l := range []*list.DoublyLinkedList[*arcEntry[K, V]]{c.t1, c.t2} {
+		for e := range l.All() {
+			entries = append(entries, e)
+		}
+	}
+
+	c.t1.Clear()
+	c.t2.Clear()
+	c.b1.Clear()
+	c.b2.Clear()
+	clear(c.items)
+	c.p = 0
+
+	for _, e := range entries {
+		c.evicted(&e.entry, EvictDeleted)
+	}
+}
+
+// Contains checks whether the cache contains the given key. It doesn't count as an access of the
+// entry and doesn't change the statistics.
+func (c *ARC[K, V]) Contains(key K) bool {
+	e, ok := c.items[key]
+	return ok && c.resident(e) && !c.expired(&e.entry)
+}
+
+// Get returns the value of the given key and counts as an access of the entry, recording a hit. If
+// the key doesn't exist or has expired, it records a miss and the second return value is false.
+func (c *ARC[K, V]) Get(key K) (V, bool) {
+	e := c.live(key)
+	if e == nil {
+		return c.lookup(nil)
+	}
+
+	c.move(e, c.t2)
+	return c.lookup(&e.entry)
+}
+
+// Peek returns the value of the given key like Get, but it doesn't count as an access of the entry
+// and doesn't change the statistics.
+func (c *ARC[K, V]) Peek(key K) (V, bool) {
+	if e, ok := c.items[key]; ok && c.resident(e) && !c.expired(&e.entry) {
+		return e.value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Set sets the value of the given key using the default TTL of the cache, and counts as an access
+// of the entry. If the key is new and the cache is full, an entry is evicted first.
+func (c *ARC[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL sets the value of the given key like Set, but the entry expires after ttl instead of
+// the default TTL. If ttl is not positive, the entry doesn't expire.
+func (c *ARC[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	e, ok := c.items[key]
+	switch {
+	case ok && c.resident(e):
+		c.move(e, c.t2)
+	case ok && e.l == c.b1:
+		c.p = min(c.p+max(c.b2.Len()/c.b1.Len(), 1), c.capacity)
+		c.replace(false)
+		c.move(e, c.t2)
+	case ok:
+		c.p = max(c.p-max(c.b1.Len()/c.b2.Len(), 1), 0)
+		c.replace(true)
+		c.move(e, c.t2)
+	default:
+		if l1 := c.t1.Len() + c.b1.Len(); l1 >= c.capacity {
+			if c.t1.Len() < c.capacity {
+				c.forget(c.b1)
+				c.replace(false)
+			} else {
+				victim := c.t1.Last().Value
+				c.remove(victim, c.evictReason(&victim.entry))
+			}
+		} else if total := l1 + c.t2.Len() + c.b2.Len(); total >= c.capacity {
+			if total >= 2*c.capacity {
+				c.forget(c.b2)
+			}
+			c.replace(false)
+		}
+
+		e = &arcEntry[K, V]{entry: entry[K, V]{key: key}}
+		c.items[key] = e
+		c.move(e, c.t1)
+	}
+
+	e.value, e.expires = value, c.expiry(ttl)
+}
+
+// Delete deletes the given key from the cache. It returns false if the key doesn't exist.
+func (c *ARC[K, V]) Delete(key K) bool {
+	e := c.live(key)
+	if e == nil {
+		return false
+	}
+
+	c.remove(e, EvictDeleted)
+	return true
+}
+
+// Purge removes all the expired entries from the cache and returns their number.
+func (c *ARC[K, V]) Purge() int {
+	count := 0
+	for _, l := range []*list.DoublyLinkedList[*arcEntry[K, V]]{c.t1, c.t2} {
+		for e := range l.All() {
+			if c.expired(&e.entry) {
+				c.remove(e, EvictExpired)
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// resident checks whether e holds a value, rather than being a ghost entry.
+func (c *ARC[K, V]) resident(e *arcEntry[K, V]) bool {
+	return e.l == c.t1 || e.l == c.t2
+}
+
+// live returns the resident entry of the given key, or nil if the key doesn't exist or is a ghost.
+// An expired entry is removed and nil is returned.
+func (c *ARC[K, V]) live(key K) *arcEntry[K, V] {
+	e, ok := c.items[key]
+	if !ok || !c.resident(e) {
+		return nil
+	}
+	if c.expired(&e.entry) {
+		c.remove(e, EvictExpired)
+		return nil
+	}
+
+	return e
+}
+
+// replace evicts an entry from t1 or t2 to make room for a new one, remembering its key in the
+// matching ghost list. The entry is evicted from t1 if t1 is larger than its target size p, or as
+// large as p when the new entry was found in b2. Nothing is evicted if the cache isn't full, which
+// happens when entries have been deleted or have expired.
+func (c *ARC[K, V]) replace(inB2 bool) {
+	if c.Len() < c.capacity {
+		return
+	}
+
+	from, to := c.t2, c.b2
+	if t1Len := c.t1.Len(); (t1Len > 0 && (t1Len > c.p || (inB2 && t1Len == c.p))) || c.t2.Empty() {
+		from, to = c.t1, c.b1
+	}
+
+	victim := from.Last().Value
+	reason := c.evictReason(&victim.entry)
+	c.move(victim, to)
+	c.evicted(&victim.entry, reason)
+
+	var zero V
+	victim.value, victim.expires = zero, time.Time{}
+}
+
+// forget removes the least recently evicted key from the ghost list l.
+func (c *ARC[K, V]) forget(l *list.DoublyLinkedList[*arcEntry[K, V]]) {
+	if e := l.Last(); e != nil {
+		l.Remove(e)
+		delete(c.items, e.Value.key)
+	}
+}
+
+// move moves e to the front of the list to, as its most recently used entry.
+func (c *ARC[K, V]) move(e *arcEntry[K, V], to *list.DoublyLinkedList[*arcEntry[K, V]]) {
+	if e.l != nil {
+		e.l.Remove(e.el)
+	}
+
+	to.PushFront(e)
+	e.l, e.el = to, to.First()
+}
+
+// remove removes the resident entry e from the cache for the given reason, without remembering
+// its key.
+func (c *ARC[K, V]) remove(e *arcEntry[K, V], reason EvictReason) {
+	e.l.Remove(e.el)
+	e.l, e.el = nil, nil
+	delete(c.items, e.key)
+	c.evicted(&e.entry, reason)
+}
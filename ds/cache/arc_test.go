@@ -0,0 +1,46 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/cache"
+)
+
+func TestARC(t *testing.T) {
+	testInterfaceHelper(t, cache.NewARC[int, string])
+}
+
+func TestARC_ScanResistance(t *testing.T) {
+	newCache := cache.NewARC[int, string](4)
+	for _, key := range []int{1, 2} {
+		newCache.Set(key, "hot")
+		newCache.Get(key)
+	}
+
+	// A scan of keys used only once evicts from the recency list and leaves the hot keys alone,
+	// where an LRU cache would lose them.
+	for key := 100; key < 120; key++ {
+		newCache.Set(key, "cold")
+	}
+	if !newCache.Contains(1) || !newCache.Contains(2) {
+		t.Error("ScanResistance: expected the frequently used keys to survive a scan")
+	}
+}
+
+func TestARC_Adaptation(t *testing.T) {
+	newCache := cache.NewARC[int, string](2)
+	newCache.Set(1, "a")
+	newCache.Get(1)
+	newCache.Set(2, "b")
+	newCache.Set(3, "c")
+	if newCache.Contains(2) || !newCache.Contains(1) {
+		t.Error("Adaptation: expected key 2 used once to be evicted before the frequently used key 1")
+	}
+
+	// Setting the evicted key 2 again is a hit in the ghost list of keys used once, which brings
+	// it back as a frequently used key.
+	newCache.Set(2, "b")
+	if !newCache.Contains(2) || newCache.Len() != 2 {
+		t.Errorf("Adaptation: expected key 2 to be back in the cache, got Len %d", newCache.Len())
+	}
+}
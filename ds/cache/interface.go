@@ -0,0 +1,237 @@
+package cache
+
+import "time"
+
+// Interface is the interface that groups the basic methods of a bounded cache implementation. A
+// cache holds up to Cap entries and evicts entries, chosen by its eviction policy, to make room for
+// new ones.
+//
+// Entries can also expire after a time-to-live (TTL). Expired entries are removed lazily: they are
+// never returned, but they count towards Len until they are accessed, evicted or removed by Purge.
+type Interface[K comparable, V any] interface {
+	// Len returns the number of entries in the cache, including expired entries that haven't been
+	// removed yet.
+	Len() int
+
+	// Cap returns the maximum number of entries in the cache.
+	Cap() int
+
+	// Empty checks whether the cache is empty.
+	Empty() bool
+
+	// Clear deletes all the entries from the cache. The eviction callback is called for every
+	// entry with the reason EvictDeleted.
+	Clear()
+
+	// Contains checks whether the cache contains the given key. It doesn't count as an access of
+	// the entry and doesn't change the statistics.
+	Contains(key K) bool
+
+	// Get returns the value of the given key and counts as an access of the entry, recording a hit.
+	// If the key doesn't exist or has expired, it records a miss and the second return value is
+	// false.
+	Get(key K) (V, bool)
+
+	// Peek returns the value of the given key like Get, but it doesn't count as an access of the
+	// entry and doesn't change the statistics.
+	Peek(key K) (V, bool)
+
+	// Set sets the value of the given key using the default TTL of the cache, and counts as an
+	// access of the entry. If the key is new and the cache is full, an entry is evicted first.
+	Set(key K, value V)
+
+	// SetWithTTL sets the value of the given key like Set, but the entry expires after ttl instead
+	// of the default TTL. If ttl is not positive, the entry doesn't expire.
+	SetWithTTL(key K, value V, ttl time.Duration)
+
+	// Delete deletes the given key from the cache. It returns false if the key doesn't exist.
+	Delete(key K) bool
+
+	// Purge removes all the expired entries from the cache and returns their number.
+	Purge() int
+
+	// Stats returns the statistics of the cache.
+	Stats() Stats
+
+	// ResetStats resets the statistics of the cache to zero.
+	ResetStats()
+
+	// SetTTL sets the default TTL of the entries set later. If ttl is not positive, which is the
+	// default, the entries don't expire.
+	SetTTL(ttl time.Duration)
+
+	// OnEvict sets the function called with every entry that leaves the cache, except for old
+	// values replaced by Set. It is called after the entry has been removed, and must not call the
+	// methods of the cache. A nil fn removes the callback.
+	OnEvict(fn EvictFunc[K, V])
+
+	// SetClock sets the function used to get the current time for expiry, which is time.Now by
+	// default. It is mainly useful for testing.
+	SetClock(now func() time.Time)
+}
+
+// EvictReason is the reason why an entry left the cache.
+type EvictReason int
+
+const (
+	// EvictCapacity means the entry was evicted to make room for a new entry.
+	EvictCapacity EvictReason = iota
+
+	// EvictExpired means the entry was removed because its TTL had passed.
+	EvictExpired
+
+	// EvictDeleted means the entry was deleted using Delete or Clear.
+	EvictDeleted
+)
+
+// String returns the name of the reason.
+func (r EvictReason) String() string {
+	switch r {
+	case EvictCapacity:
+		return "capacity"
+	case EvictExpired:
+		return "expired"
+	case EvictDeleted:
+		return "deleted"
+	default:
+		return "unknown"
+	}
+}
+
+// EvictFunc is a function called with an entry that left a cache and the reason why.
+type EvictFunc[K comparable, V any] func(key K, value V, reason EvictReason)
+
+// Stats are the statistics of a cache.
+type Stats struct {
+	// Hits is the number of calls to Get that found the key.
+	Hits uint64
+
+	// Misses is the number of calls to Get that didn't find the key or found it expired.
+	Misses uint64
+
+	// Evictions is the number of entries evicted to make room for new entries.
+	Evictions uint64
+
+	// Expirations is the number of entries removed because their TTL had passed.
+	Expirations uint64
+}
+
+// HitRatio returns the ratio of hits to all the calls to Get, or 0 if there were none.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(total)
+}
+
+// entry is an entry of a cache.
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+
+	// expires is the time at which the entry expires, or the zero time if it doesn't.
+	expires time.Time
+}
+
+// core holds the configuration and the statistics shared by all the cache implementations.
+type core[K comparable, V any] struct {
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+	onEvict  EvictFunc[K, V]
+	stats    Stats
+}
+
+func newCore[K comparable, V any](capacity int) core[K, V] {
+	return core[K, V]{capacity: max(capacity, 1), now: time.Now}
+}
+
+// Cap returns the maximum number of entries in the cache.
+func (c *core[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Stats returns the statistics of the cache.
+func (c *core[K, V]) Stats() Stats {
+	return c.stats
+}
+
+// ResetStats resets the statistics of the cache to zero.
+func (c *core[K, V]) ResetStats() {
+	c.stats = Stats{}
+}
+
+// SetTTL sets the default TTL of the entries set later. If ttl is not positive, which is the
+// default, the entries don't expire.
+func (c *core[K, V]) SetTTL(ttl time.Duration) {
+	c.ttl = ttl
+}
+
+// OnEvict sets the function called with every entry that leaves the cache, except for old values
+// replaced by Set. It is called after the entry has been removed, and must not call the methods of
+// the cache. A nil fn removes the callback.
+func (c *core[K, V]) OnEvict(fn EvictFunc[K, V]) {
+	c.onEvict = fn
+}
+
+// SetClock sets the function used to get the current time for expiry, which is time.Now by
+// default. It is mainly useful for testing.
+func (c *core[K, V]) SetClock(now func() time.Time) {
+	if now == nil {
+		now = time.Now
+	}
+
+	c.now = now
+}
+
+// expiry returns the expiry time of an entry set now with the given TTL.
+func (c *core[K, V]) expiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+
+	return c.now().Add(ttl)
+}
+
+// expired checks whether e has expired.
+func (c *core[K, V]) expired(e *entry[K, V]) bool {
+	return !e.expires.IsZero() && !c.now().Before(e.expires)
+}
+
+// evictReason returns the reason for evicting e to make room for a new entry, which is
+// EvictExpired if it happens to have expired already.
+func (c *core[K, V]) evictReason(e *entry[K, V]) EvictReason {
+	if c.expired(e) {
+		return EvictExpired
+	}
+
+	return EvictCapacity
+}
+
+// evicted records that e left the cache for the given reason and calls the eviction callback.
+func (c *core[K, V]) evicted(e *entry[K, V], reason EvictReason) {
+	switch reason {
+	case EvictCapacity:
+		c.stats.Evictions++
+	case EvictExpired:
+		c.stats.Expirations++
+	}
+
+	if c.onEvict != nil {
+		c.onEvict(e.key, e.value, reason)
+	}
+}
+
+// lookup records a hit if e is not nil, or a miss otherwise.
+func (c *core[K, V]) lookup(e *entry[K, V]) (V, bool) {
+	if e == nil {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+
+	c.stats.Hits++
+	return e.value, true
+}
@@ -0,0 +1,217 @@
+package cache_test
+
+import (
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gpahal/go-algos/ds/cache"
+)
+
+type eviction struct {
+	key    int
+	reason cache.EvictReason
+}
+
+func testInterfaceHelper(t *testing.T, newFn func(capacity int) cache.Interface[int, string]) {
+	t.Run("New", func(t *testing.T) {
+		newCache := newFn(3)
+		if newCache.Cap() != 3 || newCache.Len() != 0 || !newCache.Empty() {
+			t.Errorf("New 3: expected Cap, Len and Empty to be 3, 0 and true, got %d, %d and %v", newCache.Cap(), newCache.Len(), newCache.Empty())
+		}
+		if newCache = newFn(0); newCache.Cap() != 1 {
+			t.Errorf("New 0: expected Cap to be 1, got %d", newCache.Cap())
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		newCache := newFn(3)
+		newCache.Set(1, "a")
+		newCache.Set(2, "b")
+		newCache.Set(1, "c")
+		if v, ok := newCache.Get(1); !ok || v != "c" {
+			t.Errorf("Get 1: expected Get to return c, true, got %s, %v", v, ok)
+		}
+		if v, ok := newCache.Get(3); ok {
+			t.Errorf("Get 3: expected Get to return false, got %s, true", v)
+		}
+		if newCache.Len() != 2 || newCache.Empty() {
+			t.Errorf("Get: expected Len to be 2, got %d", newCache.Len())
+		}
+	})
+
+	t.Run("Peek", func(t *testing.T) {
+		newCache := newFn(3)
+		newCache.Set(1, "a")
+		if v, ok := newCache.Peek(1); !ok || v != "a" {
+			t.Errorf("Peek 1: expected Peek to return a, true, got %s, %v", v, ok)
+		}
+		if _, ok := newCache.Peek(2); ok {
+			t.Error("Peek 2: expected Peek to return false, got true")
+		}
+		if stats := newCache.Stats(); stats != (cache.Stats{}) {
+			t.Errorf("Peek: expected Stats to be unchanged, got %+v", stats)
+		}
+	})
+
+	t.Run("Contains", func(t *testing.T) {
+		newCache := newFn(3)
+		newCache.Set(1, "a")
+		if !newCache.Contains(1) || newCache.Contains(2) {
+			t.Errorf("Contains: expected Contains 1 and 2 to be true and false, got %v and %v", newCache.Contains(1), newCache.Contains(2))
+		}
+	})
+
+	t.Run("Capacity", func(t *testing.T) {
+		newCache := newFn(3)
+		var evictions []eviction
+		newCache.OnEvict(func(key int, value string, reason cache.EvictReason) {
+			evictions = append(evictions, eviction{key, reason})
+		})
+
+		for i := 0; i < 10; i++ {
+			newCache.Set(i, "a")
+			if newCache.Len() > 3 {
+				t.Fatalf("Capacity: expected Len to be at most 3, got %d", newCache.Len())
+			}
+		}
+		if newCache.Len() != 3 || !newCache.Contains(9) {
+			t.Errorf("Capacity: expected Len to be 3 with the last key, got %d", newCache.Len())
+		}
+		if len(evictions) != 7 || newCache.Stats().Evictions != 7 {
+			t.Errorf("Capacity: expected 7 evictions, got %v and Stats %+v", evictions, newCache.Stats())
+		}
+		for _, ev := range evictions {
+			if ev.reason != cache.EvictCapacity {
+				t.Errorf("Capacity: expected reason to be capacity, got %v", ev.reason)
+			}
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		newCache := newFn(3)
+		var evictions []eviction
+		newCache.OnEvict(func(key int, value string, reason cache.EvictReason) {
+			evictions = append(evictions, eviction{key, reason})
+		})
+
+		newCache.Set(1, "a")
+		newCache.Set(2, "b")
+		if !newCache.Delete(1) {
+			t.Error("Delete 1: expected Delete to return true, got false")
+		}
+		if newCache.Delete(1) {
+			t.Error("Delete 1: expected Delete to return false, got true")
+		}
+		if newCache.Contains(1) || newCache.Len() != 1 {
+			t.Errorf("Delete 1: expected key to be deleted, got Len %d", newCache.Len())
+		}
+		if !slices.Equal(evictions, []eviction{{1, cache.EvictDeleted}}) {
+			t.Errorf("Delete 1: expected evictions to be [{1 deleted}], got %v", evictions)
+		}
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		newCache := newFn(3)
+		count := 0
+		newCache.OnEvict(func(key int, value string, reason cache.EvictReason) {
+			if reason == cache.EvictDeleted {
+				count++
+			}
+		})
+
+		newCache.Set(1, "a")
+		newCache.Set(2, "b")
+		newCache.Clear()
+		if !newCache.Empty() || newCache.Contains(1) || count != 2 {
+			t.Errorf("Clear: expected cache to be empty after 2 evictions, got Len %d and %d evictions", newCache.Len(), count)
+		}
+
+		newCache.Set(3, "c")
+		if v, ok := newCache.Get(3); !ok || v != "c" {
+			t.Errorf("Clear: expected Get 3 after Clear to return c, true, got %s, %v", v, ok)
+		}
+	})
+
+	t.Run("TTL", func(t *testing.T) {
+		newCache := newFn(3)
+		now := time.Unix(0, 0)
+		newCache.SetClock(func() time.Time { return now })
+		var evictions []eviction
+		newCache.OnEvict(func(key int, value string, reason cache.EvictReason) {
+			evictions = append(evictions, eviction{key, reason})
+		})
+
+		newCache.SetTTL(time.Minute)
+		newCache.Set(1, "a")
+		newCache.SetWithTTL(2, "b", time.Hour)
+		newCache.SetWithTTL(3, "c", 0)
+
+		now = now.Add(time.Minute)
+		if _, ok := newCache.Get(1); ok {
+			t.Error("TTL: expected Get of an expired key to return false, got true")
+		}
+		if !newCache.Contains(2) || !newCache.Contains(3) {
+			t.Error("TTL: expected keys that haven't expired to be in the cache")
+		}
+		if !slices.Equal(evictions, []eviction{{1, cache.EvictExpired}}) {
+			t.Errorf("TTL: expected evictions to be [{1 expired}], got %v", evictions)
+		}
+
+		now = now.Add(time.Hour)
+		if newCache.Contains(2) || newCache.Len() != 2 {
+			t.Errorf("TTL: expected an expired key to be hidden but counted, got Len %d", newCache.Len())
+		}
+		if n := newCache.Purge(); n != 1 || newCache.Len() != 1 {
+			t.Errorf("TTL: expected Purge to remove 1 key leaving 1, got %d leaving %d", n, newCache.Len())
+		}
+		if stats := newCache.Stats(); stats.Expirations != 2 {
+			t.Errorf("TTL: expected Expirations to be 2, got %d", stats.Expirations)
+		}
+	})
+
+	t.Run("Stats", func(t *testing.T) {
+		newCache := newFn(3)
+		newCache.Set(1, "a")
+		newCache.Get(1)
+		newCache.Get(1)
+		newCache.Get(2)
+		stats := newCache.Stats()
+		if stats.Hits != 2 || stats.Misses != 1 || stats.HitRatio() != 2.0/3 {
+			t.Errorf("Stats: expected 2 hits and 1 miss, got %+v with HitRatio %f", stats, stats.HitRatio())
+		}
+
+		newCache.ResetStats()
+		if stats := newCache.Stats(); stats != (cache.Stats{}) || stats.HitRatio() != 0 {
+			t.Errorf("ResetStats: expected Stats to be zero, got %+v", stats)
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		newCache := newFn(16)
+		expected := make(map[int]string)
+		for n := 0; n < 5000; n++ {
+			key := r.Intn(48)
+			switch op := r.Intn(10); {
+			case op < 5:
+				value := strconv.Itoa(n)
+				newCache.Set(key, value)
+				expected[key] = value
+			case op < 9:
+				if v, ok := newCache.Get(key); ok && v != expected[key] {
+					t.Fatalf("Random: expected Get %d to return %s, got %s", key, expected[key], v)
+				}
+			default:
+				newCache.Delete(key)
+				delete(expected, key)
+			}
+
+			if newCache.Len() > 16 {
+				t.Fatalf("Random: expected Len to be at most 16, got %d", newCache.Len())
+			}
+		}
+	})
+}
@@ -0,0 +1,221 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/gpahal/go-algos/ds/list"
+)
+
+// LFU represents a cache that evicts the least frequently used entry, and among entries used
+// equally often, the least recently used one. The frequency of an entry is the number of times it
+// has been set or got since it was added.
+//
+// Every operation takes O(1) time. The entries are grouped into buckets by frequency, and the
+// buckets are kept in a list.DoublyLinkedList in ascending order of frequency, with only the
+// frequencies that have entries. An access moves an entry to the bucket of the next frequency,
+// which is either the next bucket or a new one inserted after its bucket, and the entry to evict
+// is always the last entry of the first bucket.
+type LFU[K comparable, V any] struct {
+	core[K, V]
+
+	items   map[K]*lfuEntry[K, V]
+	buckets *list.DoublyLinkedList[*lfuBucket[K, V]]
+}
+
+// lfuBucket holds the entries of an LFU cache with the same frequency from the most to the least
+// recently used.
+type lfuBucket[K comparable, V any] struct {
+	freq    int
+	entries *list.DoublyLinkedList[*lfuEntry[K, V]]
+}
+
+type lfuEntry[K comparable, V any] struct {
+	entry[K, V]
+
+	bucket *list.Element[*lfuBucket[K, V]]
+	el     *list.Element[*lfuEntry[K, V]]
+}
+
+// NewLFU returns a new LFU cache instance holding up to capacity entries. If capacity is less than
+// 1, 1 is used.
+func NewLFU[K comparable, V any](capacity int) Interface[K, V] {
+	return &LFU[K, V]{
+		core:    newCore[K, V](capacity),
+		items:   make(map[K]*lfuEntry[K, V]),
+		buckets: &list.DoublyLinkedList[*lfuBucket[K, V]]{},
+	}
+}
+
+// Len returns the number of entries in the cache, including expired entries that haven't been
+// removed yet.
+func (c *LFU[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Empty checks whether the cache is empty.
+func (c *LFU[K, V]) Empty() bool {
+	return len(c.items) == 0
+}
+
+// Clear deletes all the entries from the cache. The eviction callback is called for every entry
+// with the reason EvictDeleted.
+func (c *LFU[K, V]) Clear() {
+	var entries []*lfuEntry[K, V]
+	for b := range c.buckets.All() {
+		for e := range b.entries.All() {
+			entries = append(entries, e)
+		}
+	}
+
+	c.buckets.Clear()
+	clear(c.items)
+	for _, e := range entries {
+		c.evicted(&e.entry, EvictDeleted)
+	}
+}
+
+// Contains checks whether the cache contains the given key. It doesn't count as an access of the
+// entry and doesn't change the statistics.
+func (c *LFU[K, V]) Contains(key K) bool {
+	e, ok := c.items[key]
+	return ok && !c.expired(&e.entry)
+}
+
+// Get returns the value of the given key and counts as an access of the entry, recording a hit. If
+// the key doesn't exist or has expired, it records a miss and the second return value is false.
+func (c *LFU[K, V]) Get(key K) (V, bool) {
+	e := c.live(key)
+	if e == nil {
+		return c.lookup(nil)
+	}
+
+	c.touch(e)
+	return c.lookup(&e.entry)
+}
+
+// Peek returns the value of the given key like Get, but it doesn't count as an access of the entry
+// and doesn't change the statistics.
+func (c *LFU[K, V]) Peek(key K) (V, bool) {
+	if e, ok := c.items[key]; ok && !c.expired(&e.entry) {
+		return e.value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Set sets the value of the given key using the default TTL of the cache, and counts as an access
+// of the entry. If the key is new and the cache is full, the least frequently used entry is evicted
+// first.
+func (c *LFU[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL sets the value of the given key like Set, but the entry expires after ttl instead of
+// the default TTL. If ttl is not positive, the entry doesn't expire.
+func (c *LFU[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	if e, ok := c.items[key]; ok {
+		e.value, e.expires = value, c.expiry(ttl)
+		c.touch(e)
+		return
+	}
+
+	if len(c.items) >= c.capacity {
+		victim := c.buckets.First().Value.entries.Last().Value
+		c.remove(victim, c.evictReason(&victim.entry))
+	}
+
+	first := c.buckets.First()
+	if first == nil || first.Value.freq != 1 {
+		c.buckets.PushFront(newLFUBucket[K, V](1))
+		first = c.buckets.First()
+	}
+
+	e := &lfuEntry[K, V]{entry: entry[K, V]{key: key, value: value, expires: c.expiry(ttl)}}
+	c.link(e, first)
+	c.items[key] = e
+}
+
+// Delete deletes the given key from the cache. It returns false if the key doesn't exist.
+func (c *LFU[K, V]) Delete(key K) bool {
+	e := c.live(key)
+	if e == nil {
+		return false
+	}
+
+	c.remove(e, EvictDeleted)
+	return true
+}
+
+// Purge removes all the expired entries from the cache and returns their number.
+func (c *LFU[K, V]) Purge() int {
+	count := 0
+	for b := range c.buckets.All() {
+		for e := range b.entries.All() {
+			if c.expired(&e.entry) {
+				c.remove(e, EvictExpired)
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+func newLFUBucket[K comparable, V any](freq int) *lfuBucket[K, V] {
+	return &lfuBucket[K, V]{freq: freq, entries: &list.DoublyLinkedList[*lfuEntry[K, V]]{}}
+}
+
+// live returns the entry of the given key, or nil if the key doesn't exist. An expired entry is
+// removed and nil is returned.
+func (c *LFU[K, V]) live(key K) *lfuEntry[K, V] {
+	e, ok := c.items[key]
+	if !ok {
+		return nil
+	}
+	if c.expired(&e.entry) {
+		c.remove(e, EvictExpired)
+		return nil
+	}
+
+	return e
+}
+
+// touch moves e to the bucket of the next frequency, creating it if needed.
+func (c *LFU[K, V]) touch(e *lfuEntry[K, V]) {
+	bucket := e.bucket
+	freq := bucket.Value.freq + 1
+
+	next := bucket.Next
+	if next == nil || next.Value.freq != freq {
+		next = c.buckets.InsertAfter(bucket, newLFUBucket[K, V](freq))
+	}
+
+	c.unlink(e)
+	c.link(e, next)
+}
+
+// link adds e as the most recently used entry of bucket.
+func (c *LFU[K, V]) link(e *lfuEntry[K, V], bucket *list.Element[*lfuBucket[K, V]]) {
+	entries := bucket.Value.entries
+	entries.PushFront(e)
+	e.bucket, e.el = bucket, entries.First()
+}
+
+// unlink removes e from its bucket, removing the bucket too if it becomes empty.
+func (c *LFU[K, V]) unlink(e *lfuEntry[K, V]) {
+	entries := e.bucket.Value.entries
+	entries.Remove(e.el)
+	if entries.Empty() {
+		c.buckets.Remove(e.bucket)
+	}
+
+	e.bucket, e.el = nil, nil
+}
+
+// remove removes e from the cache for the given reason.
+func (c *LFU[K, V]) remove(e *lfuEntry[K, V], reason EvictReason) {
+	c.unlink(e)
+	delete(c.items, e.key)
+	c.evicted(&e.entry, reason)
+}
@@ -0,0 +1,31 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/cache"
+)
+
+func TestLFU(t *testing.T) {
+	testInterfaceHelper(t, cache.NewLFU[int, string])
+}
+
+func TestLFU_Eviction(t *testing.T) {
+	newCache := cache.NewLFU[int, string](3)
+	newCache.Set(1, "a")
+	newCache.Set(2, "b")
+	newCache.Set(3, "c")
+	newCache.Get(1)
+	newCache.Get(1)
+	newCache.Get(2)
+	newCache.Get(3)
+	newCache.Set(4, "d")
+	if newCache.Contains(2) || !newCache.Contains(1) || !newCache.Contains(3) {
+		t.Error("Eviction: expected the least recently used of the least frequently used keys, 2, to be evicted")
+	}
+
+	newCache.Set(5, "e")
+	if newCache.Contains(4) || !newCache.Contains(3) {
+		t.Error("Eviction: expected the new key 4, used only once, to be evicted")
+	}
+}
@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"slices"
+	"time"
+
+	"github.com/gpahal/go-algos/ds/list"
+)
+
+// LRU represents a cache that evicts the least recently used entry. The entries are kept in a
+// list.DoublyLinkedList from the most to the least recently used, so every operation takes O(1)
+// time.
+type LRU[K comparable, V any] struct {
+	core[K, V]
+
+	items map[K]*list.Element[*entry[K, V]]
+	l     *list.DoublyLinkedList[*entry[K, V]]
+}
+
+// NewLRU returns a new LRU cache instance holding up to capacity entries. If capacity is less than
+// 1, 1 is used.
+func NewLRU[K comparable, V any](capacity int) Interface[K, V] {
+	return &LRU[K, V]{
+		core:  newCore[K, V](capacity),
+		items: make(map[K]*list.Element[*entry[K, V]]),
+		l:     &list.DoublyLinkedList[*entry[K, V]]{},
+	}
+}
+
+// Len returns the number of entries in the cache, including expired entries that haven't been
+// removed yet.
+func (c *LRU[K, V]) Len() int {
+	return c.l.Len()
+}
+
+// Empty checks whether the cache is empty.
+func (c *LRU[K, V]) Empty() bool {
+	return c.l.Empty()
+}
+
+// Clear deletes all the entries from the cache. The eviction callback is called for every entry
+// with the reason EvictDeleted.
+func (c *LRU[K, V]) Clear() {
+	entries := slices.Collect(c.l.All())
+	c.l.Clear()
+	clear(c.items)
+
+	for _, e := range entries {
+		c.evicted(e, EvictDeleted)
+	}
+}
+
+// Contains checks whether the cache contains the given key. It doesn't count as an access of the
+// entry and doesn't change the statistics.
+func (c *LRU[K, V]) Contains(key K) bool {
+	el, ok := c.items[key]
+	return ok && !c.expired(el.Value)
+}
+
+// Get returns the value of the given key and counts as an access of the entry, recording a hit. If
+// the key doesn't exist or has expired, it records a miss and the second return value is false.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	el := c.live(key)
+	if el == nil {
+		return c.lookup(nil)
+	}
+
+	c.l.MoveToFront(el)
+	return c.lookup(el.Value)
+}
+
+// Peek returns the value of the given key like Get, but it doesn't count as an access of the entry
+// and doesn't change the statistics.
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	if el, ok := c.items[key]; ok && !c.expired(el.Value) {
+		return el.Value.value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Set sets the value of the given key using the default TTL of the cache, and counts as an access
+// of the entry. If the key is new and the cache is full, the least recently used entry is evicted
+// first.
+func (c *LRU[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL sets the value of the given key like Set, but the entry expires after ttl instead of
+// the default TTL. If ttl is not positive, the entry doesn't expire.
+func (c *LRU[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	if el, ok := c.items[key]; ok {
+		el.Value.value, el.Value.expires = value, c.expiry(ttl)
+		c.l.MoveToFront(el)
+		return
+	}
+
+	if c.l.Len() >= c.capacity {
+		last := c.l.Last()
+		c.remove(last, c.evictReason(last.Value))
+	}
+
+	c.l.PushFront(&entry[K, V]{key: key, value: value, expires: c.expiry(ttl)})
+	c.items[key] = c.l.First()
+}
+
+// Delete deletes the given key from the cache. It returns false if the key doesn't exist.
+func (c *LRU[K, V]) Delete(key K) bool {
+	el := c.live(key)
+	if el == nil {
+		return false
+	}
+
+	c.remove(el, EvictDeleted)
+	return true
+}
+
+// Purge removes all the expired entries from the cache and returns their number.
+func (c *LRU[K, V]) Purge() int {
+	count := 0
+	for e := range c.l.All() {
+		if c.expired(e) {
+			c.remove(c.items[e.key], EvictExpired)
+			count++
+		}
+	}
+
+	return count
+}
+
+// live returns the element of the given key, or nil if the key doesn't exist. An expired entry is
+// removed and nil is returned.
+func (c *LRU[K, V]) live(key K) *list.Element[*entry[K, V]] {
+	el, ok := c.items[key]
+	if !ok {
+		return nil
+	}
+	if c.expired(el.Value) {
+		c.remove(el, EvictExpired)
+		return nil
+	}
+
+	return el
+}
+
+// remove removes el from the cache for the given reason.
+func (c *LRU[K, V]) remove(el *list.Element[*entry[K, V]], reason EvictReason) {
+	c.l.Remove(el)
+	delete(c.items, el.Value.key)
+	c.evicted(el.Value, reason)
+}
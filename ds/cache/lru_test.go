@@ -0,0 +1,30 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/cache"
+)
+
+func TestLRU(t *testing.T) {
+	testInterfaceHelper(t, cache.NewLRU[int, string])
+}
+
+func TestLRU_Eviction(t *testing.T) {
+	newCache := cache.NewLRU[int, string](3)
+	newCache.Set(1, "a")
+	newCache.Set(2, "b")
+	newCache.Set(3, "c")
+	newCache.Get(1)
+	newCache.Peek(2)
+	newCache.Set(4, "d")
+	if newCache.Contains(2) || !newCache.Contains(1) || !newCache.Contains(3) {
+		t.Error("Eviction: expected the least recently used key 2 to be evicted")
+	}
+
+	newCache.Set(3, "e")
+	newCache.Set(5, "f")
+	if newCache.Contains(1) || !newCache.Contains(3) {
+		t.Error("Eviction: expected Set to count as an access and key 1 to be evicted")
+	}
+}
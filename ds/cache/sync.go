@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Sync represents a cache that is safe for concurrent use by multiple goroutines. It wraps another
+// cache and guards every call with a mutex. An exclusive lock is used even for reads, as Get
+// updates the eviction order and the statistics of the cache.
+//
+// The eviction callback is called while the lock is held, so it must not call the methods of the
+// cache.
+type Sync[K comparable, V any] struct {
+	mu sync.Mutex
+	c  Interface[K, V]
+}
+
+// NewSync returns a new concurrency-safe cache instance wrapping c. The caller should not use c
+// directly after this call.
+func NewSync[K comparable, V any](c Interface[K, V]) *Sync[K, V] {
+	return &Sync[K, V]{c: c}
+}
+
+// Len returns the number of entries in the cache, including expired entries that haven't been
+// removed yet.
+func (s *Sync[K, V]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Len()
+}
+
+// Cap returns the maximum number of entries in the cache.
+func (s *Sync[K, V]) Cap() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Cap()
+}
+
+// Empty checks whether the cache is empty.
+func (s *Sync[K, V]) Empty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Empty()
+}
+
+// Clear deletes all the entries from the cache. The eviction callback is called for every entry
+// with the reason EvictDeleted.
+func (s *Sync[K, V]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.c.Clear()
+}
+
+// Contains checks whether the cache contains the given key. It doesn't count as an access of the
+// entry and doesn't change the statistics.
+func (s *Sync[K, V]) Contains(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Contains(key)
+}
+
+// Get returns the value of the given key and counts as an access of the entry, recording a hit. If
+// the key doesn't exist or has expired, it records a miss and the second return value is false.
+func (s *Sync[K, V]) Get(key K) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Get(key)
+}
+
+// Peek returns the value of the given key like Get, but it doesn't count as an access of the entry
+// and doesn't change the statistics.
+func (s *Sync[K, V]) Peek(key K) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Peek(key)
+}
+
+// Set sets the value of the given key using the default TTL of the cache, and counts as an access
+// of the entry. If the key is new and the cache is full, an entry is evicted first.
+func (s *Sync[K, V]) Set(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.c.Set(key, value)
+}
+
+// SetWithTTL sets the value of the given key like Set, but the entry expires after ttl instead of
+// the default TTL. If ttl is not positive, the entry doesn't expire.
+func (s *Sync[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.c.SetWithTTL(key, value, ttl)
+}
+
+// Delete deletes the given key from the cache. It returns false if the key doesn't exist.
+func (s *Sync[K, V]) Delete(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Delete(key)
+}
+
+// Purge removes all the expired entries from the cache and returns their number.
+func (s *Sync[K, V]) Purge() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Purge()
+}
+
+// Stats returns the statistics of the cache.
+func (s *Sync[K, V]) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Stats()
+}
+
+// ResetStats resets the statistics of the cache to zero.
+func (s *Sync[K, V]) ResetStats() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.c.ResetStats()
+}
+
+// SetTTL sets the default TTL of the entries set later. If ttl is not positive, which is the
+// default, the entries don't expire.
+func (s *Sync[K, V]) SetTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.c.SetTTL(ttl)
+}
+
+// OnEvict sets the function called with every entry that leaves the cache, except for old values
+// replaced by Set. It is called after the entry has been removed while the lock is held, and must
+// not call the methods of the cache. A nil fn removes the callback.
+func (s *Sync[K, V]) OnEvict(fn EvictFunc[K, V]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.c.OnEvict(fn)
+}
+
+// SetClock sets the function used to get the current time for expiry, which is time.Now by
+// default. It is mainly useful for testing.
+func (s *Sync[K, V]) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.c.SetClock(now)
+}
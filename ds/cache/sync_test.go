@@ -0,0 +1,37 @@
+package cache_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/cache"
+)
+
+func TestSync(t *testing.T) {
+	testInterfaceHelper(t, func(capacity int) cache.Interface[int, string] {
+		return cache.NewSync(cache.NewLRU[int, string](capacity))
+	})
+}
+
+func TestSync_Concurrent(t *testing.T) {
+	newCache := cache.NewSync(cache.NewARC[int, int](64))
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := (g*31 + i) % 100
+				if v, ok := newCache.Get(key); ok && v != key {
+					t.Errorf("Concurrent: expected Get %d to return %d, got %d", key, key, v)
+				}
+				newCache.Set(key, key)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if stats := newCache.Stats(); stats.Hits+stats.Misses != 8000 || newCache.Len() > 64 {
+		t.Errorf("Concurrent: expected 8000 lookups and Len at most 64, got %+v and Len %d", stats, newCache.Len())
+	}
+}
@@ -0,0 +1,186 @@
+package filter
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+
+	"github.com/gpahal/go-algos/internal/hashing"
+)
+
+// Bloom represents a Bloom filter: a bit array of m bits where every item sets k bits chosen by
+// its hashes, so an item is possibly in the filter if all of its k bits are set. Adding items
+// never fails, but the false positive rate grows as more items are added than the filter was
+// sized for.
+//
+// Two Bloom filters with the same number of bits and hashes can be combined: the union contains
+// the items of both filters, and the intersection contains the items of both filters with a false
+// positive rate at most that of either filter.
+type Bloom struct {
+	words []uint64
+	m     uint64
+	k     int
+}
+
+// NewBloom returns a new empty Bloom filter sized for n items with the given false positive rate,
+// which should be between 0 and 1. It uses the optimal number of bits, m = -n*ln(p)/ln(2)^2, and
+// of hashes, k = m/n*ln(2). For example, a false positive rate of 1% takes about 9.6 bits and 7
+// hashes per item.
+func NewBloom(n int, fpRate float64) *Bloom {
+	m, k := bloomParams(n, fpRate)
+	return NewBloomWithSize(m, k)
+}
+
+// NewBloomWithSize returns a new empty Bloom filter with m bits and k hashes. Both of them are at
+// least 1, and k is at most 64.
+func NewBloomWithSize(m, k int) *Bloom {
+	m, k = max(m, 1), min(max(k, 1), maxHashes)
+	return &Bloom{words: make([]uint64, (m+63)/64), m: uint64(m), k: k}
+}
+
+// maxHashes is the maximum number of hashes of every item of a Bloom filter. The optimal number
+// of hashes is less than 40 for any false positive rate down to 1e-12.
+const maxHashes = 64
+
+// bloomParams returns the optimal number of bits and hashes of a Bloom filter for n items with
+// the given false positive rate.
+func bloomParams(n int, fpRate float64) (int, int) {
+	n = max(n, 1)
+	fpRate = min(max(fpRate, 1e-12), 0.5)
+	m := math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / float64(n) * math.Ln2)
+	return int(m), max(int(k), 1)
+}
+
+// Add adds the item to the filter. It always returns true.
+func (b *Bloom) Add(item []byte) bool {
+	h1, h2 := hashing.Pair(item)
+	for i := range b.k {
+		j := (h1 + uint64(i)*h2) % b.m
+		b.words[j/64] |= 1 << (j % 64)
+	}
+
+	return true
+}
+
+// Contains checks whether the item might be in the filter.
+func (b *Bloom) Contains(item []byte) bool {
+	h1, h2 := hashing.Pair(item)
+	for i := range b.k {
+		j := (h1 + uint64(i)*h2) % b.m
+		if b.words[j/64]&(1<<(j%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Clear deletes all the items from the filter.
+func (b *Bloom) Clear() {
+	clear(b.words)
+}
+
+// Bits returns the number of bits of the filter.
+func (b *Bloom) Bits() int {
+	return int(b.m)
+}
+
+// Hashes returns the number of hashes of every item.
+func (b *Bloom) Hashes() int {
+	return b.k
+}
+
+// Count returns the estimated number of distinct items added to the filter, computed from the
+// number of set bits x as -m/k*ln(1-x/m). If all the bits are set, it returns +Inf.
+func (b *Bloom) Count() float64 {
+	return -float64(b.m) / float64(b.k) * math.Log(1-b.fill())
+}
+
+// FalsePositiveRate returns the estimated probability that Contains returns true for an item that
+// was not added, computed from the fraction of set bits f as f^k.
+func (b *Bloom) FalsePositiveRate() float64 {
+	return math.Pow(b.fill(), float64(b.k))
+}
+
+// UnionWith adds the items of other to the filter. If the filters have different numbers of bits
+// or hashes, ErrIncompatible is returned and the filter is not modified.
+func (b *Bloom) UnionWith(other *Bloom) error {
+	if b.m != other.m || b.k != other.k {
+		return ErrIncompatible
+	}
+
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+	return nil
+}
+
+// IntersectWith keeps only the bits of the filter that are also set in other, so the filter
+// contains the items that are in both filters. Its false positive rate might be higher than that
+// of a filter that only had those items added. If the filters have different numbers of bits or
+// hashes, ErrIncompatible is returned and the filter is not modified.
+func (b *Bloom) IntersectWith(other *Bloom) error {
+	if b.m != other.m || b.k != other.k {
+		return ErrIncompatible
+	}
+
+	for i, w := range other.words {
+		b.words[i] &= w
+	}
+	return nil
+}
+
+// Copy creates a new copy of the filter.
+func (b *Bloom) Copy() *Bloom {
+	return &Bloom{words: append([]uint64(nil), b.words...), m: b.m, k: b.k}
+}
+
+// MarshalBinary returns the filter serialized in a binary format. It implements the
+// encoding.BinaryMarshaler interface.
+func (b *Bloom) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 14+8*len(b.words))
+	data = append(data, bloomMagic, formatVersion)
+	data = binary.LittleEndian.AppendUint32(data, uint32(b.k))
+	data = binary.LittleEndian.AppendUint64(data, b.m)
+	for _, w := range b.words {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary replaces the filter with a filter serialized by MarshalBinary. It implements the
+// encoding.BinaryUnmarshaler interface. If the data is not valid, ErrInvalidFormat is returned and
+// the filter is not modified.
+func (b *Bloom) UnmarshalBinary(data []byte) error {
+	data, err := checkHeader(data, bloomMagic)
+	if err != nil || len(data) < 12 {
+		return ErrInvalidFormat
+	}
+
+	k := binary.LittleEndian.Uint32(data)
+	m := binary.LittleEndian.Uint64(data[4:])
+	data = data[12:]
+	if k == 0 || k > maxHashes || m == 0 || m > math.MaxInt-63 || uint64(len(data)) != (m+63)/64*8 {
+		return ErrInvalidFormat
+	}
+
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+
+	b.words, b.m, b.k = words, m, int(k)
+	return nil
+}
+
+// fill returns the fraction of set bits.
+func (b *Bloom) fill() float64 {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+
+	return float64(count) / float64(b.m)
+}
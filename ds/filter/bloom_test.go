@@ -0,0 +1,113 @@
+package filter_test
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/filter"
+)
+
+func TestNewBloom(t *testing.T) {
+	testInterfaceHelper(t, 2000, 0.01, func() marshalFilter {
+		return filter.NewBloom(2000, 0.01)
+	})
+
+	b := filter.NewBloom(1000, 0.01)
+	if b.Bits() != 9586 || b.Hashes() != 7 {
+		t.Errorf("NewBloom 1000, 0.01: expected 9586 bits and 7 hashes, got %d and %d", b.Bits(), b.Hashes())
+	}
+
+	b = filter.NewBloomWithSize(0, 0)
+	if b.Bits() != 1 || b.Hashes() != 1 {
+		t.Errorf("NewBloomWithSize 0, 0: expected 1 bit and 1 hash, got %d and %d", b.Bits(), b.Hashes())
+	}
+}
+
+func TestBloom_MaxHashes(t *testing.T) {
+	b := filter.NewBloomWithSize(1000, 100)
+	if b.Hashes() != 64 {
+		t.Errorf("NewBloomWithSize 1000, 100: expected 64 hashes, got %d", b.Hashes())
+	}
+	testMaxHashesHelper(t, b)
+}
+
+func TestBloom_Estimates(t *testing.T) {
+	b := filter.NewBloom(5000, 0.01)
+	if b.Count() != 0 || b.FalsePositiveRate() != 0 {
+		t.Errorf("Count: expected empty filter to have estimates 0, got %g and %g", b.Count(), b.FalsePositiveRate())
+	}
+
+	for i := range 5000 {
+		b.Add(item(i))
+	}
+	if c := b.Count(); math.Abs(c-5000) > 100 {
+		t.Errorf("Count: expected about 5000 items, got %g", c)
+	}
+	if rate := b.FalsePositiveRate(); math.Abs(rate-0.01) > 0.003 {
+		t.Errorf("FalsePositiveRate: expected about 0.01, got %g", rate)
+	}
+}
+
+func TestBloom_Algebra(t *testing.T) {
+	a, b := filter.NewBloom(1000, 0.01), filter.NewBloom(1000, 0.01)
+	for i := range 600 {
+		a.Add(item(i))
+	}
+	for i := 400; i < 1000; i++ {
+		b.Add(item(i))
+	}
+
+	union := a.Copy()
+	if err := union.UnionWith(b); err != nil {
+		t.Fatalf("UnionWith: expected no error, got %v", err)
+	}
+	for i := range 1000 {
+		if !union.Contains(item(i)) {
+			t.Fatalf("UnionWith: expected item %d to be in the union", i)
+		}
+	}
+
+	intersection := a.Copy()
+	if err := intersection.IntersectWith(b); err != nil {
+		t.Fatalf("IntersectWith: expected no error, got %v", err)
+	}
+	for i := 400; i < 600; i++ {
+		if !intersection.Contains(item(i)) {
+			t.Fatalf("IntersectWith: expected item %d to be in the intersection", i)
+		}
+	}
+	if c := intersection.Count(); c > 300 {
+		t.Errorf("IntersectWith: expected about 200 items, got %g", c)
+	}
+	if a.Contains(item(999)) && !a.Copy().Contains(item(999)) {
+		t.Error("Copy: expected copy to have the same items")
+	}
+
+	other := filter.NewBloom(1000, 0.001)
+	if err := a.UnionWith(other); err != filter.ErrIncompatible {
+		t.Errorf("UnionWith: expected ErrIncompatible, got %v", err)
+	}
+	if err := a.IntersectWith(other); err != filter.ErrIncompatible {
+		t.Errorf("IntersectWith: expected ErrIncompatible, got %v", err)
+	}
+}
+
+// testMaxHashesHelper checks that UnmarshalBinary rejects the data of a Bloom or counting Bloom
+// filter with more than 64 hashes. The number of hashes follows the 2 bytes of the header.
+func testMaxHashesHelper(t *testing.T, f marshalFilter) {
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: expected no error, got %v", err)
+	}
+	if err := f.UnmarshalBinary(data); err != nil {
+		t.Errorf("UnmarshalBinary: expected no error with 64 hashes, got %v", err)
+	}
+
+	for _, k := range []uint32{65, math.MaxUint32} {
+		binary.LittleEndian.PutUint32(data[2:], k)
+		if err := f.UnmarshalBinary(data); err != filter.ErrInvalidFormat {
+			t.Errorf("UnmarshalBinary %d hashes: expected ErrInvalidFormat, got %v", k, err)
+		}
+	}
+}
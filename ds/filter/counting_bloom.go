@@ -0,0 +1,170 @@
+package filter
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/gpahal/go-algos/internal/hashing"
+)
+
+// CountingBloom represents a counting Bloom filter: a Bloom filter with a counter of 8 bits instead
+// of every bit, so items can be deleted by decrementing their counters. It uses 8 times the memory
+// of a Bloom filter with the same false positive rate.
+//
+// A counter that reaches 255 sticks at that value and is never decremented, because the number of
+// items counted by it is no longer known. This can only leave items in the filter, never remove
+// items that were added.
+type CountingBloom struct {
+	counters []uint8
+	k        int
+}
+
+// NewCountingBloom returns a new empty counting Bloom filter sized for n items with the given false
+// positive rate, which should be between 0 and 1. It uses the same number of counters and hashes
+// as NewBloom uses bits and hashes.
+func NewCountingBloom(n int, fpRate float64) *CountingBloom {
+	m, k := bloomParams(n, fpRate)
+	return NewCountingBloomWithSize(m, k)
+}
+
+// NewCountingBloomWithSize returns a new empty counting Bloom filter with m counters and k hashes.
+// Both of them are at least 1, and k is at most 64.
+func NewCountingBloomWithSize(m, k int) *CountingBloom {
+	return &CountingBloom{counters: make([]uint8, max(m, 1)), k: min(max(k, 1), maxHashes)}
+}
+
+// Add adds the item to the filter. It always returns true.
+func (b *CountingBloom) Add(item []byte) bool {
+	h1, h2 := hashing.Pair(item)
+	for i := range b.k {
+		j := b.index(h1, h2, i)
+		if b.counters[j] < math.MaxUint8 {
+			b.counters[j]++
+		}
+	}
+
+	return true
+}
+
+// Contains checks whether the item might be in the filter.
+func (b *CountingBloom) Contains(item []byte) bool {
+	h1, h2 := hashing.Pair(item)
+	for i := range b.k {
+		if b.counters[b.index(h1, h2, i)] == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Delete deletes the item from the filter by decrementing its counters. It returns false, without
+// modifying the filter, if the item is definitely not in the filter.
+func (b *CountingBloom) Delete(item []byte) bool {
+	if !b.Contains(item) {
+		return false
+	}
+
+	h1, h2 := hashing.Pair(item)
+	for i := range b.k {
+		j := b.index(h1, h2, i)
+		if b.counters[j] < math.MaxUint8 {
+			b.counters[j]--
+		}
+	}
+
+	return true
+}
+
+// Clear deletes all the items from the filter.
+func (b *CountingBloom) Clear() {
+	clear(b.counters)
+}
+
+// Counters returns the number of counters of the filter.
+func (b *CountingBloom) Counters() int {
+	return len(b.counters)
+}
+
+// Hashes returns the number of hashes of every item.
+func (b *CountingBloom) Hashes() int {
+	return b.k
+}
+
+// Count returns the estimated number of distinct items in the filter, computed from the number of
+// non-zero counters like Bloom.Count.
+func (b *CountingBloom) Count() float64 {
+	return -float64(len(b.counters)) / float64(b.k) * math.Log(1-b.fill())
+}
+
+// FalsePositiveRate returns the estimated probability that Contains returns true for an item that
+// is not in the filter, computed from the fraction of non-zero counters like
+// Bloom.FalsePositiveRate.
+func (b *CountingBloom) FalsePositiveRate() float64 {
+	return math.Pow(b.fill(), float64(b.k))
+}
+
+// Bloom returns a Bloom filter with the same items, which has a bit set for every non-zero counter
+// and can be combined with the Bloom filters of the same size.
+func (b *CountingBloom) Bloom() *Bloom {
+	bloom := NewBloomWithSize(len(b.counters), b.k)
+	for j, c := range b.counters {
+		if c > 0 {
+			bloom.words[j/64] |= 1 << (j % 64)
+		}
+	}
+
+	return bloom
+}
+
+// Copy creates a new copy of the filter.
+func (b *CountingBloom) Copy() *CountingBloom {
+	return &CountingBloom{counters: append([]uint8(nil), b.counters...), k: b.k}
+}
+
+// MarshalBinary returns the filter serialized in a binary format. It implements the
+// encoding.BinaryMarshaler interface.
+func (b *CountingBloom) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 14+len(b.counters))
+	data = append(data, countingBloomMagic, formatVersion)
+	data = binary.LittleEndian.AppendUint32(data, uint32(b.k))
+	data = binary.LittleEndian.AppendUint64(data, uint64(len(b.counters)))
+	return append(data, b.counters...), nil
+}
+
+// UnmarshalBinary replaces the filter with a filter serialized by MarshalBinary. It implements the
+// encoding.BinaryUnmarshaler interface. If the data is not valid, ErrInvalidFormat is returned and
+// the filter is not modified.
+func (b *CountingBloom) UnmarshalBinary(data []byte) error {
+	data, err := checkHeader(data, countingBloomMagic)
+	if err != nil || len(data) < 12 {
+		return ErrInvalidFormat
+	}
+
+	k := binary.LittleEndian.Uint32(data)
+	m := binary.LittleEndian.Uint64(data[4:])
+	data = data[12:]
+	if k == 0 || k > maxHashes || m == 0 || uint64(len(data)) != m {
+		return ErrInvalidFormat
+	}
+
+	b.counters, b.k = append([]uint8(nil), data...), int(k)
+	return nil
+}
+
+// index returns the index of the counter of the ith hash of an item with the hashes h1 and h2.
+func (b *CountingBloom) index(h1, h2 uint64, i int) int {
+	return int((h1 + uint64(i)*h2) % uint64(len(b.counters)))
+}
+
+// fill returns the fraction of non-zero counters.
+func (b *CountingBloom) fill() float64 {
+	count := 0
+	for _, c := range b.counters {
+		if c > 0 {
+			count++
+		}
+	}
+
+	return float64(count) / float64(len(b.counters))
+}
@@ -0,0 +1,64 @@
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/filter"
+)
+
+func TestNewCountingBloom(t *testing.T) {
+	testInterfaceHelper(t, 2000, 0.01, func() marshalFilter {
+		return filter.NewCountingBloom(2000, 0.01)
+	})
+	testDeletableHelper(t, 2000, func() filter.Deletable {
+		return filter.NewCountingBloom(2000, 0.01)
+	})
+}
+
+func TestCountingBloom_MaxHashes(t *testing.T) {
+	b := filter.NewCountingBloomWithSize(1000, 100)
+	if b.Hashes() != 64 {
+		t.Errorf("NewCountingBloomWithSize 1000, 100: expected 64 hashes, got %d", b.Hashes())
+	}
+	testMaxHashesHelper(t, b)
+}
+
+func TestCountingBloom_Saturation(t *testing.T) {
+	b := filter.NewCountingBloomWithSize(8, 2)
+	for range 300 {
+		b.Add(item(1))
+	}
+	for range 300 {
+		b.Delete(item(1))
+	}
+	if !b.Contains(item(1)) {
+		t.Error("Delete: expected saturated counters to keep the item in the filter")
+	}
+}
+
+func TestCountingBloom_Bloom(t *testing.T) {
+	c := filter.NewCountingBloom(1000, 0.01)
+	for i := range 1000 {
+		c.Add(item(i))
+	}
+	c.Delete(item(0))
+
+	b := c.Bloom()
+	if b.Bits() != c.Counters() || b.Hashes() != c.Hashes() {
+		t.Fatalf("Bloom: expected %d bits and %d hashes, got %d and %d", c.Counters(), c.Hashes(), b.Bits(), b.Hashes())
+	}
+	for i := range 1000 {
+		if b.Contains(item(i)) != c.Contains(item(i)) {
+			t.Fatalf("Bloom: expected Contains %d to match the counting filter", i)
+		}
+	}
+	if b.Count() != c.Count() || b.FalsePositiveRate() != c.FalsePositiveRate() {
+		t.Errorf("Bloom: expected the same estimates, got %g and %g", b.Count(), c.Count())
+	}
+
+	d := c.Copy()
+	d.Clear()
+	if !c.Contains(item(1)) {
+		t.Error("Copy: expected copy to be independent")
+	}
+}
@@ -0,0 +1,75 @@
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/filter"
+)
+
+func TestNewCuckoo(t *testing.T) {
+	testInterfaceHelper(t, 2000, 0.001, func() marshalFilter {
+		return filter.NewCuckoo(2000)
+	})
+	testDeletableHelper(t, 2000, func() filter.Deletable {
+		return filter.NewCuckoo(2000)
+	})
+
+	c := filter.NewCuckoo(1000)
+	if c.Cap() != 2048 || c.Len() != 0 || c.Full() {
+		t.Errorf("NewCuckoo 1000: expected Cap 2048 and Len 0, got %d and %d", c.Cap(), c.Len())
+	}
+}
+
+func TestCuckoo_Full(t *testing.T) {
+	c := filter.NewCuckoo(1000)
+	added := 0
+	for i := 0; !c.Full(); i++ {
+		if !c.Add(item(i)) {
+			t.Fatalf("Add %d: expected Add to succeed until the filter is full", i)
+		}
+		added++
+	}
+	if c.Len() != added || c.LoadFactor() < 0.9 {
+		t.Errorf("Add: expected filter to be full at a high load factor, got Len %d and load factor %g", c.Len(), c.LoadFactor())
+	}
+	if c.Add(item(-1)) {
+		t.Error("Add: expected Add to fail when the filter is full")
+	}
+	for i := range added {
+		if !c.Contains(item(i)) {
+			t.Fatalf("Contains %d: expected added item to be in the full filter", i)
+		}
+	}
+
+	d := c.Copy()
+	data, _ := c.MarshalBinary()
+	var e filter.Cuckoo
+	if err := e.UnmarshalBinary(data); err != nil || !e.Full() || e.Len() != added {
+		t.Errorf("UnmarshalBinary: expected full filter with Len %d, got Len %d (error %v)", added, e.Len(), err)
+	}
+
+	for i := range 10 {
+		c.Delete(item(i))
+	}
+	if c.Full() || !c.Add(item(-1)) || !c.Contains(item(-1)) {
+		t.Error("Delete: expected deleting items to make room in the filter")
+	}
+	if !d.Full() || d.Len() != added {
+		t.Error("Copy: expected copy to be independent")
+	}
+}
+
+func TestCuckoo_Duplicates(t *testing.T) {
+	c := filter.NewCuckoo(100)
+	for range 3 {
+		c.Add(item(1))
+	}
+	for range 3 {
+		if !c.Delete(item(1)) {
+			t.Fatal("Delete: expected every copy of a duplicate item to be deleted")
+		}
+	}
+	if c.Contains(item(1)) || c.Len() != 0 {
+		t.Errorf("Delete: expected filter to be empty, got Len %d", c.Len())
+	}
+}
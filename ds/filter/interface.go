@@ -0,0 +1,64 @@
+// Package filter implements probabilistic membership filters. A filter answers whether an item
+// might have been added to it using much less memory than a set: it never reports an added item
+// as missing, but it may report an item that was never added as present, with a small false
+// positive rate chosen when the filter is created. A typical use is skipping an expensive lookup
+// when the filter says the item is definitely missing.
+//
+// The items are byte slices. The hashes of the filters are deterministic, so a filter serialized
+// with MarshalBinary can be read back in another process.
+package filter
+
+import "errors"
+
+// Interface is the interface that groups the basic methods of a membership filter implementation.
+type Interface interface {
+	// Add adds the item to the filter. It returns false if the filter is full and the item could
+	// not be added, which only happens for filters with a fixed capacity, like Cuckoo.
+	Add(item []byte) bool
+
+	// Contains checks whether the item might be in the filter. If it returns false, the item is
+	// definitely not in the filter, and if it returns true, the item is in the filter with a high
+	// probability.
+	Contains(item []byte) bool
+
+	// Clear deletes all the items from the filter.
+	Clear()
+}
+
+// Deletable is implemented by the filters that support deleting items, like CountingBloom and
+// Cuckoo.
+type Deletable interface {
+	Interface
+
+	// Delete deletes the item from the filter. It returns false if the item is definitely not in
+	// the filter. Deleting an item that was never added may delete another item that has the same
+	// hashes, causing false negatives, so only the items known to be added should be deleted.
+	Delete(item []byte) bool
+}
+
+var (
+	// ErrIncompatible is returned when combining two filters with different parameters.
+	ErrIncompatible = errors.New("filter: incompatible filters")
+
+	// ErrInvalidFormat is returned when reading a filter from data that was not written by the
+	// MarshalBinary method of a filter of the same type.
+	ErrInvalidFormat = errors.New("filter: invalid format")
+)
+
+// The first byte of the binary format of every filter type, followed by the format version.
+const (
+	bloomMagic         = 'B'
+	countingBloomMagic = 'C'
+	cuckooMagic        = 'K'
+	formatVersion      = 1
+)
+
+// checkHeader checks that data starts with the header of the given filter type, and returns the
+// data after the header.
+func checkHeader(data []byte, magic byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != magic || data[1] != formatVersion {
+		return nil, ErrInvalidFormat
+	}
+
+	return data[2:], nil
+}
@@ -0,0 +1,137 @@
+package filter_test
+
+import (
+	"encoding"
+	"strconv"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/filter"
+)
+
+// marshalFilter is a filter that can be serialized.
+type marshalFilter interface {
+	filter.Interface
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+func item(i int) []byte {
+	return []byte("item-" + strconv.Itoa(i))
+}
+
+// falsePositives returns the fraction of n items that were never added for which Contains returns
+// true.
+func falsePositives(f filter.Interface, n int) float64 {
+	count := 0
+	for i := range n {
+		if f.Contains([]byte("missing-" + strconv.Itoa(i))) {
+			count++
+		}
+	}
+
+	return float64(count) / float64(n)
+}
+
+// testInterfaceHelper tests a filter created for n items with a false positive rate of at most
+// fpRate.
+func testInterfaceHelper(t *testing.T, n int, fpRate float64, newFn func() marshalFilter) {
+	t.Run("Contains", func(t *testing.T) {
+		f := newFn()
+		if f.Contains(item(0)) {
+			t.Error("Contains: expected empty filter to contain no items")
+		}
+
+		for i := range n {
+			if !f.Add(item(i)) {
+				t.Fatalf("Add %d: expected Add to return true", i)
+			}
+		}
+		for i := range n {
+			if !f.Contains(item(i)) {
+				t.Fatalf("Contains %d: expected added item to be in the filter", i)
+			}
+		}
+
+		if rate := falsePositives(f, 20000); rate > 1.5*fpRate {
+			t.Errorf("Contains: expected false positive rate to be at most %g, got %g", fpRate, rate)
+		}
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		f := newFn()
+		for i := range n {
+			f.Add(item(i))
+		}
+		f.Clear()
+		if rate := falsePositives(f, 1000); rate != 0 || f.Contains(item(0)) {
+			t.Errorf("Clear: expected filter to be empty, got false positive rate %g", rate)
+		}
+	})
+
+	t.Run("Binary", func(t *testing.T) {
+		f := newFn()
+		for i := range n {
+			f.Add(item(i))
+		}
+
+		data, err := f.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary: expected no error, got %v", err)
+		}
+
+		g := newFn()
+		if err := g.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary: expected no error, got %v", err)
+		}
+		for i := range n {
+			if !g.Contains(item(i)) {
+				t.Fatalf("UnmarshalBinary: expected item %d to be in the read filter", i)
+			}
+		}
+		if again, _ := g.MarshalBinary(); string(again) != string(data) {
+			t.Error("UnmarshalBinary: expected read filter to marshal to the same data")
+		}
+
+		for _, bad := range [][]byte{nil, data[:1], data[:len(data)-1], append(data, 0), {'X', 1}} {
+			if err := g.UnmarshalBinary(bad); err != filter.ErrInvalidFormat {
+				t.Errorf("UnmarshalBinary %v: expected ErrInvalidFormat, got %v", bad, err)
+			}
+		}
+	})
+}
+
+// testDeletableHelper tests deleting items from a filter created for n items.
+func testDeletableHelper(t *testing.T, n int, newFn func() filter.Deletable) {
+	f := newFn()
+	for i := range n {
+		f.Add(item(i))
+	}
+
+	for i := 0; i < n; i += 2 {
+		if !f.Delete(item(i)) {
+			t.Fatalf("Delete %d: expected Delete to return true", i)
+		}
+	}
+	for i := 1; i < n; i += 2 {
+		if !f.Contains(item(i)) {
+			t.Fatalf("Delete: expected item %d that was not deleted to be in the filter", i)
+		}
+	}
+
+	deleted := 0
+	for i := 0; i < n; i += 2 {
+		if !f.Contains(item(i)) {
+			deleted++
+		}
+	}
+	if deleted < n/2*9/10 {
+		t.Errorf("Delete: expected most deleted items to be missing, got %d of %d", deleted, n/2)
+	}
+
+	for i := 1; i < n; i += 2 {
+		f.Delete(item(i))
+	}
+	if f.Delete([]byte("never added")) {
+		t.Error("Delete: expected Delete of a missing item in an empty filter to return false")
+	}
+}
@@ -0,0 +1,185 @@
+package graph
+
+// Op represents the kind of mutation performed on a graph.
+type Op int
+
+const (
+	// OpAddNode is the addition of a node.
+	OpAddNode Op = iota + 1
+	// OpUpdateNode is the update of the value of a node.
+	OpUpdateNode
+	// OpDeleteNode is the deletion of a node.
+	OpDeleteNode
+	// OpAddEdge is the addition of an edge.
+	OpAddEdge
+	// OpUpdateEdge is the update of the weight of an edge.
+	OpUpdateEdge
+	// OpDeleteEdge is the deletion of an edge.
+	OpDeleteEdge
+)
+
+// String returns the name of the op.
+func (op Op) String() string {
+	switch op {
+	case OpAddNode:
+		return "AddNode"
+	case OpUpdateNode:
+		return "UpdateNode"
+	case OpDeleteNode:
+		return "DeleteNode"
+	case OpAddEdge:
+		return "AddEdge"
+	case OpUpdateEdge:
+		return "UpdateEdge"
+	case OpDeleteEdge:
+		return "DeleteEdge"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event represents a single mutation of a graph.
+type Event struct {
+	Op Op
+
+	// Node is the node affected by a node op. For OpDeleteNode, it is the node as it was before
+	// the deletion.
+	Node Node
+
+	// Edge is the edge affected by an edge op. For OpDeleteEdge, it is the edge as it was before
+	// the deletion.
+	Edge Edge
+
+	// Prev is the previous value of the node for OpUpdateNode, or the previous weight of the edge
+	// for OpUpdateEdge.
+	Prev int
+}
+
+// invert returns the event that undoes e.
+func (e Event) invert() Event {
+	switch e.Op {
+	case OpAddNode:
+		e.Op = OpDeleteNode
+	case OpDeleteNode:
+		e.Op = OpAddNode
+	case OpUpdateNode:
+		e.Node.Value, e.Prev = e.Prev, e.Node.Value
+	case OpAddEdge:
+		e.Op = OpDeleteEdge
+	case OpDeleteEdge:
+		e.Op = OpAddEdge
+	case OpUpdateEdge:
+		e.Edge.Weight, e.Prev = e.Prev, e.Edge.Weight
+	}
+
+	return e
+}
+
+// apply performs the mutation described by e on g. It returns false if the mutation is not
+// possible.
+func (e Event) apply(g *Graph) bool {
+	switch e.Op {
+	case OpAddNode:
+		return g.addNodeWithID(e.Node.ID, e.Node.Value)
+	case OpUpdateNode:
+		return g.UpdateNode(e.Node.ID, e.Node.Value)
+	case OpDeleteNode:
+		return g.DeleteNode(e.Node.ID)
+	case OpAddEdge:
+		return g.AddEdge(e.Edge.SourceID, e.Edge.TargetID, e.Edge.Weight)
+	case OpUpdateEdge:
+		return g.UpdateEdge(e.Edge.SourceID, e.Edge.TargetID, e.Edge.Weight)
+	case OpDeleteEdge:
+		return g.DeleteEdge(e.Edge.SourceID, e.Edge.TargetID)
+	default:
+		return false
+	}
+}
+
+// Hook is a function that is called after a graph is mutated.
+//
+// Deleting a node first deletes all its edges, so an OpDeleteNode event is always preceded by the
+// OpDeleteEdge events of the edges of that node. Updates that don't change the value of a node or
+// the weight of an edge don't generate any event.
+//
+// A hook is called synchronously and should not mutate the graph.
+type Hook func(e Event)
+
+type hookEntry struct {
+	id   int
+	hook Hook
+}
+
+// AddHook registers a hook that is called after every mutation of the graph and returns an id that
+// can be used to remove it. Hooks are called in the order in which they were added.
+func (g *Graph) AddHook(hook Hook) int {
+	g.currHookID++
+
+	// The full slice expression makes sure a new slice is allocated, as the hooks might be shared
+	// with other graphs. See SyncGraph.
+	g.hooks = append(g.hooks[:len(g.hooks):len(g.hooks)], hookEntry{id: g.currHookID, hook: hook})
+	return g.currHookID
+}
+
+// RemoveHook removes the hook with the given id.
+func (g *Graph) RemoveHook(id int) bool {
+	for i, he := range g.hooks {
+		if he.id == id {
+			hooks := make([]hookEntry, 0, len(g.hooks)-1)
+			hooks = append(hooks, g.hooks[:i]...)
+			g.hooks = append(hooks, g.hooks[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// emit calls all the registered hooks with the given event.
+func (g *Graph) emit(e Event) {
+	for _, he := range g.hooks {
+		he.hook(e)
+	}
+}
+
+// ChangeLog represents a recorded sequence of graph mutations. It can be replayed onto another
+// graph to mirror the mutations or inverted to undo them.
+//
+// # Example
+//
+//     var log graph.ChangeLog
+//     g.AddHook(log.Record)
+//
+//     // Mutate g...
+//
+//     log.Invert().Replay(g) // g is back to its original state
+type ChangeLog []Event
+
+// Record appends the event to the change log. It can be registered as a Hook.
+func (l *ChangeLog) Record(e Event) {
+	*l = append(*l, e)
+}
+
+// Replay applies the events of the change log to g in order. Node ids are preserved, so g should
+// be in the same state as the graph the change log was recorded from. If an event can't be
+// applied, replay stops and false is returned.
+func (l ChangeLog) Replay(g *Graph) bool {
+	for _, e := range l {
+		if !e.apply(g) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Invert returns a new change log that undoes all the events of the change log when replayed onto
+// the graph it was recorded from.
+func (l ChangeLog) Invert() ChangeLog {
+	inverted := make(ChangeLog, len(l))
+	for i, e := range l {
+		inverted[len(l)-1-i] = e.invert()
+	}
+
+	return inverted
+}
@@ -0,0 +1,181 @@
+package graph_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/graph"
+)
+
+func TestGraph_AddHook(t *testing.T) {
+	newGraph := graph.New()
+
+	var ops []graph.Op
+	hookID := newGraph.AddHook(func(e graph.Event) {
+		ops = append(ops, e.Op)
+	})
+
+	id1 := newGraph.AddNode(5)
+	id2 := newGraph.AddNode(6)
+	newGraph.UpdateNode(id1, 7)
+	newGraph.UpdateNode(id1, 7)
+	newGraph.AddEdge(id1, id2, 1)
+	newGraph.UpdateEdge(id1, id2, 2)
+	newGraph.AddOrUpdateEdge(id1, id2, 3)
+	newGraph.DeleteEdge(id1, id2)
+	newGraph.DeleteNode(id1)
+
+	expected := []graph.Op{
+		graph.OpAddNode, graph.OpAddNode, graph.OpUpdateNode, graph.OpAddEdge, graph.OpUpdateEdge,
+		graph.OpUpdateEdge, graph.OpDeleteEdge, graph.OpDeleteNode,
+	}
+	assertOps(t, "AddHook", ops, expected)
+
+	if !newGraph.RemoveHook(hookID) {
+		t.Errorf("RemoveHook: expected RemoveHook to return true, got false")
+	}
+	if newGraph.RemoveHook(hookID) {
+		t.Errorf("RemoveHook: expected RemoveHook to return false, got true")
+	}
+
+	newGraph.AddNode(8)
+	assertOps(t, "RemoveHook", ops, expected)
+}
+
+func TestGraph_AddHook_DeleteNode(t *testing.T) {
+	newGraph := graph.New()
+	id1 := newGraph.AddNode(5)
+	id2 := newGraph.AddNode(6)
+	newGraph.AddEdge(id1, id2, 1)
+	newGraph.AddEdge(id2, id1, 2)
+
+	var ops []graph.Op
+	newGraph.AddHook(func(e graph.Event) {
+		ops = append(ops, e.Op)
+	})
+
+	newGraph.DeleteNode(id1)
+	assertOps(t, "DeleteNode", ops, []graph.Op{graph.OpDeleteEdge, graph.OpDeleteEdge, graph.OpDeleteNode})
+}
+
+func TestChangeLog_Replay(t *testing.T) {
+	newGraph := graph.New()
+	mirror := graph.New()
+
+	var log graph.ChangeLog
+	newGraph.AddHook(log.Record)
+
+	id1 := newGraph.AddNode(5)
+	id2 := newGraph.AddNode(6)
+	id3 := newGraph.AddNode(7)
+	newGraph.AddEdge(id1, id2, 1)
+	newGraph.AddEdge(id2, id3, 2)
+	newGraph.UpdateEdge(id1, id2, 3)
+	newGraph.DeleteNode(id3)
+
+	if !log.Replay(mirror) {
+		t.Fatalf("Replay: expected Replay to return true, got false")
+	}
+	assertGraphsEqual(t, "Replay", mirror, newGraph, []int{id1, id2, id3})
+
+	if log.Replay(mirror) {
+		t.Errorf("Replay: expected Replay on a diverged graph to return false, got true")
+	}
+}
+
+func TestChangeLog_Invert(t *testing.T) {
+	newGraph := graph.New()
+	id1 := newGraph.AddNode(5)
+	id2 := newGraph.AddNode(6)
+	newGraph.AddEdge(id1, id2, 1)
+	original := newGraph.Copy()
+
+	var log graph.ChangeLog
+	newGraph.AddHook(log.Record)
+
+	id3 := newGraph.AddNode(7)
+	newGraph.AddEdge(id2, id3, 2)
+	newGraph.UpdateNode(id2, 8)
+	newGraph.UpdateEdge(id1, id2, 3)
+	newGraph.DeleteNode(id1)
+
+	inverted := log.Invert()
+	var undoLog graph.ChangeLog
+	newGraph.AddHook(undoLog.Record)
+	if !inverted.Replay(newGraph) {
+		t.Fatalf("Invert: expected Replay to return true, got false")
+	}
+	assertGraphsEqual(t, "Invert", newGraph, original, []int{id1, id2, id3})
+
+	if len(undoLog) != len(inverted) {
+		t.Errorf("Invert: expected %d events to be generated, got %d", len(inverted), len(undoLog))
+	}
+}
+
+func TestSyncGraph_AddHook(t *testing.T) {
+	sg := graph.NewSyncGraph(nil)
+
+	var ops []graph.Op
+	sg.AddHook(func(e graph.Event) {
+		ops = append(ops, e.Op)
+	})
+
+	id1 := sg.AddNode(5)
+	sg.Snapshot()
+	id2 := sg.AddNode(6)
+	assertOps(t, "AddHook", ops, []graph.Op{graph.OpAddNode, graph.OpAddNode})
+
+	sg.Update(func(g *graph.Graph) error {
+		g.AddEdge(id1, id2, 1)
+		return errors.New("abort")
+	})
+	assertOps(t, "Update", ops, []graph.Op{graph.OpAddNode, graph.OpAddNode})
+
+	sg.Update(func(g *graph.Graph) error {
+		g.AddEdge(id1, id2, 1)
+		g.DeleteEdge(id1, id2)
+		g.DeleteNode(id2)
+		return nil
+	})
+	assertOps(t, "Update", ops, []graph.Op{
+		graph.OpAddNode, graph.OpAddNode, graph.OpAddEdge, graph.OpDeleteEdge, graph.OpDeleteNode,
+	})
+}
+
+func assertOps(t *testing.T, name string, got, expected []graph.Op) {
+	t.Helper()
+
+	if len(got) != len(expected) {
+		t.Errorf("%s: expected ops to be %v, got %v", name, expected, got)
+		return
+	}
+
+	for i, op := range got {
+		if op != expected[i] {
+			t.Errorf("%s: expected ops to be %v, got %v", name, expected, got)
+			return
+		}
+	}
+}
+
+func assertGraphsEqual(t *testing.T, name string, got, expected *graph.Graph, ids []int) {
+	t.Helper()
+
+	if got.Len() != expected.Len() {
+		t.Errorf("%s: expected Len to be %d, got %d", name, expected.Len(), got.Len())
+	}
+
+	for _, sourceID := range ids {
+		n1, n2 := got.Node(sourceID), expected.Node(sourceID)
+		if (n1 == nil) != (n2 == nil) || (n1 != nil && n1.Value != n2.Value) {
+			t.Errorf("%s: expected Node %d to be %v, got %v", name, sourceID, n2, n1)
+		}
+
+		for _, targetID := range ids {
+			e1, e2 := got.Edge(sourceID, targetID), expected.Edge(sourceID, targetID)
+			if (e1 == nil) != (e2 == nil) || (e1 != nil && e1.Weight != e2.Weight) {
+				t.Errorf("%s: expected Edge %d-%d to be %v, got %v", name, sourceID, targetID, e2, e1)
+			}
+		}
+	}
+}
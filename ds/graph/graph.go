@@ -1,5 +1,7 @@
 package graph
 
+import "iter"
+
 // Node represents a node or a vertex in a graph.
 type Node struct {
 	ID    int
@@ -21,6 +23,9 @@ type Graph struct {
 	edgesReverseIndex map[int]map[int]int
 
 	currID int
+
+	hooks      []hookEntry
+	currHookID int
 }
 
 // New return a new graph instance.
@@ -43,10 +48,21 @@ func (g *Graph) Empty() bool {
 	return len(g.nodes) == 0
 }
 
-// Clear deletes all the items from the graph.
+// Clear deletes all the items from the graph. Registered hooks are kept, and are notified of the
+// deletion of every edge and node.
 func (g *Graph) Clear() {
+	if len(g.hooks) > 0 {
+		for id := range g.nodes {
+			g.DeleteNodeEdges(id)
+		}
+		for id, value := range g.nodes {
+			g.emit(Event{Op: OpDeleteNode, Node: Node{ID: id, Value: value}})
+		}
+	}
+
+	hooks, currHookID := g.hooks, g.currHookID
 	*g = *New()
-	return
+	g.hooks, g.currHookID = hooks, currHookID
 }
 
 // Node returns the node with the given id. If such a node doesn't exist, nil is returned.
@@ -64,34 +80,79 @@ func (g *Graph) HasNode(id int) bool {
 	return g.Node(id) != nil
 }
 
+// EachNode iterates over the nodes of the graph in no particular order.
+func (g *Graph) EachNode(fn func(Node) bool) {
+	for id, value := range g.nodes {
+		if fn(Node{ID: id, Value: value}) {
+			break
+		}
+	}
+}
+
+// Nodes returns an iterator over the nodes of the graph in no particular order. Nodes can be
+// deleted during iteration.
+func (g *Graph) Nodes() iter.Seq[Node] {
+	return func(yield func(Node) bool) {
+		for id, value := range g.nodes {
+			if !yield(Node{ID: id, Value: value}) {
+				return
+			}
+		}
+	}
+}
+
 // AddNode adds a new node to the graph and returns the id of this new node.
 func (g *Graph) AddNode(value int) int {
 	id := g.currID
 	g.nodes[g.currID] = value
 	g.currID++
+	g.emit(Event{Op: OpAddNode, Node: Node{ID: id, Value: value}})
 	return id
 }
 
+// addNodeWithID adds a new node with the given id to the graph. It is used to replay and undo
+// changes, where node ids need to be preserved. If a node with the given id already exists, it
+// returns false.
+func (g *Graph) addNodeWithID(id, value int) bool {
+	if _, ok := g.nodes[id]; ok || id <= 0 {
+		return false
+	}
+
+	g.nodes[id] = value
+	if id >= g.currID {
+		g.currID = id + 1
+	}
+	g.emit(Event{Op: OpAddNode, Node: Node{ID: id, Value: value}})
+	return true
+}
+
 // UpdateNode updates the value of the node with the given id.
 func (g *Graph) UpdateNode(id, value int) bool {
-	_, ok := g.nodes[id]
+	prev, ok := g.nodes[id]
 	if !ok {
 		return false
 	}
+	if prev == value {
+		// new value same as the previous value - no update required
+		return true
+	}
 
 	g.nodes[id] = value
+	g.emit(Event{Op: OpUpdateNode, Node: Node{ID: id, Value: value}, Prev: prev})
 	return true
 }
 
-// DeleteNode deletes the node with the given id.
+// DeleteNode deletes the node with the given id along with all its outgoing and incoming edges.
 func (g *Graph) DeleteNode(id int) bool {
-	_, ok := g.nodes[id]
-	if ok {
-		delete(g.nodes, id)
-		return true
+	value, ok := g.nodes[id]
+	if !ok {
+		return false
 	}
 
-	return false
+	g.DeleteNodeEdges(id)
+	delete(g.nodes, id)
+	g.emit(Event{Op: OpDeleteNode, Node: Node{ID: id, Value: value}})
+	return true
 }
 
 // Edge returns the edge with the given source and target ids. If such an edge doesn't exist, nil
@@ -117,6 +178,31 @@ func (g *Graph) HasEdge(sourceID, targetID int) bool {
 	return g.Edge(sourceID, targetID) != nil
 }
 
+// EachEdge iterates over the edges of the graph in no particular order.
+func (g *Graph) EachEdge(fn func(Edge) bool) {
+	for sourceID, ett := range g.edges {
+		for targetID, w := range ett {
+			if fn(Edge{SourceID: sourceID, TargetID: targetID, Weight: w}) {
+				return
+			}
+		}
+	}
+}
+
+// Edges returns an iterator over the edges of the graph in no particular order. Edges can be
+// deleted during iteration.
+func (g *Graph) Edges() iter.Seq[Edge] {
+	return func(yield func(Edge) bool) {
+		for sourceID, ett := range g.edges {
+			for targetID, w := range ett {
+				if !yield(Edge{SourceID: sourceID, TargetID: targetID, Weight: w}) {
+					return
+				}
+			}
+		}
+	}
+}
+
 // AddEdge adds a new edge to the graph.
 func (g *Graph) AddEdge(sourceID, targetID, weight int) bool {
 	_, ok := g.nodes[sourceID]
@@ -154,6 +240,7 @@ func (g *Graph) AddEdge(sourceID, targetID, weight int) bool {
 		g.edgesReverseIndex[targetID] = ets
 	}
 
+	g.emit(Event{Op: OpAddEdge, Edge: Edge{SourceID: sourceID, TargetID: targetID, Weight: weight}})
 	return true
 }
 
@@ -185,6 +272,11 @@ func (g *Graph) UpdateEdge(sourceID, targetID, weight int) bool {
 
 	g.edgesReverseIndex[targetID][sourceID] = weight
 
+	g.emit(Event{
+		Op:   OpUpdateEdge,
+		Edge: Edge{SourceID: sourceID, TargetID: targetID, Weight: weight},
+		Prev: w,
+	})
 	return true
 }
 
@@ -210,7 +302,13 @@ func (g *Graph) AddOrUpdateEdge(sourceID, targetID, weight int) bool {
 
 			if w != weight {
 				// New weight not the same as the previous value. Update required.
+				ett[targetID] = weight
 				g.edgesReverseIndex[targetID][sourceID] = weight
+				g.emit(Event{
+					Op:   OpUpdateEdge,
+					Edge: Edge{SourceID: sourceID, TargetID: targetID, Weight: weight},
+					Prev: w,
+				})
 			}
 
 			return true
@@ -234,6 +332,7 @@ func (g *Graph) AddOrUpdateEdge(sourceID, targetID, weight int) bool {
 		g.edgesReverseIndex[targetID] = ets
 	}
 
+	g.emit(Event{Op: OpAddEdge, Edge: Edge{SourceID: sourceID, TargetID: targetID, Weight: weight}})
 	return true
 }
 
@@ -243,14 +342,14 @@ func (g *Graph) DeleteEdge(sourceID, targetID int) bool {
 	if !ok {
 		return false
 	}
-	delete(ett, targetID)
-
-	ets, ok := g.edgesReverseIndex[targetID]
+	w, ok := ett[targetID]
 	if !ok {
 		return false
 	}
-	delete(ets, sourceID)
+	delete(ett, targetID)
+	delete(g.edgesReverseIndex[targetID], sourceID)
 
+	g.emit(Event{Op: OpDeleteEdge, Edge: Edge{SourceID: sourceID, TargetID: targetID, Weight: w}})
 	return true
 }
 
@@ -290,10 +389,11 @@ func (g *Graph) DeleteNodeOutgoingEdges(id int) bool {
 		return true
 	}
 
-	for targetID := range ett {
+	for targetID, w := range ett {
 		if ets, ok := g.edgesReverseIndex[targetID]; ok {
 			delete(ets, id)
 		}
+		g.emit(Event{Op: OpDeleteEdge, Edge: Edge{SourceID: id, TargetID: targetID, Weight: w}})
 	}
 
 	delete(g.edges, id)
@@ -312,10 +412,11 @@ func (g *Graph) DeleteNodeIncomingEdges(id int) bool {
 		return true
 	}
 
-	for sourceID := range ets {
+	for sourceID, w := range ets {
 		if ett, ok := g.edges[sourceID]; ok {
 			delete(ett, id)
 		}
+		g.emit(Event{Op: OpDeleteEdge, Edge: Edge{SourceID: sourceID, TargetID: id, Weight: w}})
 	}
 
 	delete(g.edgesReverseIndex, id)
@@ -326,3 +427,36 @@ func (g *Graph) DeleteNodeIncomingEdges(id int) bool {
 func (g *Graph) DeleteNodeEdges(id int) bool {
 	return g.DeleteNodeOutgoingEdges(id) && g.DeleteNodeIncomingEdges(id)
 }
+
+// Copy creates a new copy of the graph. Node ids are preserved, and nodes added to the copy
+// continue the id sequence of the original graph. Registered hooks are not copied.
+func (g *Graph) Copy() *Graph {
+	newGraph := &Graph{
+		nodes:             make(map[int]int, len(g.nodes)),
+		edges:             make(map[int]map[int]int, len(g.edges)),
+		edgesReverseIndex: make(map[int]map[int]int, len(g.edgesReverseIndex)),
+		currID:            g.currID,
+	}
+
+	for id, value := range g.nodes {
+		newGraph.nodes[id] = value
+	}
+	for sourceID, ett := range g.edges {
+		newGraph.edges[sourceID] = copyEdgeMap(ett)
+	}
+	for targetID, ets := range g.edgesReverseIndex {
+		newGraph.edgesReverseIndex[targetID] = copyEdgeMap(ets)
+	}
+
+	return newGraph
+}
+
+// copyEdgeMap returns a copy of an adjacency map of the graph.
+func copyEdgeMap(m map[int]int) map[int]int {
+	newMap := make(map[int]int, len(m))
+	for id, w := range m {
+		newMap[id] = w
+	}
+
+	return newMap
+}
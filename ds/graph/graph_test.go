@@ -131,3 +131,152 @@ func TestGraph_DeleteNode(t *testing.T) {
 		t.Errorf("UpdateNode: expected Node to return nil, got %v", el)
 	}
 }
+
+func TestGraph_DeleteNode_Edges(t *testing.T) {
+	newGraph := graph.New()
+	id1 := newGraph.AddNode(5)
+	id2 := newGraph.AddNode(6)
+	newGraph.AddEdge(id1, id2, 1)
+	newGraph.AddEdge(id2, id1, 2)
+
+	newGraph.DeleteNode(id1)
+	if len(newGraph.NodeOutgoingEdges(id2)) != 0 || len(newGraph.NodeIncomingEdges(id2)) != 0 {
+		t.Errorf("DeleteNode: expected edges of the deleted node to be deleted")
+	}
+	if newGraph.HasEdge(id1, id2) || newGraph.HasEdge(id2, id1) {
+		t.Errorf("DeleteNode: expected HasEdge to be false, got true")
+	}
+	if newGraph.DeleteEdge(id1, id2) {
+		t.Errorf("DeleteEdge: expected DeleteEdge to return false, got true")
+	}
+}
+
+func TestGraph_AddOrUpdateEdge(t *testing.T) {
+	newGraph := graph.New()
+	id1 := newGraph.AddNode(5)
+	id2 := newGraph.AddNode(6)
+	newGraph.AddOrUpdateEdge(id1, id2, 1)
+	newGraph.AddOrUpdateEdge(id1, id2, 2)
+
+	e := newGraph.Edge(id1, id2)
+	if e == nil || e.Weight != 2 {
+		t.Errorf("AddOrUpdateEdge: expected Edge to return Edge with Weight 2, got %v", e)
+	}
+	if w := newGraph.NodeOutgoingEdges(id1)[id2]; w != 2 {
+		t.Errorf("AddOrUpdateEdge: expected outgoing edge weight 2, got %d", w)
+	}
+	if w := newGraph.NodeIncomingEdges(id2)[id1]; w != 2 {
+		t.Errorf("AddOrUpdateEdge: expected incoming edge weight 2, got %d", w)
+	}
+}
+
+func TestGraph_Copy(t *testing.T) {
+	newGraph := graph.New()
+	id1 := newGraph.AddNode(5)
+	id2 := newGraph.AddNode(6)
+	newGraph.AddEdge(id1, id2, 1)
+
+	copiedGraph := newGraph.Copy()
+	copiedGraph.UpdateEdge(id1, id2, 2)
+	id3 := copiedGraph.AddNode(7)
+	if id3 == id1 || id3 == id2 {
+		t.Errorf("Copy: expected AddNode to return a new id, got %d", id3)
+	}
+
+	e := copiedGraph.Edge(id1, id2)
+	if e == nil || e.Weight != 2 {
+		t.Errorf("Copy: expected Edge to return Edge with Weight 2, got %v", e)
+	}
+
+	e = newGraph.Edge(id1, id2)
+	if e == nil || e.Weight != 1 {
+		t.Errorf("Copy: expected Edge to return Edge with Weight 1, got %v", e)
+	}
+	if newGraph.Len() != 2 {
+		t.Errorf("Copy: expected Len to be 2, got %d", newGraph.Len())
+	}
+}
+
+func TestGraph_EachNode(t *testing.T) {
+	newGraph := graph.New()
+	id1 := newGraph.AddNode(5)
+	id2 := newGraph.AddNode(6)
+
+	m := make(map[int]int)
+	newGraph.EachNode(func(n graph.Node) bool {
+		m[n.ID] = n.Value
+		return false
+	})
+	if len(m) != 2 || m[id1] != 5 || m[id2] != 6 {
+		t.Errorf("EachNode: expected nodes to be map[%d:5 %d:6], got %v", id1, id2, m)
+	}
+}
+
+func TestGraph_Nodes(t *testing.T) {
+	newGraph := graph.New()
+	id1 := newGraph.AddNode(5)
+	id2 := newGraph.AddNode(6)
+
+	m := make(map[int]int)
+	for n := range newGraph.Nodes() {
+		m[n.ID] = n.Value
+	}
+	if len(m) != 2 || m[id1] != 5 || m[id2] != 6 {
+		t.Errorf("Nodes: expected nodes to be map[%d:5 %d:6], got %v", id1, id2, m)
+	}
+
+	for n := range newGraph.Nodes() {
+		newGraph.DeleteNode(n.ID)
+	}
+	if !newGraph.Empty() {
+		t.Errorf("Nodes: expected all nodes to be deleted, got %d nodes", newGraph.Len())
+	}
+}
+
+func TestGraph_Edges(t *testing.T) {
+	newGraph := graph.New()
+	id1 := newGraph.AddNode(5)
+	id2 := newGraph.AddNode(6)
+	newGraph.AddEdge(id1, id2, 1)
+	newGraph.AddEdge(id2, id1, 2)
+	newGraph.AddEdge(id2, id2, 3)
+
+	sum := 0
+	count := 0
+	for e := range newGraph.Edges() {
+		sum += e.Weight
+		count++
+	}
+	if count != 3 || sum != 6 {
+		t.Errorf("Edges: expected 3 edges with total weight 6, got %d edges with total weight %d", count, sum)
+	}
+
+	count = 0
+	for range newGraph.Edges() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("Edges: expected break to stop the iteration after 1 edge, got %d", count)
+	}
+}
+
+func TestGraph_EachEdge(t *testing.T) {
+	newGraph := graph.New()
+	id1 := newGraph.AddNode(5)
+	id2 := newGraph.AddNode(6)
+	newGraph.AddEdge(id1, id2, 1)
+	newGraph.AddEdge(id2, id1, 2)
+	newGraph.AddEdge(id2, id2, 3)
+
+	sum := 0
+	count := 0
+	newGraph.EachEdge(func(e graph.Edge) bool {
+		sum += e.Weight
+		count++
+		return false
+	})
+	if count != 3 || sum != 6 {
+		t.Errorf("EachEdge: expected 3 edges with total weight 6, got %d edges with total weight %d", count, sum)
+	}
+}
@@ -0,0 +1,276 @@
+package graph
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// SyncGraph represents a graph that is safe for concurrent use by multiple goroutines. Reads are
+// guarded by a shared lock and mutations by an exclusive lock.
+//
+// Long running readers can take a Snapshot, which is a consistent view of the graph that is not
+// affected by later mutations. Snapshots are copy-on-write: taking one is O(1) and the underlying
+// graph is only copied by the first mutation after a snapshot has been taken.
+type SyncGraph struct {
+	mu sync.RWMutex
+	g  *Graph
+
+	// shared is true if g is referenced by a snapshot and must be copied before it is mutated.
+	shared atomic.Bool
+}
+
+// NewSyncGraph returns a new concurrency-safe graph instance wrapping g. If g is nil, a new empty
+// graph is used. The caller should not use g directly after this call.
+func NewSyncGraph(g *Graph) *SyncGraph {
+	if g == nil {
+		g = New()
+	}
+
+	return &SyncGraph{g: g}
+}
+
+// Len returns the number of nodes in the graph.
+func (sg *SyncGraph) Len() int {
+	sg.mu.RLock()
+	defer sg.mu.RUnlock()
+	return sg.g.Len()
+}
+
+// Empty checks whether the graph is empty.
+func (sg *SyncGraph) Empty() bool {
+	sg.mu.RLock()
+	defer sg.mu.RUnlock()
+	return sg.g.Empty()
+}
+
+// Clear deletes all the items from the graph.
+func (sg *SyncGraph) Clear() {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	sg.writable().Clear()
+}
+
+// Node returns the node with the given id. If such a node doesn't exist, nil is returned.
+func (sg *SyncGraph) Node(id int) *Node {
+	sg.mu.RLock()
+	defer sg.mu.RUnlock()
+	return sg.g.Node(id)
+}
+
+// HasNode checks if a node with the given id exists.
+func (sg *SyncGraph) HasNode(id int) bool {
+	sg.mu.RLock()
+	defer sg.mu.RUnlock()
+	return sg.g.HasNode(id)
+}
+
+// AddNode adds a new node to the graph and returns the id of this new node.
+func (sg *SyncGraph) AddNode(value int) int {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.writable().AddNode(value)
+}
+
+// UpdateNode updates the value of the node with the given id.
+func (sg *SyncGraph) UpdateNode(id, value int) bool {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.writable().UpdateNode(id, value)
+}
+
+// DeleteNode deletes the node with the given id.
+func (sg *SyncGraph) DeleteNode(id int) bool {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.writable().DeleteNode(id)
+}
+
+// Edge returns the edge with the given source and target ids. If such an edge doesn't exist, nil
+// is returned.
+func (sg *SyncGraph) Edge(sourceID, targetID int) *Edge {
+	sg.mu.RLock()
+	defer sg.mu.RUnlock()
+	return sg.g.Edge(sourceID, targetID)
+}
+
+// HasEdge checks if an edge exists with the given source and target ids.
+func (sg *SyncGraph) HasEdge(sourceID, targetID int) bool {
+	sg.mu.RLock()
+	defer sg.mu.RUnlock()
+	return sg.g.HasEdge(sourceID, targetID)
+}
+
+// AddEdge adds a new edge to the graph.
+func (sg *SyncGraph) AddEdge(sourceID, targetID, weight int) bool {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.writable().AddEdge(sourceID, targetID, weight)
+}
+
+// UpdateEdge updates the weight of the edge with the given source and target ids.
+func (sg *SyncGraph) UpdateEdge(sourceID, targetID, weight int) bool {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.writable().UpdateEdge(sourceID, targetID, weight)
+}
+
+// AddOrUpdateEdge adds a new edge or updates the weight of an existing edge of one exists with the
+// given source and target ids.
+func (sg *SyncGraph) AddOrUpdateEdge(sourceID, targetID, weight int) bool {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.writable().AddOrUpdateEdge(sourceID, targetID, weight)
+}
+
+// DeleteEdge deletes the edge with the given source and target ids.
+func (sg *SyncGraph) DeleteEdge(sourceID, targetID int) bool {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.writable().DeleteEdge(sourceID, targetID)
+}
+
+// NodeOutgoingEdges returns all the outgoing edges from the node with the given id. Unlike
+// Graph.NodeOutgoingEdges, the returned map is a copy and can be used freely.
+func (sg *SyncGraph) NodeOutgoingEdges(id int) map[int]int {
+	sg.mu.RLock()
+	defer sg.mu.RUnlock()
+
+	ett := sg.g.NodeOutgoingEdges(id)
+	if ett == nil {
+		return nil
+	}
+
+	return copyEdgeMap(ett)
+}
+
+// NodeIncomingEdges returns all the incoming edges from the node with the given id. Unlike
+// Graph.NodeIncomingEdges, the returned map is a copy and can be used freely.
+func (sg *SyncGraph) NodeIncomingEdges(id int) map[int]int {
+	sg.mu.RLock()
+	defer sg.mu.RUnlock()
+
+	ets := sg.g.NodeIncomingEdges(id)
+	if ets == nil {
+		return nil
+	}
+
+	return copyEdgeMap(ets)
+}
+
+// DeleteNodeOutgoingEdges deletes all the outgoing edges from the node with the given id.
+func (sg *SyncGraph) DeleteNodeOutgoingEdges(id int) bool {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.writable().DeleteNodeOutgoingEdges(id)
+}
+
+// DeleteNodeIncomingEdges deletes all the incoming edges to the node with the given id.
+func (sg *SyncGraph) DeleteNodeIncomingEdges(id int) bool {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.writable().DeleteNodeIncomingEdges(id)
+}
+
+// DeleteNodeEdges deletes all the outgoing and incoming edges from the node with the given id.
+func (sg *SyncGraph) DeleteNodeEdges(id int) bool {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.writable().DeleteNodeEdges(id)
+}
+
+// AddHook registers a hook that is called after every mutation of the graph and returns an id that
+// can be used to remove it. Hooks are called while holding the write lock, so they should not call
+// any method of the SyncGraph.
+func (sg *SyncGraph) AddHook(hook Hook) int {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.writable().AddHook(hook)
+}
+
+// RemoveHook removes the hook with the given id.
+func (sg *SyncGraph) RemoveHook(id int) bool {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.writable().RemoveHook(id)
+}
+
+// Snapshot returns a consistent, point-in-time view of the graph in O(1). Later mutations of the
+// SyncGraph are not visible in the snapshot, so it can be read without any locking while writers
+// continue.
+// NOTE: The returned graph should not be mutated as it's shared with the SyncGraph until its next
+// mutation. Use Copy on the snapshot to get a graph that can be mutated.
+func (sg *SyncGraph) Snapshot() *Graph {
+	sg.mu.RLock()
+	defer sg.mu.RUnlock()
+
+	sg.shared.Store(true)
+	return sg.g
+}
+
+// View calls fn with the current graph while holding the read lock. It can be used to perform
+// multiple reads consistently without taking a snapshot.
+// NOTE: fn should not mutate the graph, retain it after returning or call any method of the
+// SyncGraph.
+func (sg *SyncGraph) View(fn func(g *Graph)) {
+	sg.mu.RLock()
+	defer sg.mu.RUnlock()
+	fn(sg.g)
+}
+
+// Update applies a batch of mutations as a single transaction. fn is called with the graph while
+// holding the write lock, and its mutations are recorded in a ChangeLog. If fn returns an error or
+// panics, the inverted log is replayed to undo all of its mutations and the error is returned.
+// Either way, readers never observe a partially applied batch.
+//
+// Registered hooks are only called once the batch is applied successfully, with the events of all
+// the mutations of the batch in order.
+//
+// The batch is applied in place, so undoing it costs O(len(batch)). The graph is only copied if a
+// snapshot of it has been taken since the last mutation.
+// NOTE: fn should not retain the graph after returning, add or remove hooks, or call any method of
+// the SyncGraph.
+func (sg *SyncGraph) Update(fn func(g *Graph) error) (err error) {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+
+	g := sg.writable()
+	hooks, currHookID, currID := g.hooks, g.currHookID, g.currID
+
+	var log ChangeLog
+	g.hooks = nil
+	g.AddHook(log.Record)
+
+	done := false
+	defer func() {
+		if !done {
+			// Undo the batch without notifying anyone, including the change log.
+			g.hooks = nil
+			log.Invert().Replay(g)
+			g.currID = currID
+		}
+
+		g.hooks, g.currHookID = hooks, currHookID
+		if done {
+			for _, e := range log {
+				g.emit(e)
+			}
+		}
+	}()
+
+	err = fn(g)
+	done = err == nil
+	return err
+}
+
+// writable returns the graph that can be mutated in place, copying it first if it is referenced by
+// a snapshot. It must only be called while holding the write lock.
+func (sg *SyncGraph) writable() *Graph {
+	if sg.shared.Load() {
+		newGraph := sg.g.Copy()
+		newGraph.hooks, newGraph.currHookID = sg.g.hooks, sg.g.currHookID
+		sg.g = newGraph
+		sg.shared.Store(false)
+	}
+
+	return sg.g
+}
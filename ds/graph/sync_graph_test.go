@@ -0,0 +1,208 @@
+package graph_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/graph"
+)
+
+func TestSyncGraph_Snapshot(t *testing.T) {
+	sg := graph.NewSyncGraph(nil)
+	id1 := sg.AddNode(5)
+	id2 := sg.AddNode(6)
+	sg.AddEdge(id1, id2, 1)
+
+	snapshot := sg.Snapshot()
+	sg.UpdateNode(id1, 7)
+	sg.DeleteEdge(id1, id2)
+	sg.AddNode(8)
+
+	if snapshot.Len() != 2 {
+		t.Errorf("Snapshot: expected Len to be 2, got %d", snapshot.Len())
+	}
+
+	n := snapshot.Node(id1)
+	if n == nil || n.Value != 5 {
+		t.Errorf("Snapshot: expected Node to return Node with Value 5, got %v", n)
+	}
+	if !snapshot.HasEdge(id1, id2) {
+		t.Errorf("Snapshot: expected HasEdge to be true, got false")
+	}
+
+	n = sg.Node(id1)
+	if n == nil || n.Value != 7 {
+		t.Errorf("Snapshot: expected Node to return Node with Value 7, got %v", n)
+	}
+	if sg.HasEdge(id1, id2) {
+		t.Errorf("Snapshot: expected HasEdge to be false, got true")
+	}
+	if sg.Len() != 3 {
+		t.Errorf("Snapshot: expected Len to be 3, got %d", sg.Len())
+	}
+}
+
+func TestSyncGraph_Update(t *testing.T) {
+	sg := graph.NewSyncGraph(nil)
+	id1 := sg.AddNode(5)
+	id2 := sg.AddNode(6)
+
+	var id3 int
+	err := sg.Update(func(g *graph.Graph) error {
+		id3 = g.AddNode(7)
+		g.AddEdge(id1, id2, 1)
+		g.AddEdge(id2, id3, 2)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Update: expected Update to return nil, got %v", err)
+	}
+	if sg.Len() != 3 || !sg.HasEdge(id1, id2) || !sg.HasEdge(id2, id3) {
+		t.Errorf("Update: expected all mutations to be applied")
+	}
+
+	errAbort := errors.New("abort")
+	err = sg.Update(func(g *graph.Graph) error {
+		g.DeleteNode(id1)
+		g.DeleteEdge(id1, id2)
+		return errAbort
+	})
+	if err != errAbort {
+		t.Errorf("Update: expected Update to return %v, got %v", errAbort, err)
+	}
+	if !sg.HasNode(id1) || !sg.HasEdge(id1, id2) {
+		t.Errorf("Update: expected no mutations to be applied")
+	}
+}
+
+func TestSyncGraph_Update_Rollback(t *testing.T) {
+	sg := graph.NewSyncGraph(nil)
+	id1 := sg.AddNode(5)
+	id2 := sg.AddNode(6)
+	sg.AddEdge(id1, id2, 1)
+	sg.AddEdge(id2, id1, 2)
+
+	var events []graph.Event
+	sg.AddHook(func(e graph.Event) {
+		events = append(events, e)
+	})
+	snapshot := sg.Snapshot()
+
+	errAbort := errors.New("abort")
+	err := sg.Update(func(g *graph.Graph) error {
+		id3 := g.AddNode(7)
+		g.UpdateNode(id1, 8)
+		g.UpdateEdge(id1, id2, 3)
+		g.AddEdge(id2, id3, 4)
+		g.DeleteNode(id2)
+		g.Clear()
+		g.AddNode(9)
+		return errAbort
+	})
+	if err != errAbort {
+		t.Errorf("Update: expected Update to return %v, got %v", errAbort, err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Update: expected no events for a failed batch, got %v", events)
+	}
+
+	assertRolledBack := func(g interface {
+		Len() int
+		Node(id int) *graph.Node
+		Edge(sourceID, targetID int) *graph.Edge
+	}) {
+		t.Helper()
+
+		if g.Len() != 2 {
+			t.Errorf("Update: expected Len to be 2, got %d", g.Len())
+		}
+		if n := g.Node(id1); n == nil || n.Value != 5 {
+			t.Errorf("Update: expected Node to return Node with Value 5, got %v", n)
+		}
+		if e := g.Edge(id1, id2); e == nil || e.Weight != 1 {
+			t.Errorf("Update: expected Edge to return Edge with Weight 1, got %v", e)
+		}
+		if e := g.Edge(id2, id1); e == nil || e.Weight != 2 {
+			t.Errorf("Update: expected Edge to return Edge with Weight 2, got %v", e)
+		}
+	}
+	assertRolledBack(sg)
+	assertRolledBack(snapshot)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Update: expected the panic of fn to be propagated")
+			}
+		}()
+
+		sg.Update(func(g *graph.Graph) error {
+			g.DeleteEdge(id1, id2)
+			g.AddNode(10)
+			panic("abort")
+		})
+	}()
+	assertRolledBack(sg)
+
+	// The ids of the nodes added by the failed batches are reused.
+	if id := sg.AddNode(7); id != id2+1 {
+		t.Errorf("Update: expected AddNode to return %d, got %d", id2+1, id)
+	}
+	if len(events) != 1 || events[0].Op != graph.OpAddNode {
+		t.Errorf("Update: expected a single AddNode event, got %v", events)
+	}
+}
+
+func TestSyncGraph_NodeOutgoingEdges(t *testing.T) {
+	sg := graph.NewSyncGraph(nil)
+	id1 := sg.AddNode(5)
+	id2 := sg.AddNode(6)
+	sg.AddEdge(id1, id2, 1)
+
+	ett := sg.NodeOutgoingEdges(id1)
+	ett[id1] = 2
+	if sg.HasEdge(id1, id1) {
+		t.Errorf("NodeOutgoingEdges: expected HasEdge to be false, got true")
+	}
+
+	ets := sg.NodeIncomingEdges(id2)
+	if len(ets) != 1 || ets[id1] != 1 {
+		t.Errorf("NodeIncomingEdges: expected map[%d:1], got %v", id1, ets)
+	}
+}
+
+func TestSyncGraph_Concurrent(t *testing.T) {
+	sg := graph.NewSyncGraph(nil)
+	root := sg.AddNode(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				id := sg.AddNode(j)
+				sg.AddEdge(root, id, j)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				snapshot := sg.Snapshot()
+				if len(snapshot.NodeOutgoingEdges(root)) > snapshot.Len()-1 {
+					t.Errorf("Concurrent: snapshot has more edges than nodes")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if sg.Len() != 401 {
+		t.Errorf("Concurrent: expected Len to be 401, got %d", sg.Len())
+	}
+	if len(sg.NodeOutgoingEdges(root)) != 400 {
+		t.Errorf("Concurrent: expected 400 outgoing edges, got %d", len(sg.NodeOutgoingEdges(root)))
+	}
+}
@@ -0,0 +1,218 @@
+package heap
+
+import "iter"
+
+// BinomialHeap represents a binomial heap ordered by a less function. It is a list of heap-ordered
+// binomial trees with distinct degrees in increasing order, where a tree of degree k has 2^k
+// nodes, like the binary representation of the number of items.
+//
+// Peek takes O(1) time, and Push, Pop and Merge take O(log(n)) time.
+type BinomialHeap[T any] struct {
+	// head is the first root in the list of roots, and min is the root with the minimum item.
+	head, min *binomialNode[T]
+	len       int
+	less      func(a, b T) bool
+}
+
+type binomialNode[T any] struct {
+	value T
+
+	// child is the first child of the node, which has the highest degree, and sibling is the next
+	// sibling of the node, or the next root if the node is a root.
+	child, sibling *binomialNode[T]
+	degree         int
+}
+
+// NewBinomialHeap returns a new binomial heap instance ordered by less with the given items
+// inserted into it.
+func NewBinomialHeap[T any](less func(a, b T) bool, items ...T) *BinomialHeap[T] {
+	h := &BinomialHeap[T]{less: less}
+	h.Push(items...)
+	return h
+}
+
+// Len returns the number of items in the heap.
+func (h *BinomialHeap[T]) Len() int {
+	return h.len
+}
+
+// Empty checks whether the heap is empty.
+func (h *BinomialHeap[T]) Empty() bool {
+	return h.len == 0
+}
+
+// Clear deletes all the items from the heap.
+func (h *BinomialHeap[T]) Clear() {
+	h.head, h.min = nil, nil
+	h.len = 0
+}
+
+// Peek returns the item at the top of the heap without removing it. If the heap is empty, the
+// second return value is false.
+func (h *BinomialHeap[T]) Peek() (T, bool) {
+	if h.min == nil {
+		var zero T
+		return zero, false
+	}
+
+	return h.min.value, true
+}
+
+// Push inserts the given items into the heap.
+func (h *BinomialHeap[T]) Push(items ...T) {
+	for _, item := range items {
+		h.head = h.union(h.head, &binomialNode[T]{value: item})
+		h.len++
+	}
+	h.findMin()
+}
+
+// Pop removes the item at the top of the heap and returns it. If the heap is empty, the second
+// return value is false.
+func (h *BinomialHeap[T]) Pop() (T, bool) {
+	if h.min == nil {
+		var zero T
+		return zero, false
+	}
+
+	n := h.min
+	if h.head == n {
+		h.head = n.sibling
+	} else {
+		prev := h.head
+		for prev.sibling != n {
+			prev = prev.sibling
+		}
+		prev.sibling = n.sibling
+	}
+
+	// The children of n are binomial trees in decreasing order of degree, so they are reversed to
+	// form a list of roots.
+	var children *binomialNode[T]
+	for c := n.child; c != nil; {
+		next := c.sibling
+		c.sibling = children
+		children = c
+		c = next
+	}
+
+	h.head = h.union(h.head, children)
+	h.len--
+	h.findMin()
+	return n.value, true
+}
+
+// Merge moves all the items of other into the heap in O(log(n)) time, leaving other empty.
+func (h *BinomialHeap[T]) Merge(other *BinomialHeap[T]) {
+	if other == nil || other == h {
+		return
+	}
+
+	h.head = h.union(h.head, other.head)
+	h.len += other.len
+	h.findMin()
+	other.Clear()
+}
+
+// All returns an iterator over the items of the heap in no particular order, without removing
+// them. The heap should not be modified during iteration.
+func (h *BinomialHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if h.head == nil {
+			return
+		}
+
+		stack := []*binomialNode[T]{h.head}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if n.sibling != nil {
+				stack = append(stack, n.sibling)
+			}
+			if n.child != nil {
+				stack = append(stack, n.child)
+			}
+
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that removes the items of the heap in order as it yields them. If the
+// iteration stops early, the remaining items are kept in the heap.
+func (h *BinomialHeap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+// findMin sets min to the root with the minimum item.
+func (h *BinomialHeap[T]) findMin() {
+	h.min = nil
+	for n := h.head; n != nil; n = n.sibling {
+		if h.min == nil || h.less(n.value, h.min.value) {
+			h.min = n
+		}
+	}
+}
+
+// union combines the lists of roots a and b into a single list of roots with distinct degrees and
+// returns its head. Like adding binary numbers, two trees of the same degree are linked into a tree
+// of the next degree.
+func (h *BinomialHeap[T]) union(a, b *binomialNode[T]) *binomialNode[T] {
+	head := mergeRoots(a, b)
+	if head == nil {
+		return nil
+	}
+
+	var prev *binomialNode[T]
+	x := head
+	for next := x.sibling; next != nil; next = x.sibling {
+		switch {
+		case x.degree != next.degree || (next.sibling != nil && next.sibling.degree == x.degree):
+			prev, x = x, next
+		case !h.less(next.value, x.value):
+			x.sibling = next.sibling
+			linkBinomial(next, x)
+		default:
+			if prev == nil {
+				head = next
+			} else {
+				prev.sibling = next
+			}
+			linkBinomial(x, next)
+			x = next
+		}
+	}
+
+	return head
+}
+
+// mergeRoots merges the lists of roots a and b into a single list in increasing order of degree,
+// which may have up to two roots of every degree.
+func mergeRoots[T any](a, b *binomialNode[T]) *binomialNode[T] {
+	var head binomialNode[T]
+	tail := &head
+	for a != nil && b != nil {
+		if a.degree <= b.degree {
+			tail.sibling, a = a, a.sibling
+		} else {
+			tail.sibling, b = b, b.sibling
+		}
+		tail = tail.sibling
+	}
+
+	if a != nil {
+		tail.sibling = a
+	} else {
+		tail.sibling = b
+	}
+	return head.sibling
+}
+
+// linkBinomial makes the root y the first child of the root x, which has the same degree.
+func linkBinomial[T any](y, x *binomialNode[T]) {
+	y.sibling = x.child
+	x.child = y
+	x.degree++
+}
@@ -0,0 +1,21 @@
+package heap_test
+
+import (
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+func newBinomialHeap(items ...int) *heap.BinomialHeap[int] {
+	return heap.NewBinomialHeap(less, items...)
+}
+
+func TestBinomialHeap(t *testing.T) {
+	testInterfaceHelper(t, func(items ...int) heap.Interface[int] {
+		return newBinomialHeap(items...)
+	})
+}
+
+func TestBinomialHeap_Merge(t *testing.T) {
+	testMergeHelper(t, newBinomialHeap, (*heap.BinomialHeap[int]).Merge)
+}
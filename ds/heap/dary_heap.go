@@ -0,0 +1,209 @@
+package heap
+
+import "iter"
+
+// DaryHeap represents a d-ary heap ordered by a less function. It is like Heap, except that every
+// node has d children instead of 2: the children of the item at index i are at indices d*i+1 to
+// d*i+d.
+//
+// A larger d makes the tree shallower, so Push and Update that move items up are faster, while Pop
+// compares more children at every level, taking O(d*log_d(n)) time. A 4-ary heap usually beats a
+// binary heap in practice since the children of a node share a cache line.
+type DaryHeap[T any] struct {
+	arr  []T
+	d    int
+	less func(a, b T) bool
+}
+
+// NewDaryHeap returns a new d-ary heap instance ordered by less with the given items inserted into
+// it. If d is less than 2, 2 is used. The heap is built in O(n) time.
+func NewDaryHeap[T any](d int, less func(a, b T) bool, items ...T) *DaryHeap[T] {
+	h := &DaryHeap[T]{arr: append(make([]T, 0, len(items)), items...), d: max(d, 2), less: less}
+	for i := (len(h.arr) - 2) / h.d; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// Arity returns the number of children of every node of the heap.
+func (h *DaryHeap[T]) Arity() int {
+	return h.d
+}
+
+// Len returns the number of items in the heap.
+func (h *DaryHeap[T]) Len() int {
+	return len(h.arr)
+}
+
+// Empty checks whether the heap is empty.
+func (h *DaryHeap[T]) Empty() bool {
+	return len(h.arr) == 0
+}
+
+// Clear deletes all the items from the heap.
+func (h *DaryHeap[T]) Clear() {
+	clear(h.arr)
+	h.arr = h.arr[:0]
+}
+
+// Peek returns the item at the top of the heap without removing it. If the heap is empty, the
+// second return value is false.
+func (h *DaryHeap[T]) Peek() (T, bool) {
+	if len(h.arr) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return h.arr[0], true
+}
+
+// Push inserts the given items into the heap. Each item takes O(log_d(n)) time.
+func (h *DaryHeap[T]) Push(items ...T) {
+	for _, item := range items {
+		h.arr = append(h.arr, item)
+		h.up(len(h.arr) - 1)
+	}
+}
+
+// Pop removes the item at the top of the heap and returns it. If the heap is empty, the second
+// return value is false.
+func (h *DaryHeap[T]) Pop() (T, bool) {
+	return h.Remove(0)
+}
+
+// PushPop inserts the item into the heap and then removes and returns the item at the top of the
+// heap, using at most a single sift down like Heap.PushPop.
+func (h *DaryHeap[T]) PushPop(item T) T {
+	if len(h.arr) == 0 || !h.less(h.arr[0], item) {
+		return item
+	}
+
+	top := h.arr[0]
+	h.arr[0] = item
+	h.down(0)
+	return top
+}
+
+// Update replaces the item at index i and moves it up or down to restore the heap order. It
+// returns false if the heap doesn't have enough items.
+func (h *DaryHeap[T]) Update(i int, item T) bool {
+	if i < 0 || i >= len(h.arr) {
+		return false
+	}
+
+	h.arr[i] = item
+	h.fix(i)
+	return true
+}
+
+// Remove removes the item at index i from the heap and returns it. If the heap doesn't have enough
+// items, the second return value is false.
+func (h *DaryHeap[T]) Remove(i int) (T, bool) {
+	if i < 0 || i >= len(h.arr) {
+		var zero T
+		return zero, false
+	}
+
+	item := h.arr[i]
+	last := len(h.arr) - 1
+	h.arr[i] = h.arr[last]
+
+	var zero T
+	h.arr[last] = zero
+	h.arr = h.arr[:last]
+	if i < last {
+		h.fix(i)
+	}
+
+	return item, true
+}
+
+// Index returns the index of the first item in the heap, in the order of the slice, for which match
+// returns true. If there is no such item, -1 is returned. It takes O(n) time.
+func (h *DaryHeap[T]) Index(match func(T) bool) int {
+	for i, item := range h.arr {
+		if match(item) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// At returns the item at index i. If the heap doesn't have enough items, the second return value is
+// false.
+func (h *DaryHeap[T]) At(i int) (T, bool) {
+	if i < 0 || i >= len(h.arr) {
+		var zero T
+		return zero, false
+	}
+
+	return h.arr[i], true
+}
+
+// All returns an iterator over the items of the heap in no particular order, without removing
+// them. The heap should not be modified during iteration.
+func (h *DaryHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range h.arr {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that removes the items of the heap in order as it yields them. If the
+// iteration stops early, the remaining items are kept in the heap.
+func (h *DaryHeap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+// Copy creates a new copy of the heap.
+func (h *DaryHeap[T]) Copy() *DaryHeap[T] {
+	return &DaryHeap[T]{arr: append(make([]T, 0, len(h.arr)), h.arr...), d: h.d, less: h.less}
+}
+
+// fix moves the item at index i up or down to restore the heap order.
+func (h *DaryHeap[T]) fix(i int) {
+	if i > 0 && h.less(h.arr[i], h.arr[(i-1)/h.d]) {
+		h.up(i)
+	} else {
+		h.down(i)
+	}
+}
+
+func (h *DaryHeap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / h.d
+		if !h.less(h.arr[i], h.arr[parent]) {
+			break
+		}
+
+		h.arr[i], h.arr[parent] = h.arr[parent], h.arr[i]
+		i = parent
+	}
+}
+
+func (h *DaryHeap[T]) down(i int) {
+	for {
+		first := h.d*i + 1
+		if first >= len(h.arr) {
+			break
+		}
+
+		top := first
+		for j := first + 1; j < min(first+h.d, len(h.arr)); j++ {
+			if h.less(h.arr[j], h.arr[top]) {
+				top = j
+			}
+		}
+
+		if !h.less(h.arr[top], h.arr[i]) {
+			break
+		}
+
+		h.arr[i], h.arr[top] = h.arr[top], h.arr[i]
+		i = top
+	}
+}
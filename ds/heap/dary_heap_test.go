@@ -0,0 +1,65 @@
+package heap_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+func TestDaryHeap(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		t.Run(fmt.Sprintf("D%d", d), func(t *testing.T) {
+			testInterfaceHelper(t, func(items ...int) heap.Interface[int] {
+				return heap.NewDaryHeap(d, less, items...)
+			})
+		})
+	}
+
+	if a := heap.NewDaryHeap(0, less).Arity(); a != 2 {
+		t.Errorf("DaryHeap: expected Arity of a heap with d 0 to be 2, got %d", a)
+	}
+}
+
+func TestDaryHeap_UpdateRemove(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	arr := make([]int, 300)
+	for i := range arr {
+		arr[i] = r.Intn(1000)
+	}
+
+	newHeap := heap.NewDaryHeap(4, less, arr...)
+	expected := slices.Clone(arr)
+	for n := 0; n < 1000; n++ {
+		i := r.Intn(newHeap.Len())
+		old, _ := newHeap.At(i)
+		if n%2 == 0 {
+			v := r.Intn(1000)
+			newHeap.Update(i, v)
+			expected[slices.Index(expected, old)] = v
+		} else {
+			if v, ok := newHeap.Remove(i); !ok || v != old {
+				t.Fatalf("UpdateRemove: expected Remove to return (%d, true), got (%d, %t)", old, v, ok)
+			}
+			expected = slices.Delete(expected, slices.Index(expected, old), slices.Index(expected, old)+1)
+			newHeap.Push(old + 1)
+			expected = append(expected, old+1)
+		}
+	}
+
+	if newHeap.Update(-1, 0) {
+		t.Error("UpdateRemove: expected Update -1 to return false, got true")
+	}
+	if v := newHeap.PushPop(-1); v != -1 {
+		t.Errorf("UpdateRemove: expected PushPop -1 to return -1, got %d", v)
+	}
+	if i := newHeap.Index(func(v int) bool { return v == expected[0] }); i < 0 {
+		t.Errorf("UpdateRemove: expected Index of %d to be found", expected[0])
+	}
+
+	slices.Sort(expected)
+	assertInterfaceValues(t, "UpdateRemove", newHeap.Copy(), expected)
+	assertInterfaceValues(t, "UpdateRemove", newHeap, expected)
+}
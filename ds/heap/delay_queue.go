@@ -0,0 +1,182 @@
+package heap
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock is the source of time of a DelayQueue. It can be replaced with a fake clock in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After returns a channel that receives the current time once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// DelayQueue represents a queue of items that become available at given times. It is safe for
+// concurrent use by multiple goroutines. Pop blocks until the item with the earliest time is due,
+// and items due at the same time are popped in the order they were pushed.
+//
+// Close makes the queue reject new items, while the items already in it can still be popped when
+// they are due.
+type DelayQueue[T any] struct {
+	mu      sync.Mutex
+	h       *Heap[delayedItem[T]]
+	clock   Clock
+	seq     uint64
+	closed  bool
+	changed broadcast
+}
+
+type delayedItem[T any] struct {
+	item T
+	at   time.Time
+
+	// seq is the number of items pushed before the item, which orders the items with equal times.
+	seq uint64
+}
+
+func delayedLess[T any](a, b delayedItem[T]) bool {
+	if !a.at.Equal(b.at) {
+		return a.at.Before(b.at)
+	}
+	return a.seq < b.seq
+}
+
+// NewDelayQueue returns a new empty delay queue instance using the system clock.
+func NewDelayQueue[T any]() *DelayQueue[T] {
+	return &DelayQueue[T]{h: New(delayedLess[T]), clock: realClock{}}
+}
+
+// SetClock sets the clock used to decide when the items are due, which is the system clock by
+// default. It is mainly useful for testing. A nil clock restores the system clock.
+func (q *DelayQueue[T]) SetClock(clock Clock) {
+	if clock == nil {
+		clock = realClock{}
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.clock = clock
+	q.changed.notify()
+}
+
+// Len returns the number of items in the queue, including the items that are not due yet.
+func (q *DelayQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.h.Len()
+}
+
+// Empty checks whether the queue is empty.
+func (q *DelayQueue[T]) Empty() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.h.Empty()
+}
+
+// Peek returns the item with the earliest time and its time without removing it, even if it is
+// not due yet. If the queue is empty, the last return value is false.
+func (q *DelayQueue[T]) Peek() (T, time.Time, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	d, ok := q.h.Peek()
+	return d.item, d.at, ok
+}
+
+// Push inserts the item into the queue to become available at the given time. If the queue is
+// closed, ErrClosed is returned.
+func (q *DelayQueue[T]) Push(item T, at time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrClosed
+	}
+
+	q.h.Push(delayedItem[T]{item: item, at: at, seq: q.seq})
+	q.seq++
+	q.changed.notify()
+	return nil
+}
+
+// PushAfter inserts the item into the queue to become available after the given delay. If the
+// queue is closed, ErrClosed is returned.
+func (q *DelayQueue[T]) PushAfter(item T, delay time.Duration) error {
+	q.mu.Lock()
+	at := q.clock.Now().Add(delay)
+	q.mu.Unlock()
+	return q.Push(item, at)
+}
+
+// Pop removes the item with the earliest time and returns it, waiting until it is due. An item
+// pushed while waiting is taken into account if it is due earlier. If the queue is closed and
+// empty, ErrClosed is returned. If ctx is done before an item is due, ctx.Err() is returned.
+func (q *DelayQueue[T]) Pop(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		var timer <-chan time.Time
+		if d, ok := q.h.Peek(); ok {
+			wait := d.at.Sub(q.clock.Now())
+			if wait <= 0 {
+				q.h.Pop()
+				q.changed.notify()
+				q.mu.Unlock()
+				return d.item, nil
+			}
+
+			timer = q.clock.After(wait)
+		} else if q.closed {
+			q.mu.Unlock()
+			var zero T
+			return zero, ErrClosed
+		}
+
+		changed := q.changed.wait()
+		q.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-timer:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// TryPop removes the item with the earliest time and returns it without waiting, if it is due. If
+// the queue has no due items, the second return value is false.
+func (q *DelayQueue[T]) TryPop() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	d, ok := q.h.Peek()
+	if !ok || d.at.After(q.clock.Now()) {
+		var zero T
+		return zero, false
+	}
+
+	q.h.Pop()
+	q.changed.notify()
+	return d.item, true
+}
+
+// Close closes the queue. Later calls to Push fail with ErrClosed, and the goroutines waiting in
+// Pop on an empty queue are woken up and return ErrClosed. Closing a closed queue has no effect.
+func (q *DelayQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.changed.notify()
+}
@@ -0,0 +1,158 @@
+package heap_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+// fakeClock is a heap.Clock whose time only moves forward when Advance is called.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+	} else {
+		c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), ch: ch})
+	}
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	waiters := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			waiters = append(waiters, w)
+		} else {
+			w.ch <- c.now
+		}
+	}
+	c.waiters = waiters
+}
+
+func TestDelayQueue(t *testing.T) {
+	clock := newFakeClock()
+	q := heap.NewDelayQueue[string]()
+	q.SetClock(clock)
+
+	q.PushAfter("b", 2*time.Second)
+	q.PushAfter("a", time.Second)
+	q.PushAfter("c", 2*time.Second)
+	if q.Len() != 3 || q.Empty() {
+		t.Errorf("DelayQueue: expected Len to be 3, got %d", q.Len())
+	}
+	if v, at, ok := q.Peek(); !ok || v != "a" || !at.Equal(clock.Now().Add(time.Second)) {
+		t.Errorf("Peek: expected Peek to return a due in 1s, got (%s, %v, %t)", v, at, ok)
+	}
+	if _, ok := q.TryPop(); ok {
+		t.Error("TryPop: expected TryPop to return false before any item is due, got true")
+	}
+
+	clock.Advance(time.Second)
+	if v, ok := q.TryPop(); !ok || v != "a" {
+		t.Errorf("TryPop: expected TryPop to return (a, true), got (%s, %t)", v, ok)
+	}
+
+	popped := make(chan string)
+	go func() {
+		for i := 0; i < 2; i++ {
+			v, _ := q.Pop(context.Background())
+			popped <- v
+		}
+	}()
+	select {
+	case v := <-popped:
+		t.Fatalf("Pop: expected Pop to wait, got %s", v)
+	case <-time.After(5 * time.Millisecond):
+	}
+
+	clock.Advance(time.Second)
+	if v, w := <-popped, <-popped; v != "b" || w != "c" {
+		t.Errorf("Pop: expected Pop to return b and then c, got %s and %s", v, w)
+	}
+}
+
+func TestDelayQueue_Earlier(t *testing.T) {
+	clock := newFakeClock()
+	q := heap.NewDelayQueue[int]()
+	q.SetClock(clock)
+	q.PushAfter(2, time.Hour)
+
+	popped := make(chan int)
+	go func() {
+		v, _ := q.Pop(context.Background())
+		popped <- v
+	}()
+	time.Sleep(5 * time.Millisecond)
+
+	q.Push(1, clock.Now())
+	if v := <-popped; v != 1 {
+		t.Errorf("Earlier: expected Pop to return the earlier item 1, got %d", v)
+	}
+}
+
+func TestDelayQueue_Close(t *testing.T) {
+	clock := newFakeClock()
+	q := heap.NewDelayQueue[int]()
+	q.SetClock(clock)
+	q.PushAfter(1, time.Minute)
+	q.Close()
+	if err := q.Push(2, clock.Now()); !errors.Is(err, heap.ErrClosed) {
+		t.Errorf("Close: expected Push to return ErrClosed, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Pop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Close: expected Pop to wait for the remaining item and time out, got %v", err)
+	}
+
+	clock.Advance(time.Minute)
+	if v, err := q.Pop(context.Background()); err != nil || v != 1 {
+		t.Errorf("Close: expected Pop to return (1, nil), got (%d, %v)", v, err)
+	}
+	if _, err := q.Pop(context.Background()); !errors.Is(err, heap.ErrClosed) {
+		t.Errorf("Close: expected Pop to return ErrClosed, got %v", err)
+	}
+}
+
+func TestDelayQueue_SystemClock(t *testing.T) {
+	q := heap.NewDelayQueue[int]()
+	q.PushAfter(1, 5*time.Millisecond)
+	start := time.Now()
+	if v, err := q.Pop(context.Background()); err != nil || v != 1 {
+		t.Errorf("SystemClock: expected Pop to return (1, nil), got (%d, %v)", v, err)
+	}
+	if elapsed := time.Since(start); elapsed < 5*time.Millisecond {
+		t.Errorf("SystemClock: expected Pop to wait for 5ms, waited %v", elapsed)
+	}
+}
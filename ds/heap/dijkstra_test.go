@@ -0,0 +1,194 @@
+package heap_test
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+// The benchmarks in this file compare the heaps on Dijkstra's shortest paths algorithm over a
+// random sparse graph. The heaps with handles and IndexedPriorityQueue decrease the distance of a
+// vertex in place, and the other heaps push a new entry and skip the stale ones when popped.
+
+type dijkstraEdge struct {
+	to, weight int
+}
+
+type dijkstraEntry struct {
+	vertex, dist int
+}
+
+func dijkstraLess(a, b dijkstraEntry) bool {
+	return a.dist < b.dist
+}
+
+var (
+	dijkstraGraphOnce sync.Once
+	dijkstraGraph     [][]dijkstraEdge
+)
+
+// benchmarkGraph returns a random graph with 10000 vertices and 8 outgoing edges per vertex.
+func benchmarkGraph() [][]dijkstraEdge {
+	dijkstraGraphOnce.Do(func() {
+		dijkstraGraph = randomGraph(rand.New(rand.NewSource(1)), 10000, 8)
+	})
+	return dijkstraGraph
+}
+
+func randomGraph(r *rand.Rand, vertices, degree int) [][]dijkstraEdge {
+	graph := make([][]dijkstraEdge, vertices)
+	for v := range graph {
+		for i := 0; i < degree; i++ {
+			graph[v] = append(graph[v], dijkstraEdge{to: r.Intn(vertices), weight: r.Intn(1000) + 1})
+		}
+	}
+	return graph
+}
+
+func newDists(n int) []int {
+	dists := make([]int, n)
+	for i := range dists {
+		dists[i] = math.MaxInt
+	}
+	return dists
+}
+
+// dijkstraLazy runs Dijkstra's algorithm with a heap that doesn't support decrease-key.
+func dijkstraLazy(graph [][]dijkstraEdge, h heap.Interface[dijkstraEntry]) []int {
+	dists := newDists(len(graph))
+	dists[0] = 0
+	h.Push(dijkstraEntry{vertex: 0})
+	for {
+		e, ok := h.Pop()
+		if !ok {
+			return dists
+		}
+		if e.dist > dists[e.vertex] {
+			continue
+		}
+
+		for _, edge := range graph[e.vertex] {
+			if d := e.dist + edge.weight; d < dists[edge.to] {
+				dists[edge.to] = d
+				h.Push(dijkstraEntry{vertex: edge.to, dist: d})
+			}
+		}
+	}
+}
+
+func dijkstraIndexed(graph [][]dijkstraEdge) []int {
+	dists := newDists(len(graph))
+	dists[0] = 0
+	pq := heap.NewIndexedPriorityQueue[int](less)
+	pq.Push(0, 0)
+	for {
+		v, dist, ok := pq.Pop()
+		if !ok {
+			return dists
+		}
+
+		for _, edge := range graph[v] {
+			if d := dist + edge.weight; d < dists[edge.to] {
+				dists[edge.to] = d
+				pq.Push(edge.to, d)
+			}
+		}
+	}
+}
+
+func dijkstraFibonacci(graph [][]dijkstraEdge) []int {
+	dists := newDists(len(graph))
+	dists[0] = 0
+	nodes := make([]*heap.FibonacciNode[dijkstraEntry], len(graph))
+	h := heap.NewFibonacciHeap(dijkstraLess)
+	h.Insert(dijkstraEntry{vertex: 0})
+	for {
+		e, ok := h.Pop()
+		if !ok {
+			return dists
+		}
+
+		for _, edge := range graph[e.vertex] {
+			if d := e.dist + edge.weight; d < dists[edge.to] {
+				dists[edge.to] = d
+				entry := dijkstraEntry{vertex: edge.to, dist: d}
+				if !h.Update(nodes[edge.to], entry) {
+					nodes[edge.to] = h.Insert(entry)
+				}
+			}
+		}
+	}
+}
+
+func dijkstraPairing(graph [][]dijkstraEdge) []int {
+	dists := newDists(len(graph))
+	dists[0] = 0
+	nodes := make([]*heap.PairingNode[dijkstraEntry], len(graph))
+	h := heap.NewPairingHeap(dijkstraLess)
+	h.Insert(dijkstraEntry{vertex: 0})
+	for {
+		e, ok := h.Pop()
+		if !ok {
+			return dists
+		}
+
+		for _, edge := range graph[e.vertex] {
+			if d := e.dist + edge.weight; d < dists[edge.to] {
+				dists[edge.to] = d
+				entry := dijkstraEntry{vertex: edge.to, dist: d}
+				if !h.Update(nodes[edge.to], entry) {
+					nodes[edge.to] = h.Insert(entry)
+				}
+			}
+		}
+	}
+}
+
+var dijkstraFns = []struct {
+	name string
+	fn   func(graph [][]dijkstraEdge) []int
+}{
+	{"Heap", func(graph [][]dijkstraEdge) []int {
+		return dijkstraLazy(graph, heap.New(dijkstraLess))
+	}},
+	{"DaryHeap4", func(graph [][]dijkstraEdge) []int {
+		return dijkstraLazy(graph, heap.NewDaryHeap(4, dijkstraLess))
+	}},
+	{"IndexedPriorityQueue", dijkstraIndexed},
+	{"FibonacciHeap", dijkstraFibonacci},
+	{"PairingHeap", dijkstraPairing},
+	{"BinomialHeap", func(graph [][]dijkstraEdge) []int {
+		return dijkstraLazy(graph, heap.NewBinomialHeap(dijkstraLess))
+	}},
+	{"LeftistHeap", func(graph [][]dijkstraEdge) []int {
+		return dijkstraLazy(graph, heap.NewLeftistHeap(dijkstraLess))
+	}},
+	{"SkewHeap", func(graph [][]dijkstraEdge) []int {
+		return dijkstraLazy(graph, heap.NewSkewHeap(dijkstraLess))
+	}},
+}
+
+func TestDijkstra(t *testing.T) {
+	graph := randomGraph(rand.New(rand.NewSource(2)), 500, 4)
+	expected := dijkstraFns[0].fn(graph)
+	for _, d := range dijkstraFns[1:] {
+		if got := d.fn(graph); !slices.Equal(expected, got) {
+			t.Errorf("Dijkstra %s: expected distances to match those of Heap", d.name)
+		}
+	}
+}
+
+func BenchmarkDijkstra(b *testing.B) {
+	graph := benchmarkGraph()
+	for _, d := range dijkstraFns {
+		b.Run(d.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				d.fn(graph)
+			}
+		})
+	}
+}
@@ -0,0 +1,374 @@
+package heap
+
+import "iter"
+
+// FibonacciHeap represents a Fibonacci heap ordered by a less function, as described by Fredman and
+// Tarjan. It is a collection of heap-ordered trees whose roots are kept in a circular list, and it
+// defers all the work of combining the trees to Pop.
+//
+// Push, Peek, Merge and decreasing the item of a node using Update take O(1) amortized time, and
+// Pop, Remove and increasing the item of a node take O(log(n)) amortized time. This makes it
+// suitable for graph algorithms with many decrease-key operations, like Dijkstra's and Prim's,
+// although a binary heap is often faster in practice because of its smaller constant factors.
+type FibonacciHeap[T any] struct {
+	// min is the root with the minimum item, and it is also the entry point of the root list.
+	min  *FibonacciNode[T]
+	len  int
+	less func(a, b T) bool
+}
+
+// FibonacciNode is a node of a FibonacciHeap holding an item. It is returned by Insert and stays
+// valid as a handle to the item until the item is removed from the heap.
+type FibonacciNode[T any] struct {
+	value T
+
+	parent, child *FibonacciNode[T]
+
+	// left and right link the node with its siblings in a circular list.
+	left, right *FibonacciNode[T]
+
+	// degree is the number of children of the node.
+	degree int
+
+	// marked is true if the node has lost a child since it became the child of its parent.
+	marked bool
+
+	// linked is true while the node is in a heap.
+	linked bool
+}
+
+// Value returns the item of the node.
+func (n *FibonacciNode[T]) Value() T {
+	return n.value
+}
+
+// NewFibonacciHeap returns a new Fibonacci heap instance ordered by less with the given items
+// inserted into it.
+func NewFibonacciHeap[T any](less func(a, b T) bool, items ...T) *FibonacciHeap[T] {
+	h := &FibonacciHeap[T]{less: less}
+	h.Push(items...)
+	return h
+}
+
+// Len returns the number of items in the heap.
+func (h *FibonacciHeap[T]) Len() int {
+	return h.len
+}
+
+// Empty checks whether the heap is empty.
+func (h *FibonacciHeap[T]) Empty() bool {
+	return h.len == 0
+}
+
+// Clear deletes all the items from the heap. The nodes of the items are no longer valid.
+func (h *FibonacciHeap[T]) Clear() {
+	for n := range h.nodes() {
+		n.linked = false
+	}
+
+	h.min = nil
+	h.len = 0
+}
+
+// Peek returns the item at the top of the heap without removing it. If the heap is empty, the
+// second return value is false.
+func (h *FibonacciHeap[T]) Peek() (T, bool) {
+	if h.min == nil {
+		var zero T
+		return zero, false
+	}
+
+	return h.min.value, true
+}
+
+// Push inserts the given items into the heap.
+func (h *FibonacciHeap[T]) Push(items ...T) {
+	for _, item := range items {
+		h.Insert(item)
+	}
+}
+
+// Insert inserts the item into the heap and returns its node, which can be used to update or
+// remove the item later.
+func (h *FibonacciHeap[T]) Insert(item T) *FibonacciNode[T] {
+	n := &FibonacciNode[T]{}
+	h.insert(n, item)
+	return n
+}
+
+// Pop removes the item at the top of the heap and returns it. If the heap is empty, the second
+// return value is false.
+func (h *FibonacciHeap[T]) Pop() (T, bool) {
+	if h.min == nil {
+		var zero T
+		return zero, false
+	}
+
+	n := h.min
+	h.extract(n)
+	return n.value, true
+}
+
+// Update replaces the item of the node n and moves the node to restore the heap order. It returns
+// false if the item of n has been removed from the heap. The node n must belong to the heap, or to
+// a heap that has been merged into it.
+func (h *FibonacciHeap[T]) Update(n *FibonacciNode[T], item T) bool {
+	if n == nil || !n.linked {
+		return false
+	}
+
+	if h.less(n.value, item) {
+		h.remove(n)
+		h.insert(n, item)
+		return true
+	}
+
+	n.value = item
+	if p := n.parent; p != nil && h.less(n.value, p.value) {
+		h.cut(n)
+		h.cascadingCut(p)
+	}
+	if h.less(n.value, h.min.value) {
+		h.min = n
+	}
+
+	return true
+}
+
+// Remove removes the item of the node n from the heap. It returns false if the item has already
+// been removed. The node n must belong to the heap, or to a heap that has been merged into it.
+func (h *FibonacciHeap[T]) Remove(n *FibonacciNode[T]) bool {
+	if n == nil || !n.linked {
+		return false
+	}
+
+	h.remove(n)
+	return true
+}
+
+// Merge moves all the items of other into the heap in O(1) time, leaving other empty. The nodes of
+// the items of other stay valid and now belong to the heap.
+func (h *FibonacciHeap[T]) Merge(other *FibonacciHeap[T]) {
+	if other == nil || other == h || other.min == nil {
+		return
+	}
+
+	if h.min == nil {
+		h.min = other.min
+	} else {
+		a, b := h.min, other.min
+		aRight, bLeft := a.right, b.left
+		a.right, b.left = b, a
+		bLeft.right, aRight.left = aRight, bLeft
+		if h.less(b.value, a.value) {
+			h.min = b
+		}
+	}
+
+	h.len += other.len
+	other.min = nil
+	other.len = 0
+}
+
+// All returns an iterator over the items of the heap in no particular order, without removing
+// them. The heap should not be modified during iteration.
+func (h *FibonacciHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := range h.nodes() {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that removes the items of the heap in order as it yields them. If the
+// iteration stops early, the remaining items are kept in the heap.
+func (h *FibonacciHeap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+// nodes returns an iterator over all the nodes of the heap.
+func (h *FibonacciHeap[T]) nodes() iter.Seq[*FibonacciNode[T]] {
+	return func(yield func(*FibonacciNode[T]) bool) {
+		if h.min == nil {
+			return
+		}
+
+		stack := []*FibonacciNode[T]{h.min}
+		for len(stack) > 0 {
+			first := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for n := first; ; {
+				if n.child != nil {
+					stack = append(stack, n.child)
+				}
+
+				// Read the next sibling first in case yield modifies n.
+				next := n.right
+				if !yield(n) {
+					return
+				}
+				if n = next; n == first {
+					break
+				}
+			}
+		}
+	}
+}
+
+// insert resets the node n to hold item and adds it to the root list.
+func (h *FibonacciHeap[T]) insert(n *FibonacciNode[T], item T) {
+	*n = FibonacciNode[T]{value: item, linked: true}
+	n.left, n.right = n, n
+	h.addRoot(n)
+	h.len++
+}
+
+// addRoot adds the node n, whose siblings are not set, to the root list.
+func (h *FibonacciHeap[T]) addRoot(n *FibonacciNode[T]) {
+	if h.min == nil {
+		n.left, n.right = n, n
+		h.min = n
+		return
+	}
+
+	n.left, n.right = h.min, h.min.right
+	h.min.right.left = n
+	h.min.right = n
+	if h.less(n.value, h.min.value) {
+		h.min = n
+	}
+}
+
+// remove removes the node n from the heap by making it a root, if it isn't one already, and then
+// extracting it.
+func (h *FibonacciHeap[T]) remove(n *FibonacciNode[T]) {
+	if p := n.parent; p != nil {
+		h.cut(n)
+		h.cascadingCut(p)
+	}
+
+	h.extract(n)
+}
+
+// extract removes the root n from the heap. Its children become roots, and if n was the minimum,
+// the trees are consolidated to find the new minimum.
+func (h *FibonacciHeap[T]) extract(n *FibonacciNode[T]) {
+	for n.child != nil {
+		c := n.child
+		if c.right == c {
+			n.child = nil
+		} else {
+			n.child = c.right
+			unlinkSibling(c)
+		}
+
+		c.parent, c.marked = nil, false
+		h.addRoot(c)
+	}
+
+	next := n.right
+	unlinkSibling(n)
+	n.linked = false
+	h.len--
+
+	switch {
+	case next == n:
+		h.min = nil
+	case h.min == n:
+		h.min = next
+		h.consolidate()
+	}
+}
+
+// consolidate links the roots of equal degree until all the roots have distinct degrees, and
+// finds the new minimum.
+func (h *FibonacciHeap[T]) consolidate() {
+	var roots []*FibonacciNode[T]
+	for n := h.min; ; {
+		roots = append(roots, n)
+		if n = n.right; n == h.min {
+			break
+		}
+	}
+
+	var byDegree []*FibonacciNode[T]
+	for _, x := range roots {
+		for {
+			for len(byDegree) <= x.degree {
+				byDegree = append(byDegree, nil)
+			}
+
+			y := byDegree[x.degree]
+			if y == nil {
+				byDegree[x.degree] = x
+				break
+			}
+
+			byDegree[x.degree] = nil
+			if h.less(y.value, x.value) {
+				x, y = y, x
+			}
+			h.link(y, x)
+		}
+	}
+
+	h.min = nil
+	for _, n := range byDegree {
+		if n != nil && (h.min == nil || h.less(n.value, h.min.value)) {
+			h.min = n
+		}
+	}
+}
+
+// link removes the root y from the root list and makes it a child of the root x.
+func (h *FibonacciHeap[T]) link(y, x *FibonacciNode[T]) {
+	unlinkSibling(y)
+	y.parent, y.marked = x, false
+	if x.child == nil {
+		x.child = y
+	} else {
+		y.left, y.right = x.child, x.child.right
+		x.child.right.left = y
+		x.child.right = y
+	}
+	x.degree++
+}
+
+// cut removes the node n from the children of its parent and adds it to the root list.
+func (h *FibonacciHeap[T]) cut(n *FibonacciNode[T]) {
+	p := n.parent
+	if p.child == n {
+		if n.right == n {
+			p.child = nil
+		} else {
+			p.child = n.right
+		}
+	}
+	unlinkSibling(n)
+	p.degree--
+
+	n.parent, n.marked = nil, false
+	h.addRoot(n)
+}
+
+// cascadingCut marks the node n after it has lost a child. If n was already marked, it is cut from
+// its parent too, and the process repeats with the parent.
+func (h *FibonacciHeap[T]) cascadingCut(n *FibonacciNode[T]) {
+	for p := n.parent; p != nil; n, p = p, p.parent {
+		if !n.marked {
+			n.marked = true
+			return
+		}
+
+		h.cut(n)
+	}
+}
+
+// unlinkSibling removes the node n from its circular list of siblings.
+func unlinkSibling[T any](n *FibonacciNode[T]) {
+	n.left.right = n.right
+	n.right.left = n.left
+	n.left, n.right = n, n
+}
@@ -0,0 +1,27 @@
+package heap_test
+
+import (
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+func newFibonacciHeap(items ...int) *heap.FibonacciHeap[int] {
+	return heap.NewFibonacciHeap(less, items...)
+}
+
+func TestFibonacciHeap(t *testing.T) {
+	testInterfaceHelper(t, func(items ...int) heap.Interface[int] {
+		return newFibonacciHeap(items...)
+	})
+}
+
+func TestFibonacciHeap_Merge(t *testing.T) {
+	testMergeHelper(t, newFibonacciHeap, (*heap.FibonacciHeap[int]).Merge)
+}
+
+func TestFibonacciHeap_Handles(t *testing.T) {
+	testHandlesHelper[*heap.FibonacciNode[int]](t, func() *heap.FibonacciHeap[int] {
+		return newFibonacciHeap()
+	}, (*heap.FibonacciHeap[int]).Merge)
+}
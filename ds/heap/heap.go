@@ -0,0 +1,278 @@
+package heap
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// Heap represents a binary heap ordered by a less function. The item for which less reports true
+// against every other item, the minimum with respect to less, is at the top of the heap. Use a
+// less function like a < b for a min heap and a > b for a max heap.
+//
+// The heap is stored in a slice: the children of the item at index i are at indices 2i+1 and 2i+2.
+type Heap[T any] struct {
+	arr  []T
+	less func(a, b T) bool
+}
+
+// New returns a new heap instance ordered by less with the given items inserted into it. The heap
+// is built in O(n) time.
+func New[T any](less func(a, b T) bool, items ...T) *Heap[T] {
+	return FromSlice(less, append(make([]T, 0, len(items)), items...))
+}
+
+// FromSlice returns a new heap instance ordered by less that uses arr as its storage, rearranging
+// its items into a heap in O(n) time. The caller should not use arr after this call.
+func FromSlice[T any](less func(a, b T) bool, arr []T) *Heap[T] {
+	h := &Heap[T]{arr: arr, less: less}
+	h.heapify()
+	return h
+}
+
+// Len returns the number of items in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.arr)
+}
+
+// Empty checks whether the heap is empty.
+func (h *Heap[T]) Empty() bool {
+	return len(h.arr) == 0
+}
+
+// Clear deletes all the items from the heap.
+func (h *Heap[T]) Clear() {
+	clear(h.arr)
+	h.arr = h.arr[:0]
+}
+
+// Peek returns the item at the top of the heap without removing it. If the heap is empty, the
+// second return value is false.
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.arr) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return h.arr[0], true
+}
+
+// Push inserts the given items into the heap. Each item takes O(log(n)) time.
+func (h *Heap[T]) Push(items ...T) {
+	for _, item := range items {
+		h.arr = append(h.arr, item)
+		h.up(len(h.arr) - 1)
+	}
+}
+
+// Pop removes the item at the top of the heap and returns it. If the heap is empty, the second
+// return value is false.
+func (h *Heap[T]) Pop() (T, bool) {
+	return h.Remove(0)
+}
+
+// PushPop inserts the item into the heap and then removes and returns the item at the top of the
+// heap. It is faster than Push followed by Pop: if the item would be at the top, it is returned
+// immediately without modifying the heap, and otherwise only a single sift down is needed.
+func (h *Heap[T]) PushPop(item T) T {
+	if len(h.arr) == 0 || !h.less(h.arr[0], item) {
+		return item
+	}
+
+	top := h.arr[0]
+	h.arr[0] = item
+	h.down(0)
+	return top
+}
+
+// Replace removes and returns the item at the top of the heap and then inserts the given item,
+// using a single sift down. Unlike PushPop, the returned item is never the given item. If the heap
+// is empty, the item is just inserted and the second return value is false.
+func (h *Heap[T]) Replace(item T) (T, bool) {
+	if len(h.arr) == 0 {
+		h.Push(item)
+		var zero T
+		return zero, false
+	}
+
+	top := h.arr[0]
+	h.arr[0] = item
+	h.down(0)
+	return top, true
+}
+
+// Update replaces the item at index i and moves it up or down to restore the heap order. It
+// returns false if the heap doesn't have enough items.
+func (h *Heap[T]) Update(i int, item T) bool {
+	if i < 0 || i >= len(h.arr) {
+		return false
+	}
+
+	h.arr[i] = item
+	h.fix(i)
+	return true
+}
+
+// Remove removes the item at index i from the heap and returns it. If the heap doesn't have enough
+// items, the second return value is false.
+func (h *Heap[T]) Remove(i int) (T, bool) {
+	if i < 0 || i >= len(h.arr) {
+		var zero T
+		return zero, false
+	}
+
+	item := h.arr[i]
+	last := len(h.arr) - 1
+	h.arr[i] = h.arr[last]
+
+	var zero T
+	h.arr[last] = zero
+	h.arr = h.arr[:last]
+	if i < last {
+		h.fix(i)
+	}
+
+	return item, true
+}
+
+// Merge inserts all the items of other into the heap, leaving other unchanged. The items are
+// pushed one by one when other is small, taking O(m*log(n+m)) time, and otherwise the heap is
+// rebuilt in O(n+m) time, whichever is faster.
+func (h *Heap[T]) Merge(other *Heap[T]) {
+	if other == nil || other == h {
+		return
+	}
+
+	n, m := len(h.arr), len(other.arr)
+	if m*bits.Len(uint(n+m)) < n+m {
+		h.Push(other.arr...)
+		return
+	}
+
+	h.arr = append(h.arr, other.arr...)
+	h.heapify()
+}
+
+// Index returns the index of the first item in the heap, in the order of the slice, for which match
+// returns true. If there is no such item, -1 is returned. It takes O(n) time.
+func (h *Heap[T]) Index(match func(T) bool) int {
+	for i, item := range h.arr {
+		if match(item) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// At returns the item at index i. If the heap doesn't have enough items, the second return value is
+// false.
+func (h *Heap[T]) At(i int) (T, bool) {
+	if i < 0 || i >= len(h.arr) {
+		var zero T
+		return zero, false
+	}
+
+	return h.arr[i], true
+}
+
+// All returns an iterator over the items of the heap in no particular order, without removing
+// them. The heap should not be modified during iteration.
+func (h *Heap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range h.arr {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that removes the items of the heap in order as it yields them. If the
+// iteration stops early, the remaining items are kept in the heap. Use Drain on a Copy to iterate
+// in sorted order without modifying the heap.
+func (h *Heap[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			item, ok := h.Pop()
+			if !ok || !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Copy creates a new copy of the heap.
+func (h *Heap[T]) Copy() *Heap[T] {
+	return &Heap[T]{arr: append(make([]T, 0, len(h.arr)), h.arr...), less: h.less}
+}
+
+// heapify rearranges the items into a heap. All the items with index >= len/2 are leaves, so
+// sifting down the others from the last to the first takes O(n) time in total.
+func (h *Heap[T]) heapify() {
+	for i := len(h.arr)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+}
+
+// fix moves the item at index i up or down to restore the heap order.
+func (h *Heap[T]) fix(i int) {
+	if i > 0 && h.less(h.arr[i], h.arr[(i-1)/2]) {
+		h.up(i)
+	} else {
+		h.down(i)
+	}
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.arr[i], h.arr[parent]) {
+			break
+		}
+
+		h.arr[i], h.arr[parent] = h.arr[parent], h.arr[i]
+		i = parent
+	}
+}
+
+func (h *Heap[T]) down(i int) {
+	for {
+		left := 2*i + 1
+		right := left + 1
+		top := i
+		if left < len(h.arr) && h.less(h.arr[left], h.arr[top]) {
+			top = left
+		}
+		if right < len(h.arr) && h.less(h.arr[right], h.arr[top]) {
+			top = right
+		}
+
+		if top == i {
+			break
+		}
+
+		h.arr[i], h.arr[top] = h.arr[top], h.arr[i]
+		i = top
+	}
+}
+
+// find returns the index of an item equal to value in the subtree rooted at index i, or -1 if
+// there is no such item. Subtrees whose root comes after value in the heap order are skipped, as
+// all their items do too.
+func (h *Heap[T]) find(i int, value T, equal func(a, b T) bool) int {
+	if i >= len(h.arr) {
+		return -1
+	}
+	if equal(h.arr[i], value) {
+		return i
+	}
+	if h.less(value, h.arr[i]) {
+		return -1
+	}
+
+	if idx := h.find(2*i+1, value, equal); idx >= 0 {
+		return idx
+	}
+
+	return h.find(2*i+2, value, equal)
+}
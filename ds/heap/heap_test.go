@@ -0,0 +1,154 @@
+package heap_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+func less(a, b int) bool {
+	return a < b
+}
+
+func TestNew(t *testing.T) {
+	newHeap := heap.New(less, 5, 3, 8, 1, 9, 2)
+	if newHeap.Len() != 6 {
+		t.Errorf("New: expected Len to be 6, got %d", newHeap.Len())
+	}
+	if v, ok := newHeap.Peek(); !ok || v != 1 {
+		t.Errorf("New: expected Peek to return (1, true), got (%d, %t)", v, ok)
+	}
+
+	assertHeap(t, "New", newHeap, []int{1, 2, 3, 5, 8, 9})
+
+	type item struct {
+		name     string
+		priority int
+	}
+	itemHeap := heap.New(func(a, b item) bool { return a.priority > b.priority }, item{"a", 1}, item{"b", 3}, item{"c", 2})
+	if v, ok := itemHeap.Pop(); !ok || v.name != "b" {
+		t.Errorf("New: expected Pop to return item b, got %v", v)
+	}
+}
+
+func TestFromSlice(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	arr := make([]int, 200)
+	for i := range arr {
+		arr[i] = r.Intn(50)
+	}
+
+	expected := slices.Clone(arr)
+	slices.Sort(expected)
+	assertHeap(t, "FromSlice", heap.FromSlice(less, arr), expected)
+}
+
+func TestHeap_PushPop(t *testing.T) {
+	newHeap := heap.New(less, 4, 6, 8)
+	if v := newHeap.PushPop(2); v != 2 {
+		t.Errorf("PushPop 2: expected PushPop to return 2, got %d", v)
+	}
+	if v := newHeap.PushPop(7); v != 4 {
+		t.Errorf("PushPop 7: expected PushPop to return 4, got %d", v)
+	}
+	assertHeap(t, "PushPop", newHeap, []int{6, 7, 8})
+
+	if v := heap.New(less).PushPop(3); v != 3 {
+		t.Errorf("PushPop 3: expected PushPop on an empty heap to return 3, got %d", v)
+	}
+}
+
+func TestHeap_Replace(t *testing.T) {
+	newHeap := heap.New(less, 4, 6, 8)
+	if v, ok := newHeap.Replace(2); !ok || v != 4 {
+		t.Errorf("Replace 2: expected Replace to return (4, true), got (%d, %t)", v, ok)
+	}
+	assertHeap(t, "Replace", newHeap, []int{2, 6, 8})
+
+	newHeap = heap.New(less)
+	if _, ok := newHeap.Replace(3); ok {
+		t.Error("Replace 3: expected Replace on an empty heap to return false, got true")
+	}
+	assertHeap(t, "Replace", newHeap, []int{3})
+}
+
+func TestHeap_UpdateRemove(t *testing.T) {
+	newHeap := heap.New(less, 1, 2, 3, 4, 5, 6, 7)
+	idx := newHeap.Index(func(v int) bool { return v == 6 })
+	if !newHeap.Update(idx, 0) {
+		t.Error("Update: expected Update to return true, got false")
+	}
+	if newHeap.Update(7, 0) {
+		t.Error("Update 7: expected Update to return false, got true")
+	}
+	assertHeap(t, "Update", newHeap, []int{0, 1, 2, 3, 4, 5, 7})
+
+	idx = newHeap.Index(func(v int) bool { return v == 2 })
+	if v, ok := newHeap.Remove(idx); !ok || v != 2 {
+		t.Errorf("Remove: expected Remove to return (2, true), got (%d, %t)", v, ok)
+	}
+	if _, ok := newHeap.Remove(-1); ok {
+		t.Error("Remove -1: expected Remove to return false, got true")
+	}
+	assertHeap(t, "Remove", newHeap, []int{0, 1, 3, 4, 5, 7})
+}
+
+func TestHeap_Merge(t *testing.T) {
+	newHeap := heap.New(less, 5, 1, 9)
+	other := heap.New(less, 4, 2)
+	newHeap.Merge(other)
+	assertHeap(t, "Merge", newHeap, []int{1, 2, 4, 5, 9})
+	assertHeap(t, "Merge", other, []int{2, 4})
+
+	newHeap = heap.New(less, 10)
+	newHeap.Merge(heap.New(less, 8, 6, 4, 2, 0))
+	newHeap.Merge(newHeap)
+	assertHeap(t, "Merge", newHeap, []int{0, 2, 4, 6, 8, 10})
+}
+
+func TestHeap_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	newHeap := heap.New(less)
+	var expected []int
+	for n := 0; n < 2000; n++ {
+		switch op := r.Intn(4); {
+		case op < 2:
+			v := r.Intn(100)
+			newHeap.Push(v)
+			expected = append(expected, v)
+		case op < 3 && len(expected) > 0:
+			slices.Sort(expected)
+			if v, ok := newHeap.Pop(); !ok || v != expected[0] {
+				t.Fatalf("Random: expected Pop to return (%d, true), got (%d, %t)", expected[0], v, ok)
+			}
+			expected = expected[1:]
+		case len(expected) > 0:
+			i := r.Intn(newHeap.Len())
+			old, _ := newHeap.At(i)
+			v := r.Intn(100)
+			newHeap.Update(i, v)
+			expected[slices.Index(expected, old)] = v
+		}
+	}
+
+	slices.Sort(expected)
+	assertHeap(t, "Random", newHeap, expected)
+}
+
+func assertHeap(t *testing.T, name string, h *heap.Heap[int], expected []int) {
+	t.Helper()
+
+	got := slices.Collect(h.Drain())
+	if !slicesEqual(expected, got) {
+		t.Errorf("%s: expected Heap values to be %v, got %v", name, expected, got)
+	}
+	h.Push(got...)
+}
+
+func TestHeap_Interface(t *testing.T) {
+	testInterfaceHelper(t, func(items ...int) heap.Interface[int] {
+		return heap.New(less, items...)
+	})
+}
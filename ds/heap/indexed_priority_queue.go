@@ -0,0 +1,232 @@
+package heap
+
+import (
+	"iter"
+	"maps"
+)
+
+// IndexedPriorityQueue represents a priority queue of unique keys, each with a priority, ordered by
+// a less function on the priorities like Heap. Keys act as stable handles: the priority of a key
+// can be changed or the key removed at any time, which makes it suitable for algorithms like
+// Dijkstra's that need a decrease-key operation.
+//
+// It is a binary heap together with a map from every key to its index in the heap, which is kept
+// up to date as items move. Contains and Priority take O(1) time, and Push, Pop, Update and Remove
+// take O(log(n)) time.
+type IndexedPriorityQueue[K comparable, P any] struct {
+	items []indexedItem[K, P]
+	index map[K]int
+	less  func(a, b P) bool
+}
+
+type indexedItem[K comparable, P any] struct {
+	key      K
+	priority P
+}
+
+// NewIndexedPriorityQueue returns a new empty indexed priority queue instance ordered by less on
+// the priorities. Use a less function like a < b to pop the key with the lowest priority first.
+func NewIndexedPriorityQueue[K comparable, P any](
+	less func(a, b P) bool,
+) *IndexedPriorityQueue[K, P] {
+	return &IndexedPriorityQueue[K, P]{index: make(map[K]int), less: less}
+}
+
+// Len returns the number of keys in the queue.
+func (pq *IndexedPriorityQueue[K, P]) Len() int {
+	return len(pq.items)
+}
+
+// Empty checks whether the queue is empty.
+func (pq *IndexedPriorityQueue[K, P]) Empty() bool {
+	return len(pq.items) == 0
+}
+
+// Clear deletes all the keys from the queue.
+func (pq *IndexedPriorityQueue[K, P]) Clear() {
+	clear(pq.items)
+	pq.items = pq.items[:0]
+	clear(pq.index)
+}
+
+// Contains checks whether the queue contains the given key.
+func (pq *IndexedPriorityQueue[K, P]) Contains(key K) bool {
+	_, ok := pq.index[key]
+	return ok
+}
+
+// Priority returns the priority of the given key. If the key doesn't exist, the second return
+// value is false.
+func (pq *IndexedPriorityQueue[K, P]) Priority(key K) (P, bool) {
+	i, ok := pq.index[key]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+
+	return pq.items[i].priority, true
+}
+
+// Peek returns the key at the top of the queue and its priority without removing it. If the queue
+// is empty, the last return value is false.
+func (pq *IndexedPriorityQueue[K, P]) Peek() (K, P, bool) {
+	if len(pq.items) == 0 {
+		var zeroK K
+		var zeroP P
+		return zeroK, zeroP, false
+	}
+
+	return pq.items[0].key, pq.items[0].priority, true
+}
+
+// Push adds the key with the given priority to the queue and returns true. If the key already
+// exists, its priority is updated instead and false is returned.
+func (pq *IndexedPriorityQueue[K, P]) Push(key K, priority P) bool {
+	if i, ok := pq.index[key]; ok {
+		pq.items[i].priority = priority
+		pq.fix(i)
+		return false
+	}
+
+	pq.items = append(pq.items, indexedItem[K, P]{key: key, priority: priority})
+	pq.index[key] = len(pq.items) - 1
+	pq.up(len(pq.items) - 1)
+	return true
+}
+
+// Pop removes the key at the top of the queue and returns it with its priority. If the queue is
+// empty, the last return value is false.
+func (pq *IndexedPriorityQueue[K, P]) Pop() (K, P, bool) {
+	if len(pq.items) == 0 {
+		var zeroK K
+		var zeroP P
+		return zeroK, zeroP, false
+	}
+
+	item := pq.removeAt(0)
+	return item.key, item.priority, true
+}
+
+// Update changes the priority of the given key. It returns false if the key doesn't exist.
+func (pq *IndexedPriorityQueue[K, P]) Update(key K, priority P) bool {
+	i, ok := pq.index[key]
+	if !ok {
+		return false
+	}
+
+	pq.items[i].priority = priority
+	pq.fix(i)
+	return true
+}
+
+// Remove removes the given key from the queue and returns its priority. If the key doesn't exist,
+// the second return value is false.
+func (pq *IndexedPriorityQueue[K, P]) Remove(key K) (P, bool) {
+	i, ok := pq.index[key]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+
+	return pq.removeAt(i).priority, true
+}
+
+// All returns an iterator over the keys of the queue and their priorities in no particular order,
+// without removing them. The queue should not be modified during iteration.
+func (pq *IndexedPriorityQueue[K, P]) All() iter.Seq2[K, P] {
+	return func(yield func(K, P) bool) {
+		for _, item := range pq.items {
+			if !yield(item.key, item.priority) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that removes the keys of the queue in order of priority as it yields
+// them with their priorities. If the iteration stops early, the remaining keys are kept in the
+// queue.
+func (pq *IndexedPriorityQueue[K, P]) Drain() iter.Seq2[K, P] {
+	return func(yield func(K, P) bool) {
+		for {
+			key, priority, ok := pq.Pop()
+			if !ok || !yield(key, priority) {
+				return
+			}
+		}
+	}
+}
+
+// Copy creates a new copy of the queue.
+func (pq *IndexedPriorityQueue[K, P]) Copy() *IndexedPriorityQueue[K, P] {
+	return &IndexedPriorityQueue[K, P]{
+		items: append(make([]indexedItem[K, P], 0, len(pq.items)), pq.items...),
+		index: maps.Clone(pq.index),
+		less:  pq.less,
+	}
+}
+
+// removeAt removes the item at index i and returns it.
+func (pq *IndexedPriorityQueue[K, P]) removeAt(i int) indexedItem[K, P] {
+	item := pq.items[i]
+	last := len(pq.items) - 1
+	pq.swap(i, last)
+
+	pq.items[last] = indexedItem[K, P]{}
+	pq.items = pq.items[:last]
+	delete(pq.index, item.key)
+	if i < last {
+		pq.fix(i)
+	}
+
+	return item
+}
+
+// fix moves the item at index i up or down to restore the heap order.
+func (pq *IndexedPriorityQueue[K, P]) fix(i int) {
+	if i > 0 && pq.less(pq.items[i].priority, pq.items[(i-1)/2].priority) {
+		pq.up(i)
+	} else {
+		pq.down(i)
+	}
+}
+
+func (pq *IndexedPriorityQueue[K, P]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(pq.items[i].priority, pq.items[parent].priority) {
+			break
+		}
+
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+func (pq *IndexedPriorityQueue[K, P]) down(i int) {
+	for {
+		left := 2*i + 1
+		right := left + 1
+		top := i
+		if left < len(pq.items) && pq.less(pq.items[left].priority, pq.items[top].priority) {
+			top = left
+		}
+		if right < len(pq.items) && pq.less(pq.items[right].priority, pq.items[top].priority) {
+			top = right
+		}
+
+		if top == i {
+			break
+		}
+
+		pq.swap(i, top)
+		i = top
+	}
+}
+
+// swap swaps the items at indices i and j, keeping the index map up to date.
+func (pq *IndexedPriorityQueue[K, P]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.index[pq.items[i].key] = i
+	pq.index[pq.items[j].key] = j
+}
@@ -0,0 +1,120 @@
+package heap_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+func TestIndexedPriorityQueue(t *testing.T) {
+	pq := heap.NewIndexedPriorityQueue[string](less)
+	if !pq.Empty() {
+		t.Error("IndexedPriorityQueue: expected Empty to be true, got false")
+	}
+	if _, _, ok := pq.Pop(); ok {
+		t.Error("IndexedPriorityQueue: expected Pop on an empty queue to return false, got true")
+	}
+
+	for key, priority := range map[string]int{"a": 5, "b": 3, "c": 8, "d": 1} {
+		if !pq.Push(key, priority) {
+			t.Errorf("Push %s: expected Push to return true, got false", key)
+		}
+	}
+	if pq.Push("c", 4) {
+		t.Error("Push c: expected Push of an existing key to return false, got true")
+	}
+	if pq.Len() != 4 {
+		t.Errorf("IndexedPriorityQueue: expected Len to be 4, got %d", pq.Len())
+	}
+	if p, ok := pq.Priority("c"); !ok || p != 4 {
+		t.Errorf("Priority c: expected Priority to return (4, true), got (%d, %t)", p, ok)
+	}
+	if k, p, ok := pq.Peek(); !ok || k != "d" || p != 1 {
+		t.Errorf("Peek: expected Peek to return (d, 1, true), got (%s, %d, %t)", k, p, ok)
+	}
+
+	if !pq.Update("a", 0) {
+		t.Error("Update a: expected Update to return true, got false")
+	}
+	if pq.Update("e", 0) {
+		t.Error("Update e: expected Update to return false, got true")
+	}
+	if p, ok := pq.Remove("b"); !ok || p != 3 {
+		t.Errorf("Remove b: expected Remove to return (3, true), got (%d, %t)", p, ok)
+	}
+	if _, ok := pq.Remove("b"); ok {
+		t.Error("Remove b: expected Remove of a removed key to return false, got true")
+	}
+	if pq.Contains("b") || !pq.Contains("a") {
+		t.Error("Contains: expected the queue to contain a but not b")
+	}
+
+	copyPQ := pq.Copy()
+	var keys []string
+	for k := range pq.Drain() {
+		keys = append(keys, k)
+	}
+	if !slices.Equal(keys, []string{"a", "d", "c"}) {
+		t.Errorf("Drain: expected keys to be [a d c], got %v", keys)
+	}
+	if !pq.Empty() || copyPQ.Len() != 3 {
+		t.Errorf("Copy: expected the copy to keep 3 keys, got %d", copyPQ.Len())
+	}
+
+	copyPQ.Clear()
+	if !copyPQ.Empty() || copyPQ.Contains("a") {
+		t.Error("Clear: expected the queue to be empty")
+	}
+}
+
+func TestIndexedPriorityQueue_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	pq := heap.NewIndexedPriorityQueue[int](less)
+	expected := make(map[int]int)
+	for n := 0; n < 5000; n++ {
+		key := r.Intn(200)
+		switch op := r.Intn(4); op {
+		case 0, 1:
+			priority := r.Intn(1000)
+			if _, ok := expected[key]; pq.Push(key, priority) == ok {
+				t.Fatalf("Random: expected Push %d to return %t", key, !ok)
+			}
+			expected[key] = priority
+		case 2:
+			p, ok := pq.Remove(key)
+			if ep, eok := expected[key]; ok != eok || p != ep {
+				t.Fatalf("Random: expected Remove %d to return (%d, %t), got (%d, %t)", key, ep, eok, p, ok)
+			}
+			delete(expected, key)
+		default:
+			k, p, ok := pq.Pop()
+			if !ok {
+				if len(expected) > 0 {
+					t.Fatal("Random: expected Pop to return true, got false")
+				}
+				continue
+			}
+			for ek, ep := range expected {
+				if ep < p {
+					t.Fatalf("Random: expected Pop to return priority <= %d of key %d, got %d", ep, ek, p)
+				}
+			}
+			if expected[k] != p {
+				t.Fatalf("Random: expected priority of key %d to be %d, got %d", k, expected[k], p)
+			}
+			delete(expected, k)
+		}
+
+		if pq.Len() != len(expected) {
+			t.Fatalf("Random: expected Len to be %d, got %d", len(expected), pq.Len())
+		}
+	}
+
+	for k, p := range pq.All() {
+		if expected[k] != p {
+			t.Errorf("Random: expected priority of key %d to be %d, got %d", k, expected[k], p)
+		}
+	}
+}
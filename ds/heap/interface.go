@@ -0,0 +1,91 @@
+package heap
+
+import "iter"
+
+// Interface is the interface that groups the basic methods of a heap implementation. A heap is
+// ordered by a less function given to its constructor, and the item for which less reports true
+// against every other item, the minimum with respect to less, is at the top of the heap.
+//
+// Heap, the binary heap, DaryHeap and the mergeable heaps FibonacciHeap, PairingHeap, BinomialHeap,
+// LeftistHeap and SkewHeap all implement it. Each of the mergeable heaps also has a Merge method
+// taking a heap of its own type.
+type Interface[T any] interface {
+	// Len returns the number of items in the heap.
+	Len() int
+
+	// Empty checks whether the heap is empty.
+	Empty() bool
+
+	// Clear deletes all the items from the heap.
+	Clear()
+
+	// Peek returns the item at the top of the heap without removing it. If the heap is empty, the
+	// second return value is false.
+	Peek() (T, bool)
+
+	// Push inserts the given items into the heap.
+	Push(items ...T)
+
+	// Pop removes the item at the top of the heap and returns it. If the heap is empty, the second
+	// return value is false.
+	Pop() (T, bool)
+
+	// All returns an iterator over the items of the heap in no particular order, without removing
+	// them. The heap should not be modified during iteration.
+	All() iter.Seq[T]
+
+	// Drain returns an iterator that removes the items of the heap in order as it yields them. If
+	// the iteration stops early, the remaining items are kept in the heap.
+	Drain() iter.Seq[T]
+}
+
+// DoubleEnded is the interface that groups the basic methods of a double-ended heap
+// implementation, which gives access to both the minimum and the maximum item with respect to the
+// less function given to its constructor.
+//
+// MinMaxHeap and IntervalHeap implement it.
+type DoubleEnded[T any] interface {
+	// Len returns the number of items in the heap.
+	Len() int
+
+	// Empty checks whether the heap is empty.
+	Empty() bool
+
+	// Clear deletes all the items from the heap.
+	Clear()
+
+	// PeekMin returns the minimum item of the heap without removing it. If the heap is empty, the
+	// second return value is false.
+	PeekMin() (T, bool)
+
+	// PeekMax returns the maximum item of the heap without removing it. If the heap is empty, the
+	// second return value is false.
+	PeekMax() (T, bool)
+
+	// Push inserts the given items into the heap.
+	Push(items ...T)
+
+	// PopMin removes the minimum item of the heap and returns it. If the heap is empty, the second
+	// return value is false.
+	PopMin() (T, bool)
+
+	// PopMax removes the maximum item of the heap and returns it. If the heap is empty, the second
+	// return value is false.
+	PopMax() (T, bool)
+
+	// All returns an iterator over the items of the heap in no particular order, without removing
+	// them. The heap should not be modified during iteration.
+	All() iter.Seq[T]
+}
+
+// drain returns an iterator that pops the items of h as it yields them.
+func drain[T any](h Interface[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			item, ok := h.Pop()
+			if !ok || !yield(item) {
+				return
+			}
+		}
+	}
+}
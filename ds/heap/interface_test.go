@@ -0,0 +1,399 @@
+package heap_test
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+func testInterfaceHelper(t *testing.T, newFn func(items ...int) heap.Interface[int]) {
+	t.Run("New", func(t *testing.T) {
+		newHeap := newFn(5, 3, 8, 1, 9, 2)
+		if newHeap.Len() != 6 {
+			t.Errorf("New: expected Len to be 6, got %d", newHeap.Len())
+		}
+		if v, ok := newHeap.Peek(); !ok || v != 1 {
+			t.Errorf("New: expected Peek to return (1, true), got (%d, %t)", v, ok)
+		}
+
+		assertInterfaceValues(t, "New", newHeap, []int{1, 2, 3, 5, 8, 9})
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		newHeap := newFn()
+		if !newHeap.Empty() {
+			t.Error("Empty: expected Empty to be true, got false")
+		}
+		if _, ok := newHeap.Peek(); ok {
+			t.Error("Empty: expected Peek to return false, got true")
+		}
+		if _, ok := newHeap.Pop(); ok {
+			t.Error("Empty: expected Pop to return false, got true")
+		}
+
+		newHeap.Push(4)
+		if newHeap.Empty() {
+			t.Error("Empty: expected Empty to be false, got true")
+		}
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		newHeap := newFn(4, 5, 6)
+		newHeap.Clear()
+		if !newHeap.Empty() || newHeap.Len() != 0 {
+			t.Errorf("Clear: expected Len to be 0, got %d", newHeap.Len())
+		}
+
+		newHeap.Push(3, 1, 2)
+		assertInterfaceValues(t, "Clear", newHeap, []int{1, 2, 3})
+	})
+
+	t.Run("All", func(t *testing.T) {
+		newHeap := newFn(4, 2, 6, 2, 9)
+		got := slices.Sorted(newHeap.All())
+		if !slicesEqual(got, []int{2, 2, 4, 6, 9}) {
+			t.Errorf("All: expected All values to be [2 2 4 6 9], got %v", got)
+		}
+		if newHeap.Len() != 5 {
+			t.Errorf("All: expected Len to be 5, got %d", newHeap.Len())
+		}
+	})
+
+	t.Run("Drain", func(t *testing.T) {
+		newHeap := newFn(4, 2, 6, 9)
+		for v := range newHeap.Drain() {
+			if v == 4 {
+				break
+			}
+		}
+
+		assertInterfaceValues(t, "Drain", newHeap, []int{6, 9})
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		newHeap := newFn()
+		var expected []int
+		for n := 0; n < 3000; n++ {
+			if op := r.Intn(3); op < 2 || len(expected) == 0 {
+				v := r.Intn(500)
+				newHeap.Push(v)
+				expected = append(expected, v)
+				continue
+			}
+
+			slices.Sort(expected)
+			if v, ok := newHeap.Pop(); !ok || v != expected[0] {
+				t.Fatalf("Random: expected Pop to return (%d, true), got (%d, %t)", expected[0], v, ok)
+			}
+			expected = expected[1:]
+			if newHeap.Len() != len(expected) {
+				t.Fatalf("Random: expected Len to be %d, got %d", len(expected), newHeap.Len())
+			}
+		}
+
+		slices.Sort(expected)
+		assertInterfaceValues(t, "Random", newHeap, expected)
+	})
+}
+
+// testMergeHelper tests the Merge method of a mergeable heap, which moves all the items of the
+// other heap.
+func testMergeHelper[H heap.Interface[int]](t *testing.T, newFn func(items ...int) H, merge func(h, other H)) {
+	newHeap := newFn(5, 1, 9)
+	other := newFn(4, 2, 7, 3)
+	merge(newHeap, other)
+	if !other.Empty() {
+		t.Errorf("Merge: expected the other heap to be empty, got Len %d", other.Len())
+	}
+	merge(newHeap, newHeap)
+	merge(newHeap, newFn())
+	assertInterfaceValues(t, "Merge", newHeap, []int{1, 2, 3, 4, 5, 7, 9})
+
+	r := rand.New(rand.NewSource(1))
+	newHeap = newFn()
+	var expected []int
+	for i := 0; i < 20; i++ {
+		items := make([]int, r.Intn(50))
+		for j := range items {
+			items[j] = r.Intn(1000)
+		}
+
+		merge(newHeap, newFn(items...))
+		expected = append(expected, items...)
+		if len(expected) > 0 {
+			v, _ := newHeap.Pop()
+			slices.Sort(expected)
+			if v != expected[0] {
+				t.Fatalf("Merge: expected Pop to return %d, got %d", expected[0], v)
+			}
+			expected = expected[1:]
+		}
+	}
+
+	slices.Sort(expected)
+	assertInterfaceValues(t, "Merge", newHeap, expected)
+}
+
+// handleNode is the node of a heap that supports updating and removing items through handles.
+type handleNode interface {
+	comparable
+	Value() int
+}
+
+// handleHeap is a heap that supports updating and removing items through handles.
+type handleHeap[N handleNode] interface {
+	heap.Interface[int]
+	Insert(item int) N
+	Update(n N, item int) bool
+	Remove(n N) bool
+}
+
+// testHandlesHelper tests updating and removing items using the nodes returned by Insert. The items
+// are unique: the last 4 digits of an item hold its id.
+func testHandlesHelper[N handleNode, H handleHeap[N]](t *testing.T, newFn func() H, merge func(h, other H)) {
+	t.Run("Update", func(t *testing.T) {
+		newHeap := newFn()
+		nodes := make(map[int]N)
+		for _, v := range []int{50, 30, 80, 10, 90, 20, 70} {
+			nodes[v] = newHeap.Insert(v)
+		}
+		newHeap.Pop()
+
+		if !newHeap.Update(nodes[80], 5) || nodes[80].Value() != 5 {
+			t.Error("Update 80: expected Update to decrease the item to 5")
+		}
+		if !newHeap.Update(nodes[20], 95) {
+			t.Error("Update 20: expected Update to return true, got false")
+		}
+		if newHeap.Update(nodes[10], 1) {
+			t.Error("Update 10: expected Update of a popped item to return false, got true")
+		}
+		if !newHeap.Remove(nodes[50]) || newHeap.Remove(nodes[50]) {
+			t.Error("Remove 50: expected Remove to return true once")
+		}
+
+		assertInterfaceValues(t, "Update", newHeap, []int{5, 30, 70, 90, 95})
+		if newHeap.Update(nodes[30], 1) {
+			t.Error("Update 30: expected Update of a drained item to return false, got true")
+		}
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		newHeap := newFn()
+		n := newHeap.Insert(4)
+		newHeap.Insert(2)
+		newHeap.Clear()
+		if newHeap.Update(n, 1) || newHeap.Remove(n) {
+			t.Error("Clear: expected the nodes of a cleared heap to be invalid")
+		}
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		newHeap, other := newFn(), newFn()
+		newHeap.Insert(10)
+		n := other.Insert(20)
+		other.Insert(30)
+		merge(newHeap, other)
+		if !newHeap.Update(n, 5) {
+			t.Error("Merge: expected Update of a merged node to return true, got false")
+		}
+
+		assertInterfaceValues(t, "Merge", newHeap, []int{5, 10, 30})
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		const ids = 10000
+
+		r := rand.New(rand.NewSource(1))
+		newHeap := newFn()
+		nodes := make(map[int]N)
+		values := make(map[int]int)
+		for n := 0; n < 10000; n++ {
+			id := r.Intn(300)
+			v := r.Intn(1000)*ids + id
+			node, ok := nodes[id]
+			switch op := r.Intn(5); {
+			case op < 2 && !ok:
+				nodes[id] = newHeap.Insert(v)
+				values[id] = v
+			case op < 3 && ok:
+				if !newHeap.Update(node, v) {
+					t.Fatalf("Random: expected Update of id %d to return true, got false", id)
+				}
+				values[id] = v
+			case op < 4 && ok:
+				if !newHeap.Remove(node) {
+					t.Fatalf("Random: expected Remove of id %d to return true, got false", id)
+				}
+				delete(nodes, id)
+				delete(values, id)
+			case op < 5 && len(values) > 0:
+				expected := slices.Min(slices.Collect(maps.Values(values)))
+				if v, ok := newHeap.Pop(); !ok || v != expected {
+					t.Fatalf("Random: expected Pop to return (%d, true), got (%d, %t)", expected, v, ok)
+				}
+				if newHeap.Update(nodes[expected%ids], 0) {
+					t.Fatalf("Random: expected Update of popped id %d to return false, got true", expected%ids)
+				}
+				delete(nodes, expected%ids)
+				delete(values, expected%ids)
+			}
+
+			if newHeap.Len() != len(values) {
+				t.Fatalf("Random: expected Len to be %d, got %d", len(values), newHeap.Len())
+			}
+		}
+
+		for id, node := range nodes {
+			if node.Value() != values[id] {
+				t.Errorf("Random: expected Value of id %d to be %d, got %d", id, values[id], node.Value())
+			}
+		}
+		expected := slices.Sorted(maps.Values(values))
+		assertInterfaceValues(t, "Random", newHeap, expected)
+	})
+}
+
+func testDoubleEndedHelper(t *testing.T, newFn func(items ...int) heap.DoubleEnded[int]) {
+	t.Run("New", func(t *testing.T) {
+		newHeap := newFn(5, 3, 8, 1, 9, 2)
+		if newHeap.Len() != 6 {
+			t.Errorf("New: expected Len to be 6, got %d", newHeap.Len())
+		}
+		if v, ok := newHeap.PeekMin(); !ok || v != 1 {
+			t.Errorf("New: expected PeekMin to return (1, true), got (%d, %t)", v, ok)
+		}
+		if v, ok := newHeap.PeekMax(); !ok || v != 9 {
+			t.Errorf("New: expected PeekMax to return (9, true), got (%d, %t)", v, ok)
+		}
+
+		assertDoubleEndedValues(t, "New", newHeap, []int{1, 2, 3, 5, 8, 9})
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		newHeap := newFn()
+		if !newHeap.Empty() {
+			t.Error("Empty: expected Empty to be true, got false")
+		}
+		if _, ok := newHeap.PeekMin(); ok {
+			t.Error("Empty: expected PeekMin to return false, got true")
+		}
+		if _, ok := newHeap.PeekMax(); ok {
+			t.Error("Empty: expected PeekMax to return false, got true")
+		}
+		if _, ok := newHeap.PopMin(); ok {
+			t.Error("Empty: expected PopMin to return false, got true")
+		}
+		if _, ok := newHeap.PopMax(); ok {
+			t.Error("Empty: expected PopMax to return false, got true")
+		}
+	})
+
+	t.Run("Single", func(t *testing.T) {
+		newHeap := newFn(7)
+		if v, ok := newHeap.PeekMax(); !ok || v != 7 {
+			t.Errorf("Single: expected PeekMax to return (7, true), got (%d, %t)", v, ok)
+		}
+		if v, ok := newHeap.PopMax(); !ok || v != 7 {
+			t.Errorf("Single: expected PopMax to return (7, true), got (%d, %t)", v, ok)
+		}
+		if !newHeap.Empty() {
+			t.Error("Single: expected Empty to be true, got false")
+		}
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		newHeap := newFn(4, 5, 6)
+		newHeap.Clear()
+		if !newHeap.Empty() || newHeap.Len() != 0 {
+			t.Errorf("Clear: expected Len to be 0, got %d", newHeap.Len())
+		}
+
+		newHeap.Push(3, 1, 2)
+		assertDoubleEndedValues(t, "Clear", newHeap, []int{1, 2, 3})
+	})
+
+	t.Run("All", func(t *testing.T) {
+		newHeap := newFn(4, 2, 6, 2, 9)
+		got := slices.Sorted(newHeap.All())
+		if !slicesEqual(got, []int{2, 2, 4, 6, 9}) {
+			t.Errorf("All: expected All values to be [2 2 4 6 9], got %v", got)
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		var expected []int
+		for i := 0; i < 100; i++ {
+			expected = append(expected, r.Intn(500))
+		}
+		newHeap := newFn(expected...)
+		for n := 0; n < 5000; n++ {
+			slices.Sort(expected)
+			switch op := r.Intn(4); {
+			case op < 2 || len(expected) == 0:
+				v := r.Intn(500)
+				newHeap.Push(v)
+				expected = append(expected, v)
+			case op < 3:
+				if v, ok := newHeap.PopMin(); !ok || v != expected[0] {
+					t.Fatalf("Random: expected PopMin to return (%d, true), got (%d, %t)", expected[0], v, ok)
+				}
+				expected = expected[1:]
+			default:
+				last := expected[len(expected)-1]
+				if v, ok := newHeap.PopMax(); !ok || v != last {
+					t.Fatalf("Random: expected PopMax to return (%d, true), got (%d, %t)", last, v, ok)
+				}
+				expected = expected[:len(expected)-1]
+			}
+
+			if newHeap.Len() != len(expected) {
+				t.Fatalf("Random: expected Len to be %d, got %d", len(expected), newHeap.Len())
+			}
+		}
+
+		slices.Sort(expected)
+		assertDoubleEndedValues(t, "Random", newHeap, expected)
+	})
+}
+
+func assertInterfaceValues(t *testing.T, name string, h heap.Interface[int], expected []int) {
+	t.Helper()
+
+	got := slices.Collect(h.Drain())
+	if !slicesEqual(expected, got) {
+		t.Errorf("%s: expected Heap values to be %v, got %v", name, expected, got)
+	}
+	if !h.Empty() {
+		t.Errorf("%s: expected Empty to be true after Drain, got false", name)
+	}
+	h.Push(got...)
+}
+
+// assertDoubleEndedValues pops the items of h alternately from both ends and checks that they are
+// the expected items, which are sorted.
+func assertDoubleEndedValues(t *testing.T, name string, h heap.DoubleEnded[int], expected []int) {
+	t.Helper()
+
+	got := make([]int, len(expected))
+	lo, hi := 0, len(expected)-1
+	for i := 0; lo <= hi; i++ {
+		if i%2 == 0 {
+			got[lo], _ = h.PopMin()
+			lo++
+		} else {
+			got[hi], _ = h.PopMax()
+			hi--
+		}
+	}
+
+	if !slicesEqual(expected, got) || !h.Empty() {
+		t.Errorf("%s: expected Heap values to be %v, got %v and Len %d", name, expected, got, h.Len())
+	}
+	h.Push(got...)
+}
@@ -0,0 +1,221 @@
+package heap
+
+import "iter"
+
+// IntervalHeap represents an interval heap ordered by a less function. It is a complete binary
+// tree stored in a slice where every node holds two items, lo <= hi, forming an interval that is
+// contained in the interval of its parent, except for the last node, which may hold a single item.
+// The lo items form a min heap and the hi items form a max heap, so the minimum and the maximum are
+// the two items of the root.
+//
+// Node k holds its items at indices 2k and 2k+1, and its children are the nodes 2k+1 and 2k+2.
+// PeekMin and PeekMax take O(1) time, and Push, PopMin and PopMax take O(log(n)) time. Having two
+// items per node makes it shallower than a MinMaxHeap.
+type IntervalHeap[T any] struct {
+	arr  []T
+	less func(a, b T) bool
+}
+
+// NewIntervalHeap returns a new interval heap instance ordered by less with the given items
+// inserted into it.
+func NewIntervalHeap[T any](less func(a, b T) bool, items ...T) *IntervalHeap[T] {
+	h := &IntervalHeap[T]{arr: make([]T, 0, len(items)), less: less}
+	h.Push(items...)
+	return h
+}
+
+// Len returns the number of items in the heap.
+func (h *IntervalHeap[T]) Len() int {
+	return len(h.arr)
+}
+
+// Empty checks whether the heap is empty.
+func (h *IntervalHeap[T]) Empty() bool {
+	return len(h.arr) == 0
+}
+
+// Clear deletes all the items from the heap.
+func (h *IntervalHeap[T]) Clear() {
+	clear(h.arr)
+	h.arr = h.arr[:0]
+}
+
+// PeekMin returns the minimum item of the heap without removing it. If the heap is empty, the
+// second return value is false.
+func (h *IntervalHeap[T]) PeekMin() (T, bool) {
+	if len(h.arr) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return h.arr[0], true
+}
+
+// PeekMax returns the maximum item of the heap without removing it. If the heap is empty, the
+// second return value is false.
+func (h *IntervalHeap[T]) PeekMax() (T, bool) {
+	if len(h.arr) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return h.arr[h.hi(0)], true
+}
+
+// Push inserts the given items into the heap. Each item takes O(log(n)) time.
+func (h *IntervalHeap[T]) Push(items ...T) {
+	for _, item := range items {
+		h.arr = append(h.arr, item)
+		i := len(h.arr) - 1
+		k := i / 2
+		if i%2 == 1 && h.less(h.arr[i], h.arr[i-1]) {
+			h.arr[i], h.arr[i-1] = h.arr[i-1], h.arr[i]
+		}
+		if k == 0 {
+			continue
+		}
+
+		// The new item is outside the interval of the parent on at most one side.
+		parent := (k - 1) / 2
+		if lo := 2 * k; h.less(h.arr[lo], h.arr[2*parent]) {
+			h.upMin(k)
+		} else if hi := h.hi(k); h.less(h.arr[2*parent+1], h.arr[hi]) {
+			h.upMax(k)
+		}
+	}
+}
+
+// PopMin removes the minimum item of the heap and returns it. If the heap is empty, the second
+// return value is false.
+func (h *IntervalHeap[T]) PopMin() (T, bool) {
+	if len(h.arr) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	item := h.arr[0]
+	h.removeLast(0)
+	h.downMin()
+	return item, true
+}
+
+// PopMax removes the maximum item of the heap and returns it. If the heap is empty, the second
+// return value is false.
+func (h *IntervalHeap[T]) PopMax() (T, bool) {
+	if len(h.arr) <= 1 {
+		return h.PopMin()
+	}
+
+	item := h.arr[1]
+	h.removeLast(1)
+	h.downMax()
+	return item, true
+}
+
+// All returns an iterator over the items of the heap in no particular order, without removing
+// them. The heap should not be modified during iteration.
+func (h *IntervalHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range h.arr {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Copy creates a new copy of the heap.
+func (h *IntervalHeap[T]) Copy() *IntervalHeap[T] {
+	return &IntervalHeap[T]{arr: append(make([]T, 0, len(h.arr)), h.arr...), less: h.less}
+}
+
+// hi returns the index of the hi item of node k, which is its only item if it has one item.
+func (h *IntervalHeap[T]) hi(k int) int {
+	return min(2*k+1, len(h.arr)-1)
+}
+
+// removeLast replaces the item at index i with the last item and removes the last item.
+func (h *IntervalHeap[T]) removeLast(i int) {
+	last := len(h.arr) - 1
+	h.arr[i] = h.arr[last]
+
+	var zero T
+	h.arr[last] = zero
+	h.arr = h.arr[:last]
+}
+
+// upMin moves the lo item of node k up through the lo items of its ancestors.
+func (h *IntervalHeap[T]) upMin(k int) {
+	for k > 0 {
+		parent := (k - 1) / 2
+		if !h.less(h.arr[2*k], h.arr[2*parent]) {
+			break
+		}
+
+		h.arr[2*k], h.arr[2*parent] = h.arr[2*parent], h.arr[2*k]
+		k = parent
+	}
+}
+
+// upMax moves the hi item of node k up through the hi items of its ancestors.
+func (h *IntervalHeap[T]) upMax(k int) {
+	for k > 0 {
+		parent := (k - 1) / 2
+		hi := h.hi(k)
+		if !h.less(h.arr[2*parent+1], h.arr[hi]) {
+			break
+		}
+
+		h.arr[hi], h.arr[2*parent+1] = h.arr[2*parent+1], h.arr[hi]
+		k = parent
+	}
+}
+
+// downMin moves the lo item of the root down through the lo items of the nodes with the smallest
+// lo items, swapping it with the hi item of a node whenever it becomes larger.
+func (h *IntervalHeap[T]) downMin() {
+	for k := 0; ; {
+		if hi := 2*k + 1; hi < len(h.arr) && h.less(h.arr[hi], h.arr[2*k]) {
+			h.arr[hi], h.arr[2*k] = h.arr[2*k], h.arr[hi]
+		}
+
+		child := 2*k + 1
+		if 2*child >= len(h.arr) {
+			return
+		}
+		if 2*(child+1) < len(h.arr) && h.less(h.arr[2*(child+1)], h.arr[2*child]) {
+			child++
+		}
+		if !h.less(h.arr[2*child], h.arr[2*k]) {
+			return
+		}
+
+		h.arr[2*child], h.arr[2*k] = h.arr[2*k], h.arr[2*child]
+		k = child
+	}
+}
+
+// downMax moves the hi item of the root down through the hi items of the nodes with the largest hi
+// items, swapping it with the lo item of a node whenever it becomes smaller.
+func (h *IntervalHeap[T]) downMax() {
+	for k := 0; ; {
+		hi := h.hi(k)
+		if h.less(h.arr[hi], h.arr[2*k]) {
+			h.arr[hi], h.arr[2*k] = h.arr[2*k], h.arr[hi]
+		}
+
+		child := 2*k + 1
+		if 2*child >= len(h.arr) {
+			return
+		}
+		if 2*(child+1) < len(h.arr) && h.less(h.arr[h.hi(child)], h.arr[h.hi(child+1)]) {
+			child++
+		}
+		if !h.less(h.arr[hi], h.arr[h.hi(child)]) {
+			return
+		}
+
+		h.arr[hi], h.arr[h.hi(child)] = h.arr[h.hi(child)], h.arr[hi]
+		k = child
+	}
+}
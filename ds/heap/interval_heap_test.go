@@ -0,0 +1,21 @@
+package heap_test
+
+import (
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+func TestIntervalHeap(t *testing.T) {
+	testDoubleEndedHelper(t, func(items ...int) heap.DoubleEnded[int] {
+		return heap.NewIntervalHeap(less, items...)
+	})
+}
+
+func TestIntervalHeap_Copy(t *testing.T) {
+	newHeap := heap.NewIntervalHeap(less, 3, 1, 2)
+	copyHeap := newHeap.Copy()
+	newHeap.PopMin()
+	assertDoubleEndedValues(t, "Copy", copyHeap, []int{1, 2, 3})
+	assertDoubleEndedValues(t, "Copy", newHeap, []int{2, 3})
+}
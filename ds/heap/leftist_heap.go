@@ -0,0 +1,149 @@
+package heap
+
+import "iter"
+
+// LeftistHeap represents a leftist heap ordered by a less function. It is a heap-ordered binary
+// tree where the rank of every node, the length of its rightmost path, is at most the rank of its
+// left child, so the rightmost path of the tree has O(log(n)) nodes. Two heaps are merged along
+// their rightmost paths, and every other operation is a merge.
+//
+// Peek takes O(1) time, and Push, Pop and Merge take O(log(n)) time.
+type LeftistHeap[T any] struct {
+	root *leftistNode[T]
+	len  int
+	less func(a, b T) bool
+}
+
+type leftistNode[T any] struct {
+	value       T
+	left, right *leftistNode[T]
+
+	// rank is the number of nodes on the rightmost path from the node.
+	rank int
+}
+
+// NewLeftistHeap returns a new leftist heap instance ordered by less with the given items inserted
+// into it.
+func NewLeftistHeap[T any](less func(a, b T) bool, items ...T) *LeftistHeap[T] {
+	h := &LeftistHeap[T]{less: less}
+	h.Push(items...)
+	return h
+}
+
+// Len returns the number of items in the heap.
+func (h *LeftistHeap[T]) Len() int {
+	return h.len
+}
+
+// Empty checks whether the heap is empty.
+func (h *LeftistHeap[T]) Empty() bool {
+	return h.len == 0
+}
+
+// Clear deletes all the items from the heap.
+func (h *LeftistHeap[T]) Clear() {
+	h.root = nil
+	h.len = 0
+}
+
+// Peek returns the item at the top of the heap without removing it. If the heap is empty, the
+// second return value is false.
+func (h *LeftistHeap[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+
+	return h.root.value, true
+}
+
+// Push inserts the given items into the heap.
+func (h *LeftistHeap[T]) Push(items ...T) {
+	for _, item := range items {
+		h.root = h.merge(h.root, &leftistNode[T]{value: item, rank: 1})
+		h.len++
+	}
+}
+
+// Pop removes the item at the top of the heap and returns it. If the heap is empty, the second
+// return value is false.
+func (h *LeftistHeap[T]) Pop() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+
+	n := h.root
+	h.root = h.merge(n.left, n.right)
+	h.len--
+	return n.value, true
+}
+
+// Merge moves all the items of other into the heap in O(log(n)) time, leaving other empty.
+func (h *LeftistHeap[T]) Merge(other *LeftistHeap[T]) {
+	if other == nil || other == h {
+		return
+	}
+
+	h.root = h.merge(h.root, other.root)
+	h.len += other.len
+	other.Clear()
+}
+
+// All returns an iterator over the items of the heap in no particular order, without removing
+// them. The heap should not be modified during iteration.
+func (h *LeftistHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if h.root == nil {
+			return
+		}
+
+		stack := []*leftistNode[T]{h.root}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if n.right != nil {
+				stack = append(stack, n.right)
+			}
+			if n.left != nil {
+				stack = append(stack, n.left)
+			}
+
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that removes the items of the heap in order as it yields them. If the
+// iteration stops early, the remaining items are kept in the heap.
+func (h *LeftistHeap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+// merge merges the trees with roots a and b and returns the new root. The recursion follows the
+// rightmost paths, so its depth is O(log(n)).
+func (h *LeftistHeap[T]) merge(a, b *leftistNode[T]) *leftistNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	if h.less(b.value, a.value) {
+		a, b = b, a
+	}
+
+	a.right = h.merge(a.right, b)
+	if a.left == nil || a.left.rank < a.right.rank {
+		a.left, a.right = a.right, a.left
+	}
+
+	a.rank = 1
+	if a.right != nil {
+		a.rank += a.right.rank
+	}
+	return a
+}
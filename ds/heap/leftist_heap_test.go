@@ -0,0 +1,21 @@
+package heap_test
+
+import (
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+func newLeftistHeap(items ...int) *heap.LeftistHeap[int] {
+	return heap.NewLeftistHeap(less, items...)
+}
+
+func TestLeftistHeap(t *testing.T) {
+	testInterfaceHelper(t, func(items ...int) heap.Interface[int] {
+		return newLeftistHeap(items...)
+	})
+}
+
+func TestLeftistHeap_Merge(t *testing.T) {
+	testMergeHelper(t, newLeftistHeap, (*heap.LeftistHeap[int]).Merge)
+}
@@ -1,159 +1,54 @@
 package heap
 
-// MaxHeap represents a max heap.
+// MaxHeap represents a max heap of ints. It is a Heap[int] ordered by >, with methods named
+// after the maximum.
 type MaxHeap struct {
-	arr []int
+	Heap[int]
 }
 
-// NewMaxHeap returns a new max heap instance with the given items inserted into it.
+// NewMaxHeap returns a new max heap instance with the given items inserted into it. The heap is
+// built in O(n) time.
 func NewMaxHeap(values ...int) *MaxHeap {
-	h := &MaxHeap{arr: make([]int, 0, len(values))}
-	h.Insert(values...)
-	return h
+	return &MaxHeap{Heap: *New(maxLess, values...)}
 }
 
-// Len returns the number of items in the heap.
-func (h *MaxHeap) Len() int {
-	return len(h.arr)
-}
-
-// Empty checks whether the heap is empty.
-func (h *MaxHeap) Empty() bool {
-	return len(h.arr) == 0
-}
-
-// Clear deletes all the items from the heap.
-func (h *MaxHeap) Clear() {
-	h.arr = h.arr[:0]
-	return
-}
-
-func (h *MaxHeap) heapifyUp(idx int) {
-	curr := idx
-	for curr != 0 && h.arr[(curr-1)/2] < h.arr[curr] {
-		h.arr[(curr-1)/2], h.arr[curr] = h.arr[curr], h.arr[(curr-1)/2]
-		curr = (curr - 1) / 2
-	}
-}
-
-func (h *MaxHeap) heapifyDown(idx int) {
-	curr := idx
-	for {
-		left := 2*curr + 1
-		right := left + 1
-		largest := curr
-		if left < len(h.arr) && h.arr[left] > h.arr[largest] {
-			largest = left
-		}
-		if right < len(h.arr) && h.arr[right] > h.arr[largest] {
-			largest = right
-		}
-
-		if largest == curr {
-			break
-		}
-
-		h.arr[curr], h.arr[largest] = h.arr[largest], h.arr[curr]
-		curr = largest
-	}
+func maxLess(a, b int) bool {
+	return a > b
 }
 
 // Max returns the maximum value in the heap.
 func (h *MaxHeap) Max() (int, bool) {
-	if len(h.arr) == 0 {
-		return 0, false
-	}
-	return h.arr[0], true
+	return h.Peek()
 }
 
 // Find returns the index of value in the heap. If the value is not found, -1 is returned.
 func (h *MaxHeap) Find(value int) int {
-	if len(h.arr) == 0 {
-		return -1
-	}
-
-	return h.findHelper(0, value)
-}
-
-func (h *MaxHeap) findHelper(idx, value int) int {
-	if idx < 0 || idx >= len(h.arr) {
-		return -1
-	}
-	if h.arr[idx] == value {
-		return idx
-	}
-	if h.arr[idx] < value {
-		return -1
-	}
-
-	l := h.findHelper(idx*2+1, value)
-	if l >= 0 {
-		return l
-	}
-
-	return h.findHelper(idx*2+2, value)
+	return h.find(0, value, func(a, b int) bool { return a == b })
 }
 
 // Insert inserts the given items to the heap.
 func (h *MaxHeap) Insert(values ...int) {
-	for _, value := range values {
-		h.arr = append(h.arr, value)
-		h.heapifyUp(len(h.arr) - 1)
-	}
+	h.Push(values...)
 }
 
 // UpdateAt updates the item at the given index.
 func (h *MaxHeap) UpdateAt(idx, newValue int) {
-	if idx < 0 || idx >= len(h.arr) {
-		return
-	}
-
-	prev := h.arr[idx]
-	h.arr[idx] = newValue
-	if newValue >= prev {
-		h.heapifyUp(idx)
-	} else {
-		h.heapifyDown(idx)
-	}
+	h.Update(idx, newValue)
 }
 
 // ExtractMax removes the maximum value from the heap and returns it. If the heap is empty, the
 // second return value is false.
 func (h *MaxHeap) ExtractMax() (int, bool) {
-	if len(h.arr) == 0 {
-		return 0, false
-	}
-
-	v := h.arr[0]
-	if len(h.arr) == 0 {
-		h.arr = h.arr[:0]
-		return v, true
-	}
-
-	h.arr[0] = h.arr[len(h.arr)-1]
-	h.arr = h.arr[:len(h.arr)-1]
-	h.heapifyDown(0)
-	return v, true
+	return h.Pop()
 }
 
-// ExtractAt removes the value at index from the heap and returns it. If the heap doesn't enough
-// elements, the second return value is false.
+// ExtractAt removes the value at index from the heap and returns it. If the heap doesn't have
+// enough elements, the second return value is false.
 func (h *MaxHeap) ExtractAt(idx int) (int, bool) {
-	if idx < 0 || idx >= len(h.arr) {
-		return 0, false
-	}
-	if idx == 0 {
-		return h.ExtractMax()
-	}
-
-	v := h.arr[idx]
-	m, _ := h.Max()
-	h.UpdateAt(idx, m-1)
-	h.ExtractMax()
-	return v, true
+	return h.Remove(idx)
 }
 
 // Copy creates a new copy of the heap.
 func (h *MaxHeap) Copy() *MaxHeap {
-	return NewMaxHeap(h.arr...)
+	return &MaxHeap{Heap: *h.Heap.Copy()}
 }
@@ -1,6 +1,7 @@
 package heap_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/gpahal/go-algos/ds/heap"
@@ -148,6 +149,32 @@ func TestMaxHeap_Copy(t *testing.T) {
 	assertMaxHeap(t, "Copy", newHeap, []int{6, 5, 4})
 }
 
+func TestMaxHeap_All(t *testing.T) {
+	newHeap := heap.NewMaxHeap(5, 3, 8, 1)
+	got := slices.Collect(newHeap.All())
+	slices.Sort(got)
+	if !slicesEqual(got, []int{1, 3, 5, 8}) {
+		t.Errorf("All: expected sorted values to be [1 3 5 8], got %v", got)
+	}
+
+	assertMaxHeap(t, "All", newHeap, []int{8, 5, 3, 1})
+}
+
+func TestMaxHeap_Drain(t *testing.T) {
+	newHeap := heap.NewMaxHeap(5, 3, 8, 1)
+	got := slices.Collect(newHeap.Copy().Drain())
+	if !slicesEqual(got, []int{8, 5, 3, 1}) {
+		t.Errorf("Drain: expected values to be %v, got %v", []int{8, 5, 3, 1}, got)
+	}
+
+	for v := range newHeap.Drain() {
+		if v == 5 {
+			break
+		}
+	}
+	assertMaxHeap(t, "Drain", newHeap, []int{3, 1})
+}
+
 func assertMaxHeap(t *testing.T, name string, h *heap.MaxHeap, expected []int) {
 	t.Helper()
 
@@ -0,0 +1,97 @@
+package heap
+
+import "iter"
+
+// MergeSlices returns an iterator over the items of all the given slices in sorted order. Every
+// slice should already be sorted in ascending order as determined by less. The merge keeps the
+// first item of every slice that has not been yielded in a heap, so yielding all the n items of k
+// slices takes O(n*log(k)) time. It is stable: equal items are yielded in the order of the slices.
+func MergeSlices[T any](less func(a, b T) bool, slices ...[]T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), len(slices))
+		for i, s := range slices {
+			nexts[i] = func() (T, bool) {
+				if len(s) == 0 {
+					var zero T
+					return zero, false
+				}
+
+				item := s[0]
+				s = s[1:]
+				return item, true
+			}
+		}
+
+		mergeSorted(less, nexts, yield)
+	}
+}
+
+// MergeChannels returns an iterator over the items received from all the given channels in sorted
+// order, until all of them are closed. The items sent on every channel should be sorted in
+// ascending order as determined by less. Like MergeSlices, it is stable and takes O(log(k)) time
+// per item, but it also waits for an item from every open channel before yielding the next one.
+func MergeChannels[T any](less func(a, b T) bool, chans ...<-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), len(chans))
+		for i, ch := range chans {
+			nexts[i] = func() (T, bool) {
+				item, ok := <-ch
+				return item, ok
+			}
+		}
+
+		mergeSorted(less, nexts, yield)
+	}
+}
+
+// MergeSeqs returns an iterator over the items of all the given iterators in sorted order. The
+// items of every iterator should be sorted in ascending order as determined by less. Like
+// MergeSlices, it is stable and takes O(log(k)) time per item.
+func MergeSeqs[T any](less func(a, b T) bool, seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), len(seqs))
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			nexts[i] = next
+		}
+
+		mergeSorted(less, nexts, yield)
+	}
+}
+
+// mergeCursor is the next item of the source with index src in a k-way merge.
+type mergeCursor[T any] struct {
+	item T
+	src  int
+}
+
+// mergeSorted yields the items of all the sources in sorted order. Ties are broken by the index of
+// the source to keep the merge stable.
+func mergeSorted[T any](less func(a, b T) bool, nexts []func() (T, bool), yield func(T) bool) {
+	h := FromSlice(func(a, b mergeCursor[T]) bool {
+		if less(a.item, b.item) {
+			return true
+		}
+		return !less(b.item, a.item) && a.src < b.src
+	}, make([]mergeCursor[T], 0, len(nexts)))
+
+	for i, next := range nexts {
+		if item, ok := next(); ok {
+			h.Push(mergeCursor[T]{item: item, src: i})
+		}
+	}
+
+	for !h.Empty() {
+		c := h.arr[0]
+		if !yield(c.item) {
+			return
+		}
+
+		if item, ok := nexts[c.src](); ok {
+			h.Replace(mergeCursor[T]{item: item, src: c.src})
+		} else {
+			h.Pop()
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package heap_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+func TestMergeSlices(t *testing.T) {
+	got := slices.Collect(heap.MergeSlices(less, []int{1, 4, 7}, nil, []int{2, 5, 8}, []int{0, 3, 9, 10}))
+	if !slicesEqual(got, []int{0, 1, 2, 3, 4, 5, 7, 8, 9, 10}) {
+		t.Errorf("MergeSlices: expected items to be [0 1 2 3 4 5 7 8 9 10], got %v", got)
+	}
+	if got := slices.Collect(heap.MergeSlices[int](less)); len(got) != 0 {
+		t.Errorf("MergeSlices: expected no items, got %v", got)
+	}
+
+	type item struct {
+		key, src int
+	}
+	byKey := func(a, b item) bool { return a.key < b.key }
+	stable := slices.Collect(heap.MergeSlices(byKey, []item{{1, 0}, {2, 0}}, []item{{1, 1}}, []item{{1, 2}, {2, 2}}))
+	if !slices.Equal(stable, []item{{1, 0}, {1, 1}, {1, 2}, {2, 0}, {2, 2}}) {
+		t.Errorf("MergeSlices: expected the merge to be stable, got %v", stable)
+	}
+
+	for v := range heap.MergeSlices(less, []int{1, 2}, []int{3}) {
+		if v == 2 {
+			break
+		}
+	}
+}
+
+func TestMergeSlices_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var all []int
+	lists := make([][]int, 10)
+	for i := range lists {
+		for j := r.Intn(100); j > 0; j-- {
+			lists[i] = append(lists[i], r.Intn(1000))
+		}
+		slices.Sort(lists[i])
+		all = append(all, lists[i]...)
+	}
+
+	slices.Sort(all)
+	if got := slices.Collect(heap.MergeSlices(less, lists...)); !slicesEqual(got, all) {
+		t.Errorf("Random: expected items to be %v, got %v", all, got)
+	}
+}
+
+func TestMergeChannels(t *testing.T) {
+	send := func(items ...int) <-chan int {
+		ch := make(chan int)
+		go func() {
+			defer close(ch)
+			for _, item := range items {
+				ch <- item
+			}
+		}()
+		return ch
+	}
+
+	got := slices.Collect(heap.MergeChannels(less, send(1, 4, 7), send(), send(2, 5, 8), send(0, 3, 9)))
+	if !slicesEqual(got, []int{0, 1, 2, 3, 4, 5, 7, 8, 9}) {
+		t.Errorf("MergeChannels: expected items to be [0 1 2 3 4 5 7 8 9], got %v", got)
+	}
+}
+
+func TestMergeSeqs(t *testing.T) {
+	got := slices.Collect(heap.MergeSeqs(less, slices.Values([]int{1, 4}), slices.Values([]int{2, 3, 5})))
+	if !slicesEqual(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("MergeSeqs: expected items to be [1 2 3 4 5], got %v", got)
+	}
+
+	var first []int
+	for v := range heap.MergeSeqs(less, slices.Values([]int{1, 4}), slices.Values([]int{2, 3, 5})) {
+		if first = append(first, v); v == 3 {
+			break
+		}
+	}
+	if !slicesEqual(first, []int{1, 2, 3}) {
+		t.Errorf("MergeSeqs: expected items before breaking to be [1 2 3], got %v", first)
+	}
+}
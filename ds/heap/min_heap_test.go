@@ -1,6 +1,7 @@
 package heap_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/gpahal/go-algos/ds/heap"
@@ -148,6 +149,32 @@ func TestMinHeap_Copy(t *testing.T) {
 	assertMinHeap(t, "Copy", newHeap, []int{4, 5, 6})
 }
 
+func TestMinHeap_All(t *testing.T) {
+	newHeap := heap.NewMinHeap(5, 3, 8, 1)
+	got := slices.Collect(newHeap.All())
+	slices.Sort(got)
+	if !slicesEqual(got, []int{1, 3, 5, 8}) {
+		t.Errorf("All: expected sorted values to be [1 3 5 8], got %v", got)
+	}
+
+	assertMinHeap(t, "All", newHeap, []int{1, 3, 5, 8})
+}
+
+func TestMinHeap_Drain(t *testing.T) {
+	newHeap := heap.NewMinHeap(5, 3, 8, 1)
+	got := slices.Collect(newHeap.Copy().Drain())
+	if !slicesEqual(got, []int{1, 3, 5, 8}) {
+		t.Errorf("Drain: expected values to be %v, got %v", []int{1, 3, 5, 8}, got)
+	}
+
+	for v := range newHeap.Drain() {
+		if v == 3 {
+			break
+		}
+	}
+	assertMinHeap(t, "Drain", newHeap, []int{5, 8})
+}
+
 func assertMinHeap(t *testing.T, name string, h *heap.MinHeap, expected []int) {
 	t.Helper()
 
@@ -0,0 +1,224 @@
+package heap
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// MinMaxHeap represents a min-max heap ordered by a less function, as described by Atkinson, Sack,
+// Santoro and Strothotte. It is a binary tree stored in a slice like Heap, where the levels
+// alternate between min levels and max levels: an item on a min level is the minimum of its
+// subtree, and an item on a max level is the maximum of its subtree. The root is on a min level, so
+// the minimum is at the top and the maximum is one of its children.
+//
+// PeekMin and PeekMax take O(1) time, and Push, PopMin and PopMax take O(log(n)) time.
+type MinMaxHeap[T any] struct {
+	arr  []T
+	less func(a, b T) bool
+}
+
+// NewMinMaxHeap returns a new min-max heap instance ordered by less with the given items inserted
+// into it. The heap is built in O(n) time.
+func NewMinMaxHeap[T any](less func(a, b T) bool, items ...T) *MinMaxHeap[T] {
+	h := &MinMaxHeap[T]{arr: append(make([]T, 0, len(items)), items...), less: less}
+	for i := len(h.arr)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// Len returns the number of items in the heap.
+func (h *MinMaxHeap[T]) Len() int {
+	return len(h.arr)
+}
+
+// Empty checks whether the heap is empty.
+func (h *MinMaxHeap[T]) Empty() bool {
+	return len(h.arr) == 0
+}
+
+// Clear deletes all the items from the heap.
+func (h *MinMaxHeap[T]) Clear() {
+	clear(h.arr)
+	h.arr = h.arr[:0]
+}
+
+// PeekMin returns the minimum item of the heap without removing it. If the heap is empty, the
+// second return value is false.
+func (h *MinMaxHeap[T]) PeekMin() (T, bool) {
+	if len(h.arr) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return h.arr[0], true
+}
+
+// PeekMax returns the maximum item of the heap without removing it. If the heap is empty, the
+// second return value is false.
+func (h *MinMaxHeap[T]) PeekMax() (T, bool) {
+	if len(h.arr) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return h.arr[h.maxIndex()], true
+}
+
+// Push inserts the given items into the heap. Each item takes O(log(n)) time.
+func (h *MinMaxHeap[T]) Push(items ...T) {
+	for _, item := range items {
+		h.arr = append(h.arr, item)
+		h.up(len(h.arr) - 1)
+	}
+}
+
+// PopMin removes the minimum item of the heap and returns it. If the heap is empty, the second
+// return value is false.
+func (h *MinMaxHeap[T]) PopMin() (T, bool) {
+	if len(h.arr) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return h.remove(0), true
+}
+
+// PopMax removes the maximum item of the heap and returns it. If the heap is empty, the second
+// return value is false.
+func (h *MinMaxHeap[T]) PopMax() (T, bool) {
+	if len(h.arr) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return h.remove(h.maxIndex()), true
+}
+
+// All returns an iterator over the items of the heap in no particular order, without removing
+// them. The heap should not be modified during iteration.
+func (h *MinMaxHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range h.arr {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Copy creates a new copy of the heap.
+func (h *MinMaxHeap[T]) Copy() *MinMaxHeap[T] {
+	return &MinMaxHeap[T]{arr: append(make([]T, 0, len(h.arr)), h.arr...), less: h.less}
+}
+
+// maxIndex returns the index of the maximum item of the non-empty heap, which is the root or one
+// of its children.
+func (h *MinMaxHeap[T]) maxIndex() int {
+	switch len(h.arr) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	default:
+		if h.less(h.arr[1], h.arr[2]) {
+			return 2
+		}
+		return 1
+	}
+}
+
+// remove removes the item at index i, replacing it with the last item, and returns it.
+func (h *MinMaxHeap[T]) remove(i int) T {
+	item := h.arr[i]
+	last := len(h.arr) - 1
+	h.arr[i] = h.arr[last]
+
+	var zero T
+	h.arr[last] = zero
+	h.arr = h.arr[:last]
+	if i < last {
+		h.down(i)
+	}
+
+	return item
+}
+
+// order returns the function that reports whether an item comes before another on a min level, or
+// on a max level if minLevel is false.
+func (h *MinMaxHeap[T]) order(minLevel bool) func(a, b T) bool {
+	if minLevel {
+		return h.less
+	}
+
+	return func(a, b T) bool { return h.less(b, a) }
+}
+
+// up moves the item at index i up to restore the heap order. The item first moves to the parent if
+// it belongs to the levels of the other kind, and then up through its grandparents.
+func (h *MinMaxHeap[T]) up(i int) {
+	if i == 0 {
+		return
+	}
+
+	parent := (i - 1) / 2
+	minLevel := isMinLevel(i)
+	before := h.order(minLevel)
+	if before(h.arr[parent], h.arr[i]) {
+		h.arr[i], h.arr[parent] = h.arr[parent], h.arr[i]
+		i = parent
+		before = h.order(!minLevel)
+	}
+
+	for i > 2 {
+		grandparent := ((i-1)/2 - 1) / 2
+		if !before(h.arr[i], h.arr[grandparent]) {
+			break
+		}
+
+		h.arr[i], h.arr[grandparent] = h.arr[grandparent], h.arr[i]
+		i = grandparent
+	}
+}
+
+// down moves the item at index i down to restore the heap order. On a min level, it swaps the item
+// with the minimum of its children and grandchildren while that is smaller, and on a max level, it
+// does the same with the maximum.
+func (h *MinMaxHeap[T]) down(i int) {
+	before := h.order(isMinLevel(i))
+	for {
+		first := 2*i + 1
+		if first >= len(h.arr) {
+			return
+		}
+
+		// m is the first of the children and grandchildren of i in the order of its level.
+		m := first
+		for _, j := range [...]int{first + 1, 2*first + 1, 2*first + 2, 2*first + 3, 2*first + 4} {
+			if j < len(h.arr) && before(h.arr[j], h.arr[m]) {
+				m = j
+			}
+		}
+
+		if !before(h.arr[m], h.arr[i]) {
+			return
+		}
+
+		h.arr[i], h.arr[m] = h.arr[m], h.arr[i]
+		if m <= first+1 {
+			return
+		}
+
+		// The item moved to the grandchild m might have to be swapped with its parent, which is on
+		// a level of the other kind.
+		if parent := (m - 1) / 2; before(h.arr[parent], h.arr[m]) {
+			h.arr[m], h.arr[parent] = h.arr[parent], h.arr[m]
+		}
+		i = m
+	}
+}
+
+// isMinLevel checks whether the index i is on a min level, which are the levels at even depths.
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
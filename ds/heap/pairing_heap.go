@@ -0,0 +1,281 @@
+package heap
+
+import "iter"
+
+// PairingHeap represents a pairing heap ordered by a less function, as described by Fredman,
+// Sedgewick, Sleator and Tarjan. It is a single heap-ordered tree with any number of children per
+// node, and Pop combines the children of the root in two passes: first in pairs from left to right,
+// and then from right to left into a single tree.
+//
+// Push, Peek and Merge take O(1) time, and Pop, Remove and Update take O(log(n)) amortized time.
+// It is simpler than a Fibonacci heap and usually faster in practice, also with decrease-key
+// operations.
+type PairingHeap[T any] struct {
+	root *PairingNode[T]
+	len  int
+	less func(a, b T) bool
+}
+
+// PairingNode is a node of a PairingHeap holding an item. It is returned by Insert and stays valid
+// as a handle to the item until the item is removed from the heap.
+type PairingNode[T any] struct {
+	value T
+
+	// child is the first child of the node, and next is its next sibling.
+	child, next *PairingNode[T]
+
+	// prev is the previous sibling of the node, or its parent if it is the first child.
+	prev *PairingNode[T]
+
+	// linked is true while the node is in a heap.
+	linked bool
+}
+
+// Value returns the item of the node.
+func (n *PairingNode[T]) Value() T {
+	return n.value
+}
+
+// NewPairingHeap returns a new pairing heap instance ordered by less with the given items inserted
+// into it.
+func NewPairingHeap[T any](less func(a, b T) bool, items ...T) *PairingHeap[T] {
+	h := &PairingHeap[T]{less: less}
+	h.Push(items...)
+	return h
+}
+
+// Len returns the number of items in the heap.
+func (h *PairingHeap[T]) Len() int {
+	return h.len
+}
+
+// Empty checks whether the heap is empty.
+func (h *PairingHeap[T]) Empty() bool {
+	return h.len == 0
+}
+
+// Clear deletes all the items from the heap. The nodes of the items are no longer valid.
+func (h *PairingHeap[T]) Clear() {
+	for n := range h.nodes() {
+		n.linked = false
+	}
+
+	h.root = nil
+	h.len = 0
+}
+
+// Peek returns the item at the top of the heap without removing it. If the heap is empty, the
+// second return value is false.
+func (h *PairingHeap[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+
+	return h.root.value, true
+}
+
+// Push inserts the given items into the heap.
+func (h *PairingHeap[T]) Push(items ...T) {
+	for _, item := range items {
+		h.Insert(item)
+	}
+}
+
+// Insert inserts the item into the heap and returns its node, which can be used to update or
+// remove the item later.
+func (h *PairingHeap[T]) Insert(item T) *PairingNode[T] {
+	n := &PairingNode[T]{value: item, linked: true}
+	h.root = h.meld(h.root, n)
+	h.len++
+	return n
+}
+
+// Pop removes the item at the top of the heap and returns it. If the heap is empty, the second
+// return value is false.
+func (h *PairingHeap[T]) Pop() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+
+	n := h.root
+	h.remove(n)
+	return n.value, true
+}
+
+// Update replaces the item of the node n and moves the node to restore the heap order. It returns
+// false if the item of n has been removed from the heap. The node n must belong to the heap, or to
+// a heap that has been merged into it.
+func (h *PairingHeap[T]) Update(n *PairingNode[T], item T) bool {
+	if n == nil || !n.linked {
+		return false
+	}
+
+	if h.less(n.value, item) {
+		h.remove(n)
+		*n = PairingNode[T]{value: item, linked: true}
+		h.root = h.meld(h.root, n)
+		h.len++
+		return true
+	}
+
+	n.value = item
+	if n != h.root {
+		h.cut(n)
+		h.root = h.meld(h.root, n)
+	}
+
+	return true
+}
+
+// Remove removes the item of the node n from the heap. It returns false if the item has already
+// been removed. The node n must belong to the heap, or to a heap that has been merged into it.
+func (h *PairingHeap[T]) Remove(n *PairingNode[T]) bool {
+	if n == nil || !n.linked {
+		return false
+	}
+
+	h.remove(n)
+	return true
+}
+
+// Merge moves all the items of other into the heap in O(1) time, leaving other empty. The nodes of
+// the items of other stay valid and now belong to the heap.
+func (h *PairingHeap[T]) Merge(other *PairingHeap[T]) {
+	if other == nil || other == h {
+		return
+	}
+
+	h.root = h.meld(h.root, other.root)
+	h.len += other.len
+	other.root = nil
+	other.len = 0
+}
+
+// All returns an iterator over the items of the heap in no particular order, without removing
+// them. The heap should not be modified during iteration.
+func (h *PairingHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := range h.nodes() {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that removes the items of the heap in order as it yields them. If the
+// iteration stops early, the remaining items are kept in the heap.
+func (h *PairingHeap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+// nodes returns an iterator over all the nodes of the heap.
+func (h *PairingHeap[T]) nodes() iter.Seq[*PairingNode[T]] {
+	return func(yield func(*PairingNode[T]) bool) {
+		if h.root == nil {
+			return
+		}
+
+		stack := []*PairingNode[T]{h.root}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if n.next != nil {
+				stack = append(stack, n.next)
+			}
+			if n.child != nil {
+				stack = append(stack, n.child)
+			}
+
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// remove removes the node n from the heap and replaces it with the combination of its children.
+func (h *PairingHeap[T]) remove(n *PairingNode[T]) {
+	if n == h.root {
+		h.root = h.combine(n.child)
+	} else {
+		h.cut(n)
+		h.root = h.meld(h.root, h.combine(n.child))
+	}
+
+	n.child = nil
+	n.linked = false
+	h.len--
+}
+
+// meld combines the trees with roots a and b, which have no siblings, and returns the new root.
+func (h *PairingHeap[T]) meld(a, b *PairingNode[T]) *PairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	if h.less(b.value, a.value) {
+		a, b = b, a
+	}
+
+	b.prev, b.next = a, a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// combine combines the list of siblings starting with first into a single tree using the two
+// passes of the pairing heap, and returns its root.
+func (h *PairingHeap[T]) combine(first *PairingNode[T]) *PairingNode[T] {
+	// The first pass melds the siblings in pairs from left to right, and links the resulting trees
+	// through next in reverse order.
+	var pairs *PairingNode[T]
+	for n := first; n != nil; {
+		a, b := n, n.next
+		n = nil
+		if b != nil {
+			n = b.next
+			b.prev, b.next = nil, nil
+		}
+
+		a.prev, a.next = nil, nil
+		a = h.meld(a, b)
+		a.next = pairs
+		pairs = a
+	}
+
+	// The second pass melds the trees from right to left.
+	var root *PairingNode[T]
+	for pairs != nil {
+		next := pairs.next
+		pairs.next = nil
+		root = h.meld(root, pairs)
+		pairs = next
+	}
+
+	if root != nil {
+		root.prev = nil
+	}
+	return root
+}
+
+// cut detaches the subtree rooted at the node n, which is not the root, from the tree.
+func (h *PairingHeap[T]) cut(n *PairingNode[T]) {
+	if n.prev.child == n {
+		n.prev.child = n.next
+	} else {
+		n.prev.next = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	}
+
+	n.prev, n.next = nil, nil
+}
@@ -0,0 +1,27 @@
+package heap_test
+
+import (
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+func newPairingHeap(items ...int) *heap.PairingHeap[int] {
+	return heap.NewPairingHeap(less, items...)
+}
+
+func TestPairingHeap(t *testing.T) {
+	testInterfaceHelper(t, func(items ...int) heap.Interface[int] {
+		return newPairingHeap(items...)
+	})
+}
+
+func TestPairingHeap_Merge(t *testing.T) {
+	testMergeHelper(t, newPairingHeap, (*heap.PairingHeap[int]).Merge)
+}
+
+func TestPairingHeap_Handles(t *testing.T) {
+	testHandlesHelper[*heap.PairingNode[int]](t, func() *heap.PairingHeap[int] {
+		return newPairingHeap()
+	}, (*heap.PairingHeap[int]).Merge)
+}
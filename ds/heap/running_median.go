@@ -0,0 +1,74 @@
+package heap
+
+// RunningMedian tracks the median of a stream of ints. It keeps the smaller half of the values in a
+// MaxHeap and the larger half in a MinHeap, with the lower half holding at most one more value, so
+// the median is at the top of one or both heaps.
+//
+// Push takes O(log(n)) time and Median takes O(1) time.
+type RunningMedian struct {
+	lower *MaxHeap
+	upper *MinHeap
+}
+
+// NewRunningMedian returns a new running median instance with the given values pushed into it.
+func NewRunningMedian(values ...int) *RunningMedian {
+	m := &RunningMedian{lower: NewMaxHeap(), upper: NewMinHeap()}
+	m.Push(values...)
+	return m
+}
+
+// Len returns the number of values pushed.
+func (m *RunningMedian) Len() int {
+	return m.lower.Len() + m.upper.Len()
+}
+
+// Empty checks whether no values have been pushed.
+func (m *RunningMedian) Empty() bool {
+	return m.lower.Empty()
+}
+
+// Clear deletes all the values.
+func (m *RunningMedian) Clear() {
+	m.lower.Clear()
+	m.upper.Clear()
+}
+
+// Push adds the given values to the stream.
+func (m *RunningMedian) Push(values ...int) {
+	for _, value := range values {
+		if top, ok := m.lower.Max(); !ok || value <= top {
+			m.lower.Insert(value)
+		} else {
+			m.upper.Insert(value)
+		}
+
+		if m.lower.Len() > m.upper.Len()+1 {
+			v, _ := m.lower.ExtractMax()
+			m.upper.Insert(v)
+		} else if m.upper.Len() > m.lower.Len() {
+			v, _ := m.upper.ExtractMin()
+			m.lower.Insert(v)
+		}
+	}
+}
+
+// Median returns the median of the values, which is the mean of the two middle values if their
+// number is even. If no values have been pushed, the second return value is false.
+func (m *RunningMedian) Median() (float64, bool) {
+	low, ok := m.lower.Max()
+	if !ok {
+		return 0, false
+	}
+	if m.lower.Len() > m.upper.Len() {
+		return float64(low), true
+	}
+
+	high, _ := m.upper.Min()
+	return (float64(low) + float64(high)) / 2, true
+}
+
+// LowMedian returns the lower of the two middle values, or the middle value if their number is
+// odd. If no values have been pushed, the second return value is false.
+func (m *RunningMedian) LowMedian() (int, bool) {
+	return m.lower.Max()
+}
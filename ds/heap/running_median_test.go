@@ -0,0 +1,56 @@
+package heap_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+func TestRunningMedian(t *testing.T) {
+	m := heap.NewRunningMedian()
+	if _, ok := m.Median(); ok || !m.Empty() {
+		t.Error("RunningMedian: expected Median of an empty stream to return false, got true")
+	}
+
+	tests := []struct {
+		value  int
+		median float64
+	}{{5, 5}, {15, 10}, {1, 5}, {3, 4}, {8, 5}, {7, 6}}
+	for _, test := range tests {
+		m.Push(test.value)
+		if v, ok := m.Median(); !ok || v != test.median {
+			t.Errorf("Push %d: expected Median to return (%v, true), got (%v, %t)", test.value, test.median, v, ok)
+		}
+	}
+	if v, ok := m.LowMedian(); !ok || v != 5 {
+		t.Errorf("RunningMedian: expected LowMedian to return (5, true), got (%d, %t)", v, ok)
+	}
+
+	m.Clear()
+	if m.Len() != 0 {
+		t.Errorf("Clear: expected Len to be 0, got %d", m.Len())
+	}
+}
+
+func TestRunningMedian_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := heap.NewRunningMedian()
+	var values []int
+	for i := 0; i < 500; i++ {
+		v := r.Intn(100)
+		m.Push(v)
+		values = append(values, v)
+
+		sorted := slices.Sorted(slices.Values(values))
+		n := len(sorted)
+		expected := float64(sorted[n/2])
+		if n%2 == 0 {
+			expected = (float64(sorted[n/2-1]) + float64(sorted[n/2])) / 2
+		}
+		if v, _ := m.Median(); v != expected {
+			t.Fatalf("Random: expected Median to be %v, got %v", expected, v)
+		}
+	}
+}
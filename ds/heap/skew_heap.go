@@ -0,0 +1,155 @@
+package heap
+
+import "iter"
+
+// SkewHeap represents a skew heap ordered by a less function, the self-adjusting version of a
+// leftist heap described by Sleator and Tarjan. It merges two heaps along their rightmost paths
+// like a leftist heap, but instead of keeping ranks, it swaps the children of every node on the
+// merged path.
+//
+// Peek takes O(1) time, and Push, Pop and Merge take O(log(n)) amortized time. A single operation
+// can take O(n) time, so merging is done iteratively.
+type SkewHeap[T any] struct {
+	root *skewNode[T]
+	len  int
+	less func(a, b T) bool
+}
+
+type skewNode[T any] struct {
+	value       T
+	left, right *skewNode[T]
+}
+
+// NewSkewHeap returns a new skew heap instance ordered by less with the given items inserted into
+// it.
+func NewSkewHeap[T any](less func(a, b T) bool, items ...T) *SkewHeap[T] {
+	h := &SkewHeap[T]{less: less}
+	h.Push(items...)
+	return h
+}
+
+// Len returns the number of items in the heap.
+func (h *SkewHeap[T]) Len() int {
+	return h.len
+}
+
+// Empty checks whether the heap is empty.
+func (h *SkewHeap[T]) Empty() bool {
+	return h.len == 0
+}
+
+// Clear deletes all the items from the heap.
+func (h *SkewHeap[T]) Clear() {
+	h.root = nil
+	h.len = 0
+}
+
+// Peek returns the item at the top of the heap without removing it. If the heap is empty, the
+// second return value is false.
+func (h *SkewHeap[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+
+	return h.root.value, true
+}
+
+// Push inserts the given items into the heap.
+func (h *SkewHeap[T]) Push(items ...T) {
+	for _, item := range items {
+		h.root = h.merge(h.root, &skewNode[T]{value: item})
+		h.len++
+	}
+}
+
+// Pop removes the item at the top of the heap and returns it. If the heap is empty, the second
+// return value is false.
+func (h *SkewHeap[T]) Pop() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+
+	n := h.root
+	h.root = h.merge(n.left, n.right)
+	h.len--
+	return n.value, true
+}
+
+// Merge moves all the items of other into the heap in O(log(n)) amortized time, leaving other
+// empty.
+func (h *SkewHeap[T]) Merge(other *SkewHeap[T]) {
+	if other == nil || other == h {
+		return
+	}
+
+	h.root = h.merge(h.root, other.root)
+	h.len += other.len
+	other.Clear()
+}
+
+// All returns an iterator over the items of the heap in no particular order, without removing
+// them. The heap should not be modified during iteration.
+func (h *SkewHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if h.root == nil {
+			return
+		}
+
+		stack := []*skewNode[T]{h.root}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if n.right != nil {
+				stack = append(stack, n.right)
+			}
+			if n.left != nil {
+				stack = append(stack, n.left)
+			}
+
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that removes the items of the heap in order as it yields them. If the
+// iteration stops early, the remaining items are kept in the heap.
+func (h *SkewHeap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+// merge merges the trees with roots a and b and returns the new root. It walks down the rightmost
+// paths taking the smaller root each time, and every node taken gets its old left child as its
+// right child and the rest of the merged path as its left child.
+func (h *SkewHeap[T]) merge(a, b *skewNode[T]) *skewNode[T] {
+	var root, parent *skewNode[T]
+	for a != nil && b != nil {
+		if h.less(b.value, a.value) {
+			a, b = b, a
+		}
+
+		next := a.right
+		a.right = a.left
+		if parent == nil {
+			root = a
+		} else {
+			parent.left = a
+		}
+
+		parent, a = a, next
+	}
+
+	rest := a
+	if rest == nil {
+		rest = b
+	}
+	if parent == nil {
+		return rest
+	}
+
+	parent.left = rest
+	return root
+}
@@ -0,0 +1,21 @@
+package heap_test
+
+import (
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+func newSkewHeap(items ...int) *heap.SkewHeap[int] {
+	return heap.NewSkewHeap(less, items...)
+}
+
+func TestSkewHeap(t *testing.T) {
+	testInterfaceHelper(t, func(items ...int) heap.Interface[int] {
+		return newSkewHeap(items...)
+	})
+}
+
+func TestSkewHeap_Merge(t *testing.T) {
+	testMergeHelper(t, newSkewHeap, (*heap.SkewHeap[int]).Merge)
+}
@@ -0,0 +1,110 @@
+package heap
+
+import "math"
+
+// SlidingQuantile tracks a quantile of the last window items of a stream with respect to a less
+// function. It uses the nearest-rank method: the q-quantile of n items is the ceil(q*n)-th smallest
+// item, or the smallest item if q*n < 1, so it is always one of the items.
+//
+// The items of the window are split between two IndexedPriorityQueue instances keyed by the
+// position of the item in the stream: the lower one holds the ceil(q*n) smallest items with the
+// largest at the top, and the upper one holds the others with the smallest at the top. When an
+// item leaves the window, it is removed from its queue by key and the queues are rebalanced.
+//
+// Push takes O(log(w)) time and Quantile takes O(1) time. Use one instance per quantile to track
+// several quantiles of the same stream.
+type SlidingQuantile[T any] struct {
+	window int
+	q      float64
+	less   func(a, b T) bool
+
+	// next is the position of the next item in the stream.
+	next  int
+	lower *IndexedPriorityQueue[int, T]
+	upper *IndexedPriorityQueue[int, T]
+}
+
+// NewSlidingQuantile returns a new sliding quantile instance tracking the q-quantile of the last
+// window items with respect to less. If window is less than 1, 1 is used, and q is clamped to the
+// range [0, 1].
+func NewSlidingQuantile[T any](window int, q float64, less func(a, b T) bool) *SlidingQuantile[T] {
+	return &SlidingQuantile[T]{
+		window: max(window, 1),
+		q:      min(max(q, 0), 1),
+		less:   less,
+		lower:  NewIndexedPriorityQueue[int](func(a, b T) bool { return less(b, a) }),
+		upper:  NewIndexedPriorityQueue[int](less),
+	}
+}
+
+// Window returns the maximum number of items in the window.
+func (s *SlidingQuantile[T]) Window() int {
+	return s.window
+}
+
+// Len returns the number of items in the window.
+func (s *SlidingQuantile[T]) Len() int {
+	return s.lower.Len() + s.upper.Len()
+}
+
+// Clear deletes all the items from the window.
+func (s *SlidingQuantile[T]) Clear() {
+	s.lower.Clear()
+	s.upper.Clear()
+}
+
+// Push adds the given items to the window. Once the window is full, every item pushes out the
+// oldest item in the window.
+func (s *SlidingQuantile[T]) Push(items ...T) {
+	for _, item := range items {
+		if old := s.next - s.window; old >= 0 {
+			if _, ok := s.lower.Remove(old); !ok {
+				s.upper.Remove(old)
+			}
+		}
+
+		if s.belowUpper(item) {
+			s.lower.Push(s.next, item)
+		} else {
+			s.upper.Push(s.next, item)
+		}
+		s.next++
+
+		s.rebalance()
+	}
+}
+
+// Quantile returns the quantile of the items in the window. If the window is empty, the second
+// return value is false.
+func (s *SlidingQuantile[T]) Quantile() (T, bool) {
+	_, item, ok := s.lower.Peek()
+	return item, ok
+}
+
+// belowUpper checks whether the item belongs to the lower queue, which is the case if it isn't
+// larger than the top of the lower queue, or than the top of the upper queue if the lower queue is
+// empty.
+func (s *SlidingQuantile[T]) belowUpper(item T) bool {
+	if _, top, ok := s.lower.Peek(); ok {
+		return !s.less(top, item)
+	}
+
+	_, top, ok := s.upper.Peek()
+	return !ok || !s.less(top, item)
+}
+
+// rebalance moves items between the top of the queues until the lower queue holds the number of
+// items up to the rank of the quantile.
+func (s *SlidingQuantile[T]) rebalance() {
+	// The small tolerance keeps products like 0.7*10 = 7.000000000000001 from rounding up.
+	n := s.Len()
+	rank := min(max(int(math.Ceil(s.q*float64(n)-1e-9)), 1), n)
+	for s.lower.Len() > rank {
+		key, item, _ := s.lower.Pop()
+		s.upper.Push(key, item)
+	}
+	for s.lower.Len() < rank {
+		key, item, _ := s.upper.Pop()
+		s.lower.Push(key, item)
+	}
+}
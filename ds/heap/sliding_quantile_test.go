@@ -0,0 +1,54 @@
+package heap_test
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+func TestSlidingQuantile(t *testing.T) {
+	s := heap.NewSlidingQuantile(4, 0.5, less)
+	if _, ok := s.Quantile(); ok {
+		t.Error("SlidingQuantile: expected Quantile of an empty window to return false, got true")
+	}
+
+	tests := []struct {
+		value, quantile int
+	}{{5, 5}, {1, 1}, {9, 5}, {3, 3}, {7, 3}, {2, 3}, {8, 3}}
+	for _, test := range tests {
+		s.Push(test.value)
+		if v, ok := s.Quantile(); !ok || v != test.quantile {
+			t.Errorf("Push %d: expected Quantile to return (%d, true), got (%d, %t)", test.value, test.quantile, v, ok)
+		}
+	}
+	if s.Len() != 4 || s.Window() != 4 {
+		t.Errorf("SlidingQuantile: expected Len and Window to be 4, got %d and %d", s.Len(), s.Window())
+	}
+
+	s.Clear()
+	if s.Len() != 0 {
+		t.Errorf("Clear: expected Len to be 0, got %d", s.Len())
+	}
+}
+
+func TestSlidingQuantile_Random(t *testing.T) {
+	for _, q := range []float64{0, 0.1, 0.5, 0.7, 0.9, 0.99, 1} {
+		r := rand.New(rand.NewSource(1))
+		s := heap.NewSlidingQuantile(20, q, less)
+		var values []int
+		for i := 0; i < 300; i++ {
+			v := r.Intn(50)
+			s.Push(v)
+			values = append(values, v)
+
+			window := slices.Sorted(slices.Values(values[max(len(values)-20, 0):]))
+			rank := max(int(math.Ceil(q*float64(len(window))-1e-9)), 1)
+			if v, _ := s.Quantile(); v != window[rank-1] {
+				t.Fatalf("Random %v: expected Quantile to be %d, got %d", q, window[rank-1], v)
+			}
+		}
+	}
+}
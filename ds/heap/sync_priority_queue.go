@@ -0,0 +1,178 @@
+package heap
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned when pushing into a closed queue, or when popping from a closed queue that
+// has no items left.
+var ErrClosed = errors.New("heap: queue is closed")
+
+// SyncPriorityQueue represents a priority queue that is safe for concurrent use by multiple
+// goroutines, ordered by a less function like Heap. Pop blocks until an item is available, and if
+// the queue has a capacity, Push blocks while it is full, so it can be used to feed work items to
+// a pool of goroutines in order of priority.
+//
+// Close makes the queue reject new items, while the items already in it can still be popped.
+type SyncPriorityQueue[T any] struct {
+	mu       sync.Mutex
+	h        *Heap[T]
+	capacity int
+	closed   bool
+	changed  broadcast
+}
+
+// NewSyncPriorityQueue returns a new concurrency-safe priority queue instance ordered by less that
+// holds up to capacity items. If capacity is not positive, the queue is unbounded and Push never
+// blocks.
+func NewSyncPriorityQueue[T any](capacity int, less func(a, b T) bool) *SyncPriorityQueue[T] {
+	return &SyncPriorityQueue[T]{h: New(less), capacity: max(capacity, 0)}
+}
+
+// Len returns the number of items in the queue.
+func (q *SyncPriorityQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.h.Len()
+}
+
+// Cap returns the maximum number of items in the queue, or 0 if the queue is unbounded.
+func (q *SyncPriorityQueue[T]) Cap() int {
+	return q.capacity
+}
+
+// Empty checks whether the queue is empty.
+func (q *SyncPriorityQueue[T]) Empty() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.h.Empty()
+}
+
+// Peek returns the item at the top of the queue without removing it. If the queue is empty, the
+// second return value is false.
+func (q *SyncPriorityQueue[T]) Peek() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.h.Peek()
+}
+
+// Push inserts the item into the queue, waiting while the queue is full. If the queue is closed,
+// ErrClosed is returned. If ctx is done before there is room for the item, ctx.Err() is returned.
+func (q *SyncPriorityQueue[T]) Push(ctx context.Context, item T) error {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ErrClosed
+		}
+		if !q.full() {
+			q.h.Push(item)
+			q.changed.notify()
+			q.mu.Unlock()
+			return nil
+		}
+
+		changed := q.changed.wait()
+		q.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// TryPush inserts the item into the queue without waiting. It returns false if the queue is full
+// or closed.
+func (q *SyncPriorityQueue[T]) TryPush(item T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed || q.full() {
+		return false
+	}
+
+	q.h.Push(item)
+	q.changed.notify()
+	return true
+}
+
+// Pop removes the item at the top of the queue and returns it, waiting until an item is available.
+// If the queue is closed and empty, ErrClosed is returned. If ctx is done before an item is
+// available, ctx.Err() is returned.
+func (q *SyncPriorityQueue[T]) Pop(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		if item, ok := q.h.Pop(); ok {
+			q.changed.notify()
+			q.mu.Unlock()
+			return item, nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			var zero T
+			return zero, ErrClosed
+		}
+
+		changed := q.changed.wait()
+		q.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// TryPop removes the item at the top of the queue and returns it without waiting. If the queue is
+// empty, the second return value is false.
+func (q *SyncPriorityQueue[T]) TryPop() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	item, ok := q.h.Pop()
+	if ok {
+		q.changed.notify()
+	}
+	return item, ok
+}
+
+// Close closes the queue. Later calls to Push fail with ErrClosed, and the goroutines waiting in
+// Push or in Pop on an empty queue are woken up and return ErrClosed. Closing a closed queue has no
+// effect.
+func (q *SyncPriorityQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.changed.notify()
+}
+
+func (q *SyncPriorityQueue[T]) full() bool {
+	return q.capacity > 0 && q.h.Len() >= q.capacity
+}
+
+// broadcast wakes up the goroutines waiting for a change of a queue. The channel is created by the
+// first waiter and closed by the next change, so changes without waiters don't allocate. Its
+// methods must be called with the lock of the queue held.
+type broadcast struct {
+	ch chan struct{}
+}
+
+// wait returns a channel that is closed on the next change.
+func (b *broadcast) wait() <-chan struct{} {
+	if b.ch == nil {
+		b.ch = make(chan struct{})
+	}
+	return b.ch
+}
+
+// notify wakes up all the waiters.
+func (b *broadcast) notify() {
+	if b.ch != nil {
+		close(b.ch)
+		b.ch = nil
+	}
+}
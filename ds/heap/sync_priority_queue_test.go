@@ -0,0 +1,130 @@
+package heap_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+func TestSyncPriorityQueue(t *testing.T) {
+	ctx := context.Background()
+	q := heap.NewSyncPriorityQueue(0, less)
+	for _, v := range []int{5, 1, 3} {
+		if err := q.Push(ctx, v); err != nil {
+			t.Fatalf("Push %d: expected no error, got %v", v, err)
+		}
+	}
+	if q.Len() != 3 || q.Cap() != 0 || q.Empty() {
+		t.Errorf("SyncPriorityQueue: expected Len 3 and Cap 0, got %d and %d", q.Len(), q.Cap())
+	}
+	if v, ok := q.Peek(); !ok || v != 1 {
+		t.Errorf("Peek: expected Peek to return (1, true), got (%d, %t)", v, ok)
+	}
+	if v, err := q.Pop(ctx); err != nil || v != 1 {
+		t.Errorf("Pop: expected Pop to return (1, nil), got (%d, %v)", v, err)
+	}
+	if v, ok := q.TryPop(); !ok || v != 3 {
+		t.Errorf("TryPop: expected TryPop to return (3, true), got (%d, %t)", v, ok)
+	}
+
+	q.Close()
+	if err := q.Push(ctx, 2); !errors.Is(err, heap.ErrClosed) {
+		t.Errorf("Close: expected Push to return ErrClosed, got %v", err)
+	}
+	if q.TryPush(2) {
+		t.Error("Close: expected TryPush to return false, got true")
+	}
+	if v, err := q.Pop(ctx); err != nil || v != 5 {
+		t.Errorf("Close: expected Pop to return the remaining item (5, nil), got (%d, %v)", v, err)
+	}
+	if _, err := q.Pop(ctx); !errors.Is(err, heap.ErrClosed) {
+		t.Errorf("Close: expected Pop to return ErrClosed, got %v", err)
+	}
+}
+
+func TestSyncPriorityQueue_Blocking(t *testing.T) {
+	q := heap.NewSyncPriorityQueue(2, less)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Pop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Pop: expected Pop on an empty queue to time out, got %v", err)
+	}
+
+	if !q.TryPush(4) || !q.TryPush(2) || q.TryPush(3) {
+		t.Error("TryPush: expected TryPush to fail only when the queue is full")
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := q.Push(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Push: expected Push on a full queue to time out, got %v", err)
+	}
+
+	pushed := make(chan error)
+	go func() {
+		pushed <- q.Push(context.Background(), 1)
+	}()
+	if v, err := q.Pop(context.Background()); err != nil || v != 2 {
+		t.Errorf("Pop: expected Pop to return (2, nil), got (%d, %v)", v, err)
+	}
+	if err := <-pushed; err != nil {
+		t.Errorf("Push: expected the blocked Push to succeed, got %v", err)
+	}
+	if v, _ := q.Pop(context.Background()); v != 1 {
+		t.Errorf("Pop: expected Pop to return the pushed item 1, got %d", v)
+	}
+
+	popped := make(chan error)
+	go func() {
+		q.Pop(context.Background())
+		_, err := q.Pop(context.Background())
+		popped <- err
+	}()
+	time.Sleep(5 * time.Millisecond)
+	q.Close()
+	if err := <-popped; !errors.Is(err, heap.ErrClosed) {
+		t.Errorf("Close: expected the blocked Pop to return ErrClosed, got %v", err)
+	}
+}
+
+func TestSyncPriorityQueue_Concurrent(t *testing.T) {
+	q := heap.NewSyncPriorityQueue(8, less)
+	var wg sync.WaitGroup
+	for p := 0; p < 4; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 250; i++ {
+				q.Push(context.Background(), i)
+			}
+		}()
+	}
+
+	results := make(chan int)
+	for c := 0; c < 4; c++ {
+		go func() {
+			sum := 0
+			for {
+				v, err := q.Pop(context.Background())
+				if err != nil {
+					results <- sum
+					return
+				}
+				sum += v
+			}
+		}()
+	}
+
+	wg.Wait()
+	q.Close()
+	total := 0
+	for c := 0; c < 4; c++ {
+		total += <-results
+	}
+	if expected := 4 * 249 * 250 / 2; total != expected {
+		t.Errorf("Concurrent: expected the sum of the popped items to be %d, got %d", expected, total)
+	}
+}
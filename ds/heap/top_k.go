@@ -0,0 +1,70 @@
+package heap
+
+import "slices"
+
+// TopK collects the k largest items of a stream with respect to a less function, using O(k) memory.
+// It keeps the items collected so far in a heap ordered by less, so the smallest of them is at the
+// top and is replaced whenever a larger item arrives. Every item takes O(log(k)) time.
+//
+// Use a less function like a > b to collect the k smallest items instead.
+type TopK[T any] struct {
+	h *Heap[T]
+	k int
+}
+
+// NewTopK returns a new top-k collector instance keeping the k largest items with respect to less.
+// If k is less than 1, 1 is used.
+func NewTopK[T any](k int, less func(a, b T) bool) *TopK[T] {
+	k = max(k, 1)
+	return &TopK[T]{h: FromSlice(less, make([]T, 0, k)), k: k}
+}
+
+// K returns the maximum number of items kept by the collector.
+func (t *TopK[T]) K() int {
+	return t.k
+}
+
+// Len returns the number of items kept by the collector, which is at most K.
+func (t *TopK[T]) Len() int {
+	return t.h.Len()
+}
+
+// Clear deletes all the items from the collector.
+func (t *TopK[T]) Clear() {
+	t.h.Clear()
+}
+
+// Push offers the given items to the collector. An item is kept if the collector has less than K
+// items or if it is larger than the smallest item kept, which is then dropped.
+func (t *TopK[T]) Push(items ...T) {
+	for _, item := range items {
+		if t.h.Len() < t.k {
+			t.h.Push(item)
+		} else if t.h.less(t.h.arr[0], item) {
+			t.h.Replace(item)
+		}
+	}
+}
+
+// Min returns the smallest item kept by the collector, which an item must exceed to be kept once
+// the collector is full. If the collector is empty, the second return value is false.
+func (t *TopK[T]) Min() (T, bool) {
+	return t.h.Peek()
+}
+
+// Items returns the items kept by the collector from the largest to the smallest, without removing
+// them. It takes O(k*log(k)) time.
+func (t *TopK[T]) Items() []T {
+	items := slices.Clone(t.h.arr)
+	slices.SortStableFunc(items, func(a, b T) int {
+		switch {
+		case t.h.less(b, a):
+			return -1
+		case t.h.less(a, b):
+			return 1
+		default:
+			return 0
+		}
+	})
+	return items
+}
@@ -0,0 +1,55 @@
+package heap_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+func TestTopK(t *testing.T) {
+	topK := heap.NewTopK(3, less)
+	if _, ok := topK.Min(); ok {
+		t.Error("TopK: expected Min of an empty collector to return false, got true")
+	}
+
+	topK.Push(5, 1, 9, 3, 7, 9, 2)
+	if topK.Len() != 3 || topK.K() != 3 {
+		t.Errorf("TopK: expected Len and K to be 3, got %d and %d", topK.Len(), topK.K())
+	}
+	if v, ok := topK.Min(); !ok || v != 7 {
+		t.Errorf("TopK: expected Min to return (7, true), got (%d, %t)", v, ok)
+	}
+	if items := topK.Items(); !slicesEqual(items, []int{9, 9, 7}) {
+		t.Errorf("TopK: expected Items to be [9 9 7], got %v", items)
+	}
+
+	topK.Clear()
+	if topK.Len() != 0 {
+		t.Errorf("Clear: expected Len to be 0, got %d", topK.Len())
+	}
+
+	smallest := heap.NewTopK(2, func(a, b int) bool { return a > b })
+	smallest.Push(5, 1, 9, 3)
+	if items := smallest.Items(); !slicesEqual(items, []int{1, 3}) {
+		t.Errorf("TopK: expected Items of the smallest to be [1 3], got %v", items)
+	}
+}
+
+func TestTopK_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	topK := heap.NewTopK(10, less)
+	var all []int
+	for i := 0; i < 1000; i++ {
+		v := r.Intn(10000)
+		topK.Push(v)
+		all = append(all, v)
+	}
+
+	slices.Sort(all)
+	slices.Reverse(all)
+	if items := topK.Items(); !slicesEqual(items, all[:10]) {
+		t.Errorf("Random: expected Items to be %v, got %v", all[:10], items)
+	}
+}
@@ -0,0 +1,716 @@
+package list
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// CircularDoublyLinkedList represents a list instance implemented as a circular doubly linked list.
+// The Next link of the last element points to the first element and the Prev link of the first
+// element points to the last element, so the elements form a ring.
+//
+// Because the ring has no ends, the start of the list is only a pointer into it. Rotate moves that
+// pointer without relinking any element, and MoveToBack of the first element and MoveToFront of the
+// last element take O(1) time. This suits round-robin scheduling: serve First and then call
+// Rotate(-1) to move on to the next element.
+//
+// Code traversing the list using the links of its elements must stop after Len elements or when it
+// reaches First again, as no link is ever nil. Iterators, Each and the helpers of this package, like
+// Cursor, MergeSorted and Dedup, stop at the end of the list.
+type CircularDoublyLinkedList[T comparable] struct {
+	head *Element[T]
+	len  int
+}
+
+// NewCircularDoublyLinkedList returns a new circular doubly linked list instance of ints with the
+// given items inserted in order.
+func NewCircularDoublyLinkedList(items ...int) Interface[int] {
+	return NewCircularDoublyLinkedListOf(items...)
+}
+
+// NewCircularDoublyLinkedListOf returns a new circular doubly linked list instance with the given
+// items inserted in order.
+func NewCircularDoublyLinkedListOf[T comparable](items ...T) Interface[T] {
+	newList := &CircularDoublyLinkedList[T]{}
+	newList.PushBack(items...)
+	return newList
+}
+
+// Len returns the number of items in the list.
+func (cdll *CircularDoublyLinkedList[T]) Len() int {
+	return cdll.len
+}
+
+// Empty checks whether the list is empty.
+func (cdll *CircularDoublyLinkedList[T]) Empty() bool {
+	return cdll.head == nil
+}
+
+// Clear deletes all the items from the list.
+func (cdll *CircularDoublyLinkedList[T]) Clear() {
+	curr := cdll.head
+	for i := 0; i < cdll.len; i++ {
+		next := curr.Next
+		curr.Next, curr.Prev, curr.list = nil, nil, nil
+		curr = next
+	}
+
+	cdll.head = nil
+	cdll.len = 0
+}
+
+// First returns the first element of the list.
+func (cdll *CircularDoublyLinkedList[T]) First() *Element[T] {
+	return cdll.head
+}
+
+// Last returns the last element of the list.
+func (cdll *CircularDoublyLinkedList[T]) Last() *Element[T] {
+	if cdll.head == nil {
+		return nil
+	}
+
+	return cdll.head.Prev
+}
+
+// At returns the (i+1)th element of the list. Negative indices can also be used to find the (-i)th
+// last element. The ring is walked in whichever direction reaches the element sooner.
+func (cdll *CircularDoublyLinkedList[T]) At(i int) *Element[T] {
+	if i < 0 {
+		i += cdll.len
+	}
+	if i < 0 || i >= cdll.len {
+		return nil
+	}
+
+	curr := cdll.head
+	if i <= cdll.len/2 {
+		for ; i > 0; i-- {
+			curr = curr.Next
+		}
+	} else {
+		for i = cdll.len - i; i > 0; i-- {
+			curr = curr.Prev
+		}
+	}
+
+	return curr
+}
+
+// Contains checks whether the list contains all the given items.
+func (cdll *CircularDoublyLinkedList[T]) Contains(items ...T) bool {
+	if len(items) == 0 {
+		return true
+	}
+	if cdll.head == nil {
+		return false
+	}
+
+	itemsMap := make(map[T]struct{}, len(items))
+	for _, item := range items {
+		itemsMap[item] = struct{}{}
+	}
+
+	curr := cdll.head
+	for i := 0; i < cdll.len; i++ {
+		delete(itemsMap, curr.Value)
+		curr = curr.Next
+	}
+
+	return len(itemsMap) == 0
+}
+
+// Each iterates over the items of the list.
+func (cdll *CircularDoublyLinkedList[T]) Each(fn func(T) bool) {
+	curr := cdll.head
+	for i := 0; i < cdll.len; i++ {
+		if fn(curr.Value) {
+			break
+		}
+
+		curr = curr.Next
+	}
+}
+
+// Iterator returns a list.Iterable that can be used to iterate over the list.
+func (cdll *CircularDoublyLinkedList[T]) Iterator() Iterable[T] {
+	return &circularDoublyLinkedListIterable[T]{
+		list: cdll,
+		curr: cdll.head,
+	}
+}
+
+// All returns an iterator over the items of the list from the first to the last. The current
+// element can be removed during iteration.
+func (cdll *CircularDoublyLinkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var next *Element[T]
+		for curr := cdll.head; curr != nil; curr = next {
+			next = cdll.after(curr)
+			if !yield(curr.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the list from the last to the first. The current
+// element can be removed during iteration.
+func (cdll *CircularDoublyLinkedList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var prev *Element[T]
+		for curr := cdll.Last(); curr != nil; curr = prev {
+			prev = cdll.before(curr)
+			if !yield(curr.Value) {
+				return
+			}
+		}
+	}
+}
+
+// PushFront adds the given items at the start of the list.
+func (cdll *CircularDoublyLinkedList[T]) PushFront(items ...T) {
+	for _, item := range items {
+		newEl := &Element[T]{Value: item, list: cdll}
+		cdll.link(newEl, cdll.Last())
+		cdll.head = newEl
+		cdll.len++
+	}
+}
+
+// PopFront removes and returns the first element from the list. If the list is empty, it returns
+// nil.
+func (cdll *CircularDoublyLinkedList[T]) PopFront() *Element[T] {
+	return cdll.Remove(cdll.head)
+}
+
+// PushBack adds the given items at the end of the list.
+func (cdll *CircularDoublyLinkedList[T]) PushBack(items ...T) {
+	for _, item := range items {
+		cdll.link(&Element[T]{Value: item, list: cdll}, cdll.Last())
+		cdll.len++
+	}
+}
+
+// PopBack removes and returns the last element from the list. If the list is empty, it returns
+// nil.
+func (cdll *CircularDoublyLinkedList[T]) PopBack() *Element[T] {
+	return cdll.Remove(cdll.Last())
+}
+
+// InsertAt adds the item as the (i+1)th element and returns the element. Negative indices can also
+// be used to insert after the (-i)th last element. If the list doesn't have enough elements, it
+// returns nil.
+func (cdll *CircularDoublyLinkedList[T]) InsertAt(i int, item T) *Element[T] {
+	if cdll.head == nil {
+		if i == 0 || i == -1 {
+			cdll.PushFront(item)
+			return cdll.First()
+		}
+
+		return nil
+	}
+	if i == 0 {
+		return cdll.InsertBefore(cdll.head, item)
+	}
+	if i > 0 {
+		i--
+	}
+
+	return cdll.InsertAfter(cdll.At(i), item)
+}
+
+// InsertAfter adds the item after the given element and returns the inserted element. If inserting
+// after e is not possible, including when e doesn't belong to the list, it returns nil.
+func (cdll *CircularDoublyLinkedList[T]) InsertAfter(e *Element[T], item T) *Element[T] {
+	if !cdll.owns(e) {
+		return nil
+	}
+
+	newEl := &Element[T]{Value: item, list: cdll}
+	cdll.link(newEl, e)
+	cdll.len++
+	return newEl
+}
+
+// InsertBefore adds the item before the given element and returns the inserted element. If
+// inserting before e is not possible, including when e doesn't belong to the list, it returns nil.
+func (cdll *CircularDoublyLinkedList[T]) InsertBefore(e *Element[T], item T) *Element[T] {
+	if !cdll.owns(e) {
+		return nil
+	}
+
+	newEl := &Element[T]{Value: item, list: cdll}
+	cdll.link(newEl, e.Prev)
+	if e == cdll.head {
+		cdll.head = newEl
+	}
+	cdll.len++
+	return newEl
+}
+
+// RemoveAt removes the (i+1)th element. Negative indices can also be used to remove the (-i)th
+// last element. If the list doesn't have enough elements, it returns nil.
+func (cdll *CircularDoublyLinkedList[T]) RemoveAt(i int) *Element[T] {
+	return cdll.Remove(cdll.At(i))
+}
+
+// Remove removes and returns the given element. If removing e is not possible, including when e
+// doesn't belong to the list, it returns nil.
+func (cdll *CircularDoublyLinkedList[T]) Remove(e *Element[T]) *Element[T] {
+	if !cdll.owns(e) {
+		return nil
+	}
+
+	cdll.unlink(e)
+	e.list = nil
+	cdll.len--
+	return e
+}
+
+// RemoveAfter removes and returns the element after the given element. If removing after e is not
+// possible, including when e doesn't belong to the list or is the last element, it returns nil.
+func (cdll *CircularDoublyLinkedList[T]) RemoveAfter(e *Element[T]) *Element[T] {
+	if !cdll.owns(e) {
+		return nil
+	}
+
+	return cdll.Remove(cdll.after(e))
+}
+
+// RemoveBefore removes and returns the element before the given element. If removing before e is
+// not possible, including when e doesn't belong to the list or is the first element, it returns
+// nil.
+func (cdll *CircularDoublyLinkedList[T]) RemoveBefore(e *Element[T]) *Element[T] {
+	if !cdll.owns(e) {
+		return nil
+	}
+
+	return cdll.Remove(cdll.before(e))
+}
+
+// DeleteFirst deletes the first occurrence of the given items from the list. If the same item is
+// passed twice as an argument, only one occurrence is deleted in total.
+func (cdll *CircularDoublyLinkedList[T]) DeleteFirst(items ...T) {
+	if len(items) == 0 || cdll.head == nil {
+		return
+	}
+
+	itemsMap := make(map[T]struct{}, len(items))
+	for _, item := range items {
+		itemsMap[item] = struct{}{}
+	}
+
+	var next *Element[T]
+	for curr := cdll.head; curr != nil; curr = next {
+		next = cdll.after(curr)
+		if _, ok := itemsMap[curr.Value]; ok {
+			delete(itemsMap, curr.Value)
+			cdll.Remove(curr)
+
+			if len(itemsMap) == 0 {
+				break
+			}
+		}
+	}
+}
+
+// Delete deletes the all occurrences of the given items from the list.
+func (cdll *CircularDoublyLinkedList[T]) Delete(items ...T) {
+	if len(items) == 0 || cdll.head == nil {
+		return
+	}
+
+	itemsMap := make(map[T]struct{}, len(items))
+	for _, item := range items {
+		itemsMap[item] = struct{}{}
+	}
+
+	var next *Element[T]
+	for curr := cdll.head; curr != nil; curr = next {
+		next = cdll.after(curr)
+		if _, ok := itemsMap[curr.Value]; ok {
+			cdll.Remove(curr)
+		}
+	}
+}
+
+// MoveToFront moves the given element to the start of the list. It returns false if e doesn't
+// belong to the list. Moving the last element only moves the start of the ring.
+func (cdll *CircularDoublyLinkedList[T]) MoveToFront(e *Element[T]) bool {
+	if !cdll.owns(e) {
+		return false
+	}
+	if e != cdll.head && e != cdll.head.Prev {
+		cdll.unlink(e)
+		cdll.link(e, cdll.head.Prev)
+	}
+
+	cdll.head = e
+	return true
+}
+
+// MoveToBack moves the given element to the end of the list. It returns false if e doesn't belong
+// to the list. Moving the first element only moves the start of the ring.
+func (cdll *CircularDoublyLinkedList[T]) MoveToBack(e *Element[T]) bool {
+	if !cdll.owns(e) {
+		return false
+	}
+	if e == cdll.head {
+		cdll.head = e.Next
+	} else if e != cdll.head.Prev {
+		cdll.unlink(e)
+		cdll.link(e, cdll.head.Prev)
+	}
+
+	return true
+}
+
+// MoveAfter moves the given element after mark. It returns false if e or mark doesn't belong to
+// the list. If e and mark are the same element, the list is not modified.
+func (cdll *CircularDoublyLinkedList[T]) MoveAfter(e, mark *Element[T]) bool {
+	if !cdll.owns(e) || !cdll.owns(mark) {
+		return false
+	}
+	if e == mark || e == cdll.after(mark) {
+		return true
+	}
+	cdll.unlink(e)
+	cdll.link(e, mark)
+	return true
+}
+
+// MoveBefore moves the given element before mark. It returns false if e or mark doesn't belong to
+// the list. If e and mark are the same element, the list is not modified.
+func (cdll *CircularDoublyLinkedList[T]) MoveBefore(e, mark *Element[T]) bool {
+	if !cdll.owns(e) || !cdll.owns(mark) {
+		return false
+	}
+	if e == mark || e == cdll.before(mark) {
+		return true
+	}
+
+	cdll.unlink(e)
+	cdll.link(e, mark.Prev)
+	if mark == cdll.head {
+		cdll.head = e
+	}
+
+	return true
+}
+
+// PushBackList moves all the elements of other to the end of the list, leaving other empty. If
+// other is a CircularDoublyLinkedList, its ring is spliced in and its elements keep their identity.
+// If other is the list itself, the list is not modified.
+//
+// Splicing takes O(len(other)) time, as the elements of other have to be marked as belonging to
+// the list.
+func (cdll *CircularDoublyLinkedList[T]) PushBackList(other Interface[T]) {
+	otherList, ok := other.(*CircularDoublyLinkedList[T])
+	if !ok {
+		if other != nil {
+			cdll.PushBack(drain(other)...)
+		}
+
+		return
+	}
+	if otherList == cdll || otherList.head == nil {
+		return
+	}
+
+	cdll.splice(otherList)
+}
+
+// PushFrontList moves all the elements of other to the start of the list, in order, leaving other
+// empty. If other is a CircularDoublyLinkedList, its ring is spliced in and its elements keep their
+// identity. If other is the list itself, the list is not modified.
+//
+// Splicing takes O(len(other)) time, as the elements of other have to be marked as belonging to
+// the list.
+func (cdll *CircularDoublyLinkedList[T]) PushFrontList(other Interface[T]) {
+	otherList, ok := other.(*CircularDoublyLinkedList[T])
+	if !ok {
+		if other != nil {
+			items := drain(other)
+			reverseItems(items)
+			cdll.PushFront(items...)
+		}
+
+		return
+	}
+	if otherList == cdll || otherList.head == nil {
+		return
+	}
+
+	first := otherList.head
+	cdll.splice(otherList)
+	cdll.head = first
+}
+
+// Reverse reverses the order of the elements of the list in place.
+func (cdll *CircularDoublyLinkedList[T]) Reverse() {
+	if cdll.head == nil {
+		return
+	}
+
+	curr := cdll.head
+	for i := 0; i < cdll.len; i++ {
+		curr.Next, curr.Prev = curr.Prev, curr.Next
+		curr = curr.Prev
+	}
+
+	cdll.head = cdll.head.Next
+}
+
+// Rotate rotates the list by k positions, moving its last k elements to its start. Negative values
+// of k rotate the list in the other direction, moving its first -k elements to its end. Rotating by
+// a multiple of the length of the list doesn't modify it.
+//
+// Only the start of the ring moves, so rotating takes O(min(k, n-k)) time and no element is
+// relinked.
+func (cdll *CircularDoublyLinkedList[T]) Rotate(k int) {
+	if cdll.len < 2 {
+		return
+	}
+
+	k %= cdll.len
+	if k < 0 {
+		k += cdll.len
+	}
+	if k != 0 {
+		cdll.head = cdll.At(-k)
+	}
+}
+
+// SplitAt removes the elements from the (i+1)th element onwards and returns them as a new list of
+// the same type. Negative indices can also be used to split off the last -i elements. If the list
+// doesn't have enough elements, it returns nil.
+func (cdll *CircularDoublyLinkedList[T]) SplitAt(i int) Interface[T] {
+	if i < 0 {
+		i += cdll.len
+	}
+	if i < 0 || i > cdll.len {
+		return nil
+	}
+
+	newList := &CircularDoublyLinkedList[T]{}
+	if i == cdll.len {
+		return newList
+	}
+
+	first := cdll.At(i)
+	newList.head, newList.len = first, cdll.len-i
+	if i == 0 {
+		cdll.head = nil
+	} else {
+		last, prev := cdll.head.Prev, first.Prev
+		prev.Next, cdll.head.Prev = cdll.head, prev
+		last.Next, first.Prev = first, last
+	}
+	cdll.len = i
+
+	newList.adoptBy(newList)
+	return newList
+}
+
+// Sort sorts the list in place in ascending order as determined by less, using a bottom-up merge
+// sort in O(n*log(n)) time. The sort is stable. The elements are relinked rather than copied, so
+// they keep their identity.
+func (cdll *CircularDoublyLinkedList[T]) Sort(less func(a, b T) bool) {
+	if cdll.len < 2 {
+		return
+	}
+
+	cdll.head.Prev.Next = nil
+	head, tail := sortElements(cdll.head, less)
+
+	prev := tail
+	for curr := head; curr != nil; curr = curr.Next {
+		curr.Prev = prev
+		prev = curr
+	}
+
+	tail.Next = head
+	cdll.head = head
+}
+
+// mergeSorted relinks the elements of other into the sorted list in sorted order, if other is a
+// CircularDoublyLinkedList, and leaves other empty. It returns false if other is of another type.
+func (cdll *CircularDoublyLinkedList[T]) mergeSorted(other Interface[T], less func(a, b T) bool) bool {
+	otherList, ok := other.(*CircularDoublyLinkedList[T])
+	if !ok {
+		return false
+	}
+	if otherList.head == nil || cdll.head == nil {
+		if otherList.head != nil {
+			cdll.splice(otherList)
+		}
+		return true
+	}
+
+	otherList.adoptBy(cdll)
+	cdll.head.Prev.Next, otherList.head.Prev.Next = nil, nil
+	head, tail := mergeElements(cdll.head, otherList.head, less)
+
+	prev := tail
+	for curr := head; curr != nil; curr = curr.Next {
+		curr.Prev = prev
+		prev = curr
+	}
+
+	tail.Next = head
+	cdll.head = head
+	cdll.len += otherList.len
+	otherList.head, otherList.len = nil, 0
+	return true
+}
+
+// Copy creates a new copy of the list.
+func (cdll *CircularDoublyLinkedList[T]) Copy() Interface[T] {
+	newList := &CircularDoublyLinkedList[T]{}
+	cdll.Each(func(item T) bool {
+		newList.PushBack(item)
+		return false
+	})
+
+	return newList
+}
+
+// Validate checks the internal consistency of the list: every element belongs to the list, the
+// Next and Prev links of neighbouring elements point to each other and following Len Next links
+// from the first element leads back to it. It returns an error describing the first inconsistency
+// found, or nil if the list is consistent.
+func (cdll *CircularDoublyLinkedList[T]) Validate() error {
+	if cdll.head == nil {
+		if cdll.len != 0 {
+			return fmt.Errorf("list: length is %d, but found no elements", cdll.len)
+		}
+
+		return nil
+	}
+
+	curr := cdll.head
+	for i := 0; i < cdll.len; i++ {
+		if curr == nil {
+			return fmt.Errorf("list: element %d has no next element", i)
+		}
+		if curr.list != cdll {
+			return fmt.Errorf("list: element %d doesn't belong to the list", i)
+		}
+		if curr.Next == nil || curr.Next.Prev != curr {
+			return fmt.Errorf("list: element %d has an inconsistent next link", i)
+		}
+		if i > 0 && curr == cdll.head {
+			return fmt.Errorf("list: length is %d, but found %d elements", cdll.len, i)
+		}
+
+		curr = curr.Next
+	}
+
+	if curr != cdll.head {
+		return errors.New("list: last element doesn't link back to the first element")
+	}
+
+	return nil
+}
+
+// owns checks whether e is an element of the list.
+func (cdll *CircularDoublyLinkedList[T]) owns(e *Element[T]) bool {
+	return e != nil && e.list == cdll
+}
+
+// adoptBy marks all the elements of the list as belonging to owner.
+func (cdll *CircularDoublyLinkedList[T]) adoptBy(owner *CircularDoublyLinkedList[T]) {
+	curr := cdll.head
+	for i := 0; i < cdll.len; i++ {
+		curr.list = owner
+		curr = curr.Next
+	}
+}
+
+// after returns the element after e, or nil if e is the last element.
+func (cdll *CircularDoublyLinkedList[T]) after(e *Element[T]) *Element[T] {
+	if e.Next == cdll.head {
+		return nil
+	}
+
+	return e.Next
+}
+
+// before returns the element before e, or nil if e is the first element.
+func (cdll *CircularDoublyLinkedList[T]) before(e *Element[T]) *Element[T] {
+	if e == cdll.head {
+		return nil
+	}
+
+	return e.Prev
+}
+
+// splice links the ring of other at the end of the list, leaving other empty.
+func (cdll *CircularDoublyLinkedList[T]) splice(other *CircularDoublyLinkedList[T]) {
+	other.adoptBy(cdll)
+	if cdll.head == nil {
+		cdll.head = other.head
+	} else {
+		first, last := other.head, other.head.Prev
+		cdll.head.Prev.Next, first.Prev = first, cdll.head.Prev
+		last.Next, cdll.head.Prev = cdll.head, last
+	}
+
+	cdll.len += other.len
+	other.head, other.len = nil, 0
+}
+
+// link links the unlinked element e into the ring after mark. If mark is nil, the list must be
+// empty and e becomes its only element. The start of the ring is not moved.
+func (cdll *CircularDoublyLinkedList[T]) link(e, mark *Element[T]) {
+	if mark == nil {
+		e.Next, e.Prev = e, e
+		cdll.head = e
+		return
+	}
+
+	e.Prev, e.Next = mark, mark.Next
+	mark.Next.Prev = e
+	mark.Next = e
+}
+
+// unlink detaches e from its neighbours without removing it from the list. If e is the first
+// element, the start of the ring moves to the next element.
+func (cdll *CircularDoublyLinkedList[T]) unlink(e *Element[T]) {
+	if e.Next == e {
+		cdll.head = nil
+	} else {
+		e.Prev.Next = e.Next
+		e.Next.Prev = e.Prev
+		if e == cdll.head {
+			cdll.head = e.Next
+		}
+	}
+
+	e.Next = nil
+	e.Prev = nil
+}
+
+type circularDoublyLinkedListIterable[T comparable] struct {
+	list  *CircularDoublyLinkedList[T]
+	curr  *Element[T]
+	value T
+}
+
+func (cdlli *circularDoublyLinkedListIterable[T]) Next() bool {
+	if cdlli.curr == nil {
+		var zero T
+		cdlli.value = zero
+		return false
+	}
+
+	cdlli.value = cdlli.curr.Value
+	cdlli.curr = cdlli.list.after(cdlli.curr)
+	return true
+}
+
+func (cdlli *circularDoublyLinkedListIterable[T]) Value() T {
+	return cdlli.value
+}
@@ -0,0 +1,61 @@
+package list_test
+
+import (
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/list"
+)
+
+func TestCircularDoublyLinkedList(t *testing.T) {
+	testInterfaceHelper(t, list.NewCircularDoublyLinkedList)
+}
+
+func TestCircularDoublyLinkedListOf(t *testing.T) {
+	testInterfaceOfHelper(t, list.NewCircularDoublyLinkedListOf[string])
+}
+
+func TestCircularDoublyLinkedList_Ring(t *testing.T) {
+	newList := list.NewCircularDoublyLinkedList(4, 5, 6)
+	first, last := newList.First(), newList.Last()
+	if last.Next != first || first.Prev != last {
+		t.Errorf("Ring: expected the last and first Elements to be linked, got Next %v and Prev %v", last.Next, first.Prev)
+	}
+
+	var got []int
+	for i := 0; i < 5; i++ {
+		got = append(got, newList.First().Value)
+		newList.Rotate(-1)
+	}
+	if !slicesEqual(got, []int{4, 5, 6, 4, 5}) {
+		t.Errorf("Ring: expected round-robin values to be [4 5 6 4 5], got %v", got)
+	}
+	if newList.First() != first.Next.Next {
+		t.Errorf("Ring: expected Rotate to keep the Elements, got First %v", newList.First())
+	}
+	assertListValues(t, "Ring", newList, []int{6, 4, 5})
+}
+
+func TestCircularDoublyLinkedList_Validate(t *testing.T) {
+	newList := list.NewCircularDoublyLinkedListOf(4, 5, 6).(*list.CircularDoublyLinkedList[int])
+	if err := newList.Validate(); err != nil {
+		t.Errorf("Validate: expected Validate to return nil, got %v", err)
+	}
+
+	newList.First().Prev = nil
+	if err := newList.Validate(); err == nil {
+		t.Error("Validate: expected Validate to return an error for a broken Prev link, got nil")
+	}
+
+	newList = list.NewCircularDoublyLinkedListOf(4, 5, 6).(*list.CircularDoublyLinkedList[int])
+	newList.Last().Next = newList.At(1)
+	if err := newList.Validate(); err == nil {
+		t.Error("Validate: expected Validate to return an error for a ring not closed at the first Element, got nil")
+	}
+}
+
+func TestCircularDoublyLinkedList_PushListOther(t *testing.T) {
+	newList := list.NewCircularDoublyLinkedList(4, 5)
+	newList.PushBackList(list.NewDoublyLinkedList(6, 7))
+	newList.PushFrontList(list.NewSinglyLinkedList(2, 3))
+	assertListValues(t, "PushListOther", newList, []int{2, 3, 4, 5, 6, 7})
+}
@@ -0,0 +1,225 @@
+package list
+
+// Cursor is a bidirectional cursor over a list.Interface that can also modify the list while
+// iterating over it.
+//
+// A cursor is either positioned at an element of the list or off the list. A new cursor is off the
+// list. From off the list, Next moves to the first element and Prev moves to the last element, so
+// a cursor can iterate in both directions:
+//
+//     for c := list.NewCursor(l); c.Next(); {
+//         // Use c.Value()...
+//     }
+//
+//     for c := list.NewCursor(l); c.Prev(); {
+//         // Use c.Value() in reverse order...
+//     }
+//
+// The cursor follows the links of the list, so elements inserted or removed by other means are
+// seen by later moves. Moving elements within the list, for example with MoveToFront or Sort,
+// keeps the cursor at its element, and iteration continues from its new position. If the element
+// the cursor is positioned at is removed from the list by other means, the cursor becomes invalid:
+// Next and Prev return false and all the other methods fail until Reset is called.
+//
+// On a list without Prev links, like a SinglyLinkedList, moving backwards and modifying the list
+// after moving backwards take O(n) time.
+type Cursor[T comparable] struct {
+	list Interface[T]
+	curr *Element[T]
+
+	// prev is the element before curr when known, so that lists without Prev links don't have to
+	// be walked to remove or insert while moving forwards. It's only a hint and is checked before
+	// use.
+	prev *Element[T]
+
+	// forward is true if the last move was made by Next.
+	forward bool
+	invalid bool
+}
+
+// NewCursor returns a new cursor over l positioned off the list.
+func NewCursor[T comparable](l Interface[T]) *Cursor[T] {
+	return &Cursor[T]{list: l}
+}
+
+// Valid checks whether the cursor can still be used. A cursor becomes invalid if the element it is
+// positioned at is removed from the list by other means.
+func (c *Cursor[T]) Valid() bool {
+	if !c.invalid && c.curr != nil && !c.owns(c.curr) {
+		c.invalid = true
+	}
+
+	return !c.invalid
+}
+
+// Reset moves the cursor off the list, making it valid again.
+func (c *Cursor[T]) Reset() {
+	c.curr = nil
+	c.prev = nil
+	c.invalid = false
+}
+
+// Element returns the element the cursor is positioned at, or nil if it is off the list or
+// invalid.
+func (c *Cursor[T]) Element() *Element[T] {
+	if !c.Valid() {
+		return nil
+	}
+
+	return c.curr
+}
+
+// Value returns the item of the element the cursor is positioned at. If the cursor is off the list
+// or invalid, it returns the zero value.
+func (c *Cursor[T]) Value() T {
+	if !c.Valid() || c.curr == nil {
+		var zero T
+		return zero
+	}
+
+	return c.curr.Value
+}
+
+// Next moves the cursor to the next element, or to the first element if it is off the list. It
+// returns false if there is no such element, in which case the cursor moves off the list, or if
+// the cursor is invalid.
+func (c *Cursor[T]) Next() bool {
+	if !c.Valid() {
+		return false
+	}
+
+	var next *Element[T]
+	if c.curr == nil {
+		next = c.list.First()
+	} else {
+		next = after(c.list, c.curr)
+	}
+
+	c.prev, c.curr = c.curr, next
+	c.forward = true
+	return next != nil
+}
+
+// Prev moves the cursor to the previous element, or to the last element if it is off the list. It
+// returns false if there is no such element, in which case the cursor moves off the list, or if
+// the cursor is invalid.
+func (c *Cursor[T]) Prev() bool {
+	if !c.Valid() {
+		return false
+	}
+
+	var prev *Element[T]
+	if c.curr == nil {
+		prev = c.list.Last()
+	} else {
+		prev = c.before(c.curr)
+	}
+
+	c.prev, c.curr = nil, prev
+	c.forward = false
+	return prev != nil
+}
+
+// Set replaces the item of the element the cursor is positioned at. It returns false if the cursor
+// is off the list or invalid.
+func (c *Cursor[T]) Set(item T) bool {
+	if !c.Valid() || c.curr == nil {
+		return false
+	}
+
+	c.curr.Value = item
+	return true
+}
+
+// Remove removes and returns the element the cursor is positioned at. If the cursor is off the list
+// or invalid, it returns nil.
+//
+// The cursor moves back against the direction of the last move, so that the next move in the same
+// direction reaches the element that followed the removed one. For example, after removing while
+// moving with Next, the cursor is positioned at the element before the removed one, or off the
+// list if it was the first element.
+func (c *Cursor[T]) Remove() *Element[T] {
+	if !c.Valid() || c.curr == nil {
+		return nil
+	}
+
+	removedEl := c.curr
+	prev := c.before(removedEl)
+	if c.forward {
+		c.curr = prev
+	} else {
+		c.curr = after(c.list, removedEl)
+	}
+
+	c.prev = nil
+	if prev == nil {
+		return c.list.Remove(removedEl)
+	}
+
+	return c.list.RemoveAfter(prev)
+}
+
+// InsertAfter adds the item after the element the cursor is positioned at, or at the start of the
+// list if the cursor is off the list, and returns the inserted element. The cursor doesn't move, so
+// moving with Next reaches the inserted element. If the cursor is invalid, it returns nil.
+func (c *Cursor[T]) InsertAfter(item T) *Element[T] {
+	if !c.Valid() {
+		return nil
+	}
+	if c.curr == nil {
+		c.list.PushFront(item)
+		return c.list.First()
+	}
+
+	return c.list.InsertAfter(c.curr, item)
+}
+
+// InsertBefore adds the item before the element the cursor is positioned at, or at the end of the
+// list if the cursor is off the list, and returns the inserted element. The cursor doesn't move, so
+// moving with Prev reaches the inserted element. If the cursor is invalid, it returns nil.
+func (c *Cursor[T]) InsertBefore(item T) *Element[T] {
+	if !c.Valid() {
+		return nil
+	}
+	if c.curr == nil {
+		c.list.PushBack(item)
+		return c.list.Last()
+	}
+
+	var newEl *Element[T]
+	if prev := c.before(c.curr); prev == nil {
+		c.list.PushFront(item)
+		newEl = c.list.First()
+	} else {
+		newEl = c.list.InsertAfter(prev, item)
+	}
+
+	c.prev = newEl
+	return newEl
+}
+
+// owns checks whether e is an element of the list of the cursor.
+func (c *Cursor[T]) owns(e *Element[T]) bool {
+	return e.owner() == any(c.list)
+}
+
+// before returns the element before e, or nil if e is the first element.
+func (c *Cursor[T]) before(e *Element[T]) *Element[T] {
+	first := c.list.First()
+	if e == first {
+		return nil
+	}
+	if e.Prev != nil {
+		return e.Prev
+	}
+	if c.prev != nil && c.prev.Next == e && c.owns(c.prev) {
+		return c.prev
+	}
+
+	curr := first
+	for curr != nil && curr.Next != e {
+		curr = curr.Next
+	}
+
+	return curr
+}
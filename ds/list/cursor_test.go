@@ -0,0 +1,184 @@
+package list_test
+
+import (
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/list"
+)
+
+func TestCursor(t *testing.T) {
+	for name, newFn := range map[string]func(...int) list.Interface[int]{
+		"DoublyLinkedList":         list.NewDoublyLinkedList,
+		"SinglyLinkedList":         list.NewSinglyLinkedList,
+		"CircularDoublyLinkedList": list.NewCircularDoublyLinkedList,
+		"UnrolledLinkedList":       newSmallUnrolledLinkedList,
+	} {
+		t.Run(name, func(t *testing.T) {
+			testCursorHelper(t, newFn)
+		})
+	}
+}
+
+func testCursorHelper(t *testing.T, newFn func(...int) list.Interface[int]) {
+	t.Run("Next", func(t *testing.T) {
+		newList := newFn(4, 5, 6)
+		var got []int
+		for c := list.NewCursor(newList); c.Next(); {
+			got = append(got, c.Value())
+		}
+		if !slicesEqual(got, []int{4, 5, 6}) {
+			t.Errorf("Next: expected values to be [4 5 6], got %v", got)
+		}
+	})
+
+	t.Run("Prev", func(t *testing.T) {
+		newList := newFn(4, 5, 6)
+		var got []int
+		for c := list.NewCursor(newList); c.Prev(); {
+			got = append(got, c.Value())
+		}
+		if !slicesEqual(got, []int{6, 5, 4}) {
+			t.Errorf("Prev: expected values to be [6 5 4], got %v", got)
+		}
+
+		c := list.NewCursor(newList)
+		c.Next()
+		c.Next()
+		c.Prev()
+		if c.Value() != 4 {
+			t.Errorf("Prev: expected Value to be 4, got %d", c.Value())
+		}
+		if c.Prev() || c.Element() != nil {
+			t.Error("Prev: expected Prev from the first element to move off the list")
+		}
+		if !c.Prev() || c.Value() != 6 {
+			t.Errorf("Prev: expected Prev from off the list to move to 6, got %d", c.Value())
+		}
+
+		if list.NewCursor(newFn()).Prev() {
+			t.Error("Prev: expected Prev on an empty list to return false, got true")
+		}
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		newList := newFn(4, 5, 6)
+		c := list.NewCursor(newList)
+		if c.Set(1) {
+			t.Error("Set: expected Set off the list to return false, got true")
+		}
+
+		for c.Next() {
+			c.Set(c.Value() * 2)
+		}
+		assertListValues(t, "Set", newList, []int{8, 10, 12})
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		newList := newFn(4, 5, 6, 7, 8)
+		c := list.NewCursor(newList)
+		var got []int
+		for c.Next() {
+			got = append(got, c.Value())
+			if c.Value()%2 == 0 {
+				if el := c.Remove(); el == nil {
+					t.Error("Remove: expected Remove to return the removed Element, got nil")
+				}
+			}
+		}
+		if !slicesEqual(got, []int{4, 5, 6, 7, 8}) {
+			t.Errorf("Remove: expected visited values to be [4 5 6 7 8], got %v", got)
+		}
+		assertListValues(t, "Remove", newList, []int{5, 7})
+
+		newList = newFn(4, 5, 6, 7, 8)
+		c = list.NewCursor(newList)
+		got = nil
+		for c.Prev() {
+			got = append(got, c.Value())
+			if c.Value()%2 == 1 {
+				c.Remove()
+			}
+		}
+		if !slicesEqual(got, []int{8, 7, 6, 5, 4}) {
+			t.Errorf("Remove: expected visited values to be [8 7 6 5 4], got %v", got)
+		}
+		assertListValues(t, "Remove", newList, []int{4, 6, 8})
+
+		if c.Remove() != nil {
+			t.Error("Remove: expected Remove off the list to return nil, got non-nil")
+		}
+	})
+
+	t.Run("InsertAfter", func(t *testing.T) {
+		newList := newFn(4, 6)
+		c := list.NewCursor(newList)
+		c.InsertAfter(2)
+		var got []int
+		for c.Next() {
+			got = append(got, c.Value())
+			if c.Value() == 4 {
+				if el := c.InsertAfter(5); el == nil || el.Value != 5 {
+					t.Errorf("InsertAfter: expected InsertAfter to return Element with Value 5, got %v", el)
+				}
+			}
+		}
+		if !slicesEqual(got, []int{2, 4, 5, 6}) {
+			t.Errorf("InsertAfter: expected visited values to be [2 4 5 6], got %v", got)
+		}
+		assertListValues(t, "InsertAfter", newList, []int{2, 4, 5, 6})
+	})
+
+	t.Run("InsertBefore", func(t *testing.T) {
+		newList := newFn(4, 6)
+		c := list.NewCursor(newList)
+		c.InsertBefore(8)
+		for c.Next() {
+			c.InsertBefore(c.Value() - 1)
+		}
+		assertListValues(t, "InsertBefore", newList, []int{3, 4, 5, 6, 7, 8})
+
+		newList = newFn()
+		c = list.NewCursor(newList)
+		c.InsertBefore(1)
+		c.InsertAfter(0)
+		assertListValues(t, "InsertBefore", newList, []int{0, 1})
+	})
+
+	t.Run("ExternalModification", func(t *testing.T) {
+		newList := newFn(4, 5, 6)
+		c := list.NewCursor(newList)
+		c.Next()
+		newList.InsertAfter(newList.First(), 9)
+		if !c.Next() || c.Value() != 9 {
+			t.Errorf("ExternalModification: expected Next to move to 9, got %d", c.Value())
+		}
+
+		newList.MoveToFront(c.Element())
+		if !c.Next() || c.Value() != 4 {
+			t.Errorf("ExternalModification: expected Next to move to 4, got %d", c.Value())
+		}
+
+		newList.Remove(c.Element())
+		if c.Valid() {
+			t.Error("ExternalModification: expected Valid to be false, got true")
+		}
+		if c.Next() || c.Prev() || c.Set(1) || c.Remove() != nil || c.InsertAfter(1) != nil {
+			t.Error("ExternalModification: expected an invalid cursor to fail")
+		}
+
+		c.Reset()
+		var got []int
+		for c.Next() {
+			got = append(got, c.Value())
+		}
+		if !slicesEqual(got, []int{9, 5, 6}) {
+			t.Errorf("ExternalModification: expected values to be [9 5 6], got %v", got)
+		}
+
+		c.Prev()
+		newList.SplitAt(0)
+		if c.Valid() {
+			t.Error("ExternalModification: expected Valid after SplitAt to be false, got true")
+		}
+	})
+}
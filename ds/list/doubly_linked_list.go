@@ -1,57 +1,68 @@
 package list
 
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
 // DoublyLinkedList represents a list instance implemented as a doubly linked list.
-type DoublyLinkedList struct {
-	head *Element
-	tail *Element
+type DoublyLinkedList[T comparable] struct {
+	head *Element[T]
+	tail *Element[T]
+	len  int
+}
+
+// NewDoublyLinkedList returns a new doubly linked list instance of ints with the given items
+// inserted in order.
+func NewDoublyLinkedList(items ...int) Interface[int] {
+	return NewDoublyLinkedListOf(items...)
 }
 
-// NewDoublyLinkedList returns a new doubly linked list instance with the given items inserted in
+// NewDoublyLinkedListOf returns a new doubly linked list instance with the given items inserted in
 // order.
-func NewDoublyLinkedList(items ...int) Interface {
-	newList := &DoublyLinkedList{}
+func NewDoublyLinkedListOf[T comparable](items ...T) Interface[T] {
+	newList := &DoublyLinkedList[T]{}
 	newList.PushBack(items...)
 	return newList
 }
 
 // Len returns the number of items in the list.
-func (dll *DoublyLinkedList) Len() int {
-	if dll.head == nil {
-		return 0
-	}
-
-	count := 1
-	for curr := dll.head; curr.Next != nil; curr = curr.Next {
-		count++
-	}
-
-	return count
+func (dll *DoublyLinkedList[T]) Len() int {
+	return dll.len
 }
 
 // Empty checks whether the list is empty.
-func (dll *DoublyLinkedList) Empty() bool {
+func (dll *DoublyLinkedList[T]) Empty() bool {
 	return dll.head == nil
 }
 
 // Clear deletes all the items from the list.
-func (dll *DoublyLinkedList) Clear() {
+func (dll *DoublyLinkedList[T]) Clear() {
+	var next *Element[T]
+	for curr := dll.head; curr != nil; curr = next {
+		next = curr.Next
+		curr.Next, curr.Prev, curr.list = nil, nil, nil
+	}
+
 	dll.head = nil
 	dll.tail = nil
+	dll.len = 0
 }
 
 // First returns the first element of the list.
-func (dll *DoublyLinkedList) First() *Element {
+func (dll *DoublyLinkedList[T]) First() *Element[T] {
 	return dll.head
 }
 
 // Last returns the last element of the list.
-func (dll *DoublyLinkedList) Last() *Element {
+func (dll *DoublyLinkedList[T]) Last() *Element[T] {
 	return dll.tail
 }
 
 // At returns the (i+1)th element of the list. Negative indices can also be used to find the (-i)th
 // last element.
-func (dll *DoublyLinkedList) At(i int) *Element {
+func (dll *DoublyLinkedList[T]) At(i int) *Element[T] {
 	if dll.head == nil {
 		return nil
 	}
@@ -63,7 +74,7 @@ func (dll *DoublyLinkedList) At(i int) *Element {
 	}
 
 	currIdx := 0
-	var curr *Element
+	var curr *Element[T]
 	if reverse {
 		curr = dll.tail
 	} else {
@@ -87,7 +98,7 @@ func (dll *DoublyLinkedList) At(i int) *Element {
 }
 
 // Contains checks whether the list contains all the given items.
-func (dll *DoublyLinkedList) Contains(items ...int) bool {
+func (dll *DoublyLinkedList[T]) Contains(items ...T) bool {
 	if len(items) == 0 {
 		return true
 	}
@@ -95,7 +106,7 @@ func (dll *DoublyLinkedList) Contains(items ...int) bool {
 		return false
 	}
 
-	itemsMap := make(map[int]struct{}, len(items))
+	itemsMap := make(map[T]struct{}, len(items))
 	for _, item := range items {
 		itemsMap[item] = struct{}{}
 	}
@@ -110,7 +121,7 @@ func (dll *DoublyLinkedList) Contains(items ...int) bool {
 }
 
 // Each iterates over the items of the list.
-func (dll *DoublyLinkedList) Each(fn func(int) bool) {
+func (dll *DoublyLinkedList[T]) Each(fn func(T) bool) {
 	if dll.head == nil {
 		return
 	}
@@ -123,25 +134,54 @@ func (dll *DoublyLinkedList) Each(fn func(int) bool) {
 }
 
 // Iterator returns a list.Iterable that can be used to iterate over the list.
-func (dll *DoublyLinkedList) Iterator() Iterable {
-	return &doublyLinkedListIterable{
+func (dll *DoublyLinkedList[T]) Iterator() Iterable[T] {
+	return &doublyLinkedListIterable[T]{
 		curr: dll.head,
 	}
 }
 
+// All returns an iterator over the items of the list from the first to the last. The current
+// element can be removed during iteration.
+func (dll *DoublyLinkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var next *Element[T]
+		for curr := dll.head; curr != nil; curr = next {
+			next = curr.Next
+			if !yield(curr.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the list from the last to the first. The current
+// element can be removed during iteration.
+func (dll *DoublyLinkedList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var prev *Element[T]
+		for curr := dll.tail; curr != nil; curr = prev {
+			prev = curr.Prev
+			if !yield(curr.Value) {
+				return
+			}
+		}
+	}
+}
+
 // PushFront adds the given items at the start of the list.
-func (dll *DoublyLinkedList) PushFront(items ...int) {
+func (dll *DoublyLinkedList[T]) PushFront(items ...T) {
 	if len(items) == 0 {
 		return
 	}
 
-	var next *Element
-	var curr *Element
+	var next *Element[T]
+	var curr *Element[T]
 	for _, item := range items {
 		if curr == nil {
-			curr = &Element{
+			curr = &Element[T]{
 				Value: item,
 				Next:  dll.head,
+				list:  dll,
 			}
 
 			if dll.head != nil {
@@ -150,9 +190,10 @@ func (dll *DoublyLinkedList) PushFront(items ...int) {
 				dll.tail = curr
 			}
 		} else {
-			curr = &Element{
+			curr = &Element[T]{
 				Value: item,
 				Next:  curr,
+				list:  dll,
 			}
 
 			next.Prev = curr
@@ -162,11 +203,12 @@ func (dll *DoublyLinkedList) PushFront(items ...int) {
 	}
 
 	dll.head = curr
+	dll.len += len(items)
 }
 
 // PopFront removes and returns the first element from the list. If the list is empty, it returns
 // nil.
-func (dll *DoublyLinkedList) PopFront() *Element {
+func (dll *DoublyLinkedList[T]) PopFront() *Element[T] {
 	if dll.head == nil {
 		return nil
 	}
@@ -180,22 +222,25 @@ func (dll *DoublyLinkedList) PopFront() *Element {
 	}
 
 	removedEl.Next = nil
+	removedEl.list = nil
+	dll.len--
 	return removedEl
 }
 
 // PushBack adds the given items at the end of the list.
-func (dll *DoublyLinkedList) PushBack(items ...int) {
+func (dll *DoublyLinkedList[T]) PushBack(items ...T) {
 	if len(items) == 0 {
 		return
 	}
 
-	var prev *Element
-	var curr *Element
+	var prev *Element[T]
+	var curr *Element[T]
 	for _, item := range items {
 		if curr == nil {
-			curr = &Element{
+			curr = &Element[T]{
 				Value: item,
 				Prev:  dll.tail,
+				list:  dll,
 			}
 
 			if dll.tail != nil {
@@ -204,9 +249,10 @@ func (dll *DoublyLinkedList) PushBack(items ...int) {
 				dll.head = curr
 			}
 		} else {
-			curr = &Element{
+			curr = &Element[T]{
 				Value: item,
 				Prev:  curr,
+				list:  dll,
 			}
 
 			prev.Next = curr
@@ -216,11 +262,12 @@ func (dll *DoublyLinkedList) PushBack(items ...int) {
 	}
 
 	dll.tail = curr
+	dll.len += len(items)
 }
 
 // PopBack removes and returns the last element from the list. If the list is empty, it returns
 // nil.
-func (dll *DoublyLinkedList) PopBack() *Element {
+func (dll *DoublyLinkedList[T]) PopBack() *Element[T] {
 	if dll.head == nil {
 		return nil
 	}
@@ -234,13 +281,15 @@ func (dll *DoublyLinkedList) PopBack() *Element {
 	}
 
 	removedEl.Prev = nil
+	removedEl.list = nil
+	dll.len--
 	return removedEl
 }
 
 // InsertAt adds the item as the (i+1)th element and returns the element. Negative indices can also
 // be used to insert after the (-i)th last element. If the list doesn't have enough elements, it
 // returns nil.
-func (dll *DoublyLinkedList) InsertAt(i int, item int) *Element {
+func (dll *DoublyLinkedList[T]) InsertAt(i int, item T) *Element[T] {
 	if dll.head == nil {
 		if i == 0 || i == -1 {
 			dll.PushFront(item)
@@ -250,7 +299,7 @@ func (dll *DoublyLinkedList) InsertAt(i int, item int) *Element {
 		return nil
 	}
 	if i == 0 {
-		return dll.InsertAfter(dll.head, item)
+		return dll.InsertBefore(dll.head, item)
 	}
 	if i > 0 {
 		i--
@@ -261,16 +310,17 @@ func (dll *DoublyLinkedList) InsertAt(i int, item int) *Element {
 }
 
 // InsertAfter adds the item after the given element and returns the inserted element. If inserting
-// after e is not possible, it returns nil.
-func (dll *DoublyLinkedList) InsertAfter(e *Element, item int) *Element {
-	if e == nil {
+// after e is not possible, including when e doesn't belong to the list, it returns nil.
+func (dll *DoublyLinkedList[T]) InsertAfter(e *Element[T], item T) *Element[T] {
+	if !dll.owns(e) {
 		return nil
 	}
 
-	newEl := &Element{
+	newEl := &Element[T]{
 		Value: item,
 		Next:  e.Next,
 		Prev:  e,
+		list:  dll,
 	}
 	if e.Next == nil {
 		dll.tail = newEl
@@ -278,21 +328,23 @@ func (dll *DoublyLinkedList) InsertAfter(e *Element, item int) *Element {
 		e.Next.Prev = newEl
 	}
 	e.Next = newEl
+	dll.len++
 
 	return newEl
 }
 
 // InsertBefore adds the item before the given element and returns the inserted element. If
-// inserting after e is not possible, it returns nil.
-func (dll *DoublyLinkedList) InsertBefore(e *Element, item int) *Element {
-	if e == nil {
+// inserting before e is not possible, including when e doesn't belong to the list, it returns nil.
+func (dll *DoublyLinkedList[T]) InsertBefore(e *Element[T], item T) *Element[T] {
+	if !dll.owns(e) {
 		return nil
 	}
 
-	newEl := &Element{
+	newEl := &Element[T]{
 		Value: item,
 		Next:  e,
 		Prev:  e.Prev,
+		list:  dll,
 	}
 	if e.Prev == nil {
 		dll.head = newEl
@@ -300,19 +352,21 @@ func (dll *DoublyLinkedList) InsertBefore(e *Element, item int) *Element {
 		e.Prev.Next = newEl
 	}
 	e.Prev = newEl
+	dll.len++
 
 	return newEl
 }
 
 // RemoveAt removes the (i+1)th element. Negative indices can also be used to remove the (-i)th
 // last element. If the list doesn't have enough elements, it returns nil.
-func (dll *DoublyLinkedList) RemoveAt(i int) *Element {
+func (dll *DoublyLinkedList[T]) RemoveAt(i int) *Element[T] {
 	return dll.Remove(dll.At(i))
 }
 
-// Remove removes and returns the given element. If removing e is not possible, it returns nil.
-func (dll *DoublyLinkedList) Remove(e *Element) *Element {
-	if e == nil {
+// Remove removes and returns the given element. If removing e is not possible, including when e
+// doesn't belong to the list, it returns nil.
+func (dll *DoublyLinkedList[T]) Remove(e *Element[T]) *Element[T] {
+	if !dll.owns(e) {
 		return nil
 	}
 
@@ -332,13 +386,15 @@ func (dll *DoublyLinkedList) Remove(e *Element) *Element {
 
 	e.Prev = nil
 	e.Next = nil
+	e.list = nil
+	dll.len--
 	return e
 }
 
 // RemoveAfter removes and returns the element after the given element. If removing after e is not
-// possible, it returns nil.
-func (dll *DoublyLinkedList) RemoveAfter(e *Element) *Element {
-	if e == nil {
+// possible, including when e doesn't belong to the list, it returns nil.
+func (dll *DoublyLinkedList[T]) RemoveAfter(e *Element[T]) *Element[T] {
+	if !dll.owns(e) {
 		return nil
 	}
 
@@ -346,9 +402,9 @@ func (dll *DoublyLinkedList) RemoveAfter(e *Element) *Element {
 }
 
 // RemoveBefore removes and returns the element before the given element. If removing before e is
-// not possible, it returns nil.
-func (dll *DoublyLinkedList) RemoveBefore(e *Element) *Element {
-	if e == nil {
+// not possible, including when e doesn't belong to the list, it returns nil.
+func (dll *DoublyLinkedList[T]) RemoveBefore(e *Element[T]) *Element[T] {
+	if !dll.owns(e) {
 		return nil
 	}
 
@@ -357,17 +413,17 @@ func (dll *DoublyLinkedList) RemoveBefore(e *Element) *Element {
 
 // DeleteFirst deletes the first occurrence of the given items from the list. If the same item is
 // passed twice as an argument, only one occurrence is deleted in total.
-func (dll *DoublyLinkedList) DeleteFirst(items ...int) {
+func (dll *DoublyLinkedList[T]) DeleteFirst(items ...T) {
 	if len(items) == 0 || dll.head == nil {
 		return
 	}
 
-	itemsMap := make(map[int]struct{}, len(items))
+	itemsMap := make(map[T]struct{}, len(items))
 	for _, item := range items {
 		itemsMap[item] = struct{}{}
 	}
 
-	var next *Element
+	var next *Element[T]
 	for curr := dll.head; curr != nil; curr = next {
 		next = curr.Next
 		if _, ok := itemsMap[curr.Value]; ok {
@@ -382,17 +438,17 @@ func (dll *DoublyLinkedList) DeleteFirst(items ...int) {
 }
 
 // Delete deletes the all occurrences of the given items from the list.
-func (dll *DoublyLinkedList) Delete(items ...int) {
+func (dll *DoublyLinkedList[T]) Delete(items ...T) {
 	if len(items) == 0 || dll.head == nil {
 		return
 	}
 
-	itemsMap := make(map[int]struct{}, len(items))
+	itemsMap := make(map[T]struct{}, len(items))
 	for _, item := range items {
 		itemsMap[item] = struct{}{}
 	}
 
-	var next *Element
+	var next *Element[T]
 	for curr := dll.head; curr != nil; curr = next {
 		next = curr.Next
 		if _, ok := itemsMap[curr.Value]; ok {
@@ -401,9 +457,231 @@ func (dll *DoublyLinkedList) Delete(items ...int) {
 	}
 }
 
+// MoveToFront moves the given element to the start of the list. It returns false if e doesn't
+// belong to the list.
+func (dll *DoublyLinkedList[T]) MoveToFront(e *Element[T]) bool {
+	if !dll.owns(e) {
+		return false
+	}
+	if e != dll.head {
+		dll.unlink(e)
+		dll.linkAfter(e, nil)
+	}
+
+	return true
+}
+
+// MoveToBack moves the given element to the end of the list. It returns false if e doesn't belong
+// to the list.
+func (dll *DoublyLinkedList[T]) MoveToBack(e *Element[T]) bool {
+	if !dll.owns(e) {
+		return false
+	}
+	if e != dll.tail {
+		dll.unlink(e)
+		dll.linkAfter(e, dll.tail)
+	}
+
+	return true
+}
+
+// MoveAfter moves the given element after mark. It returns false if e or mark doesn't belong to
+// the list. If e and mark are the same element, the list is not modified.
+func (dll *DoublyLinkedList[T]) MoveAfter(e, mark *Element[T]) bool {
+	if !dll.owns(e) || !dll.owns(mark) {
+		return false
+	}
+	if e != mark && e != mark.Next {
+		dll.unlink(e)
+		dll.linkAfter(e, mark)
+	}
+
+	return true
+}
+
+// MoveBefore moves the given element before mark. It returns false if e or mark doesn't belong to
+// the list. If e and mark are the same element, the list is not modified.
+func (dll *DoublyLinkedList[T]) MoveBefore(e, mark *Element[T]) bool {
+	if !dll.owns(e) || !dll.owns(mark) {
+		return false
+	}
+	if e != mark && e != mark.Prev {
+		dll.unlink(e)
+		dll.linkAfter(e, mark.Prev)
+	}
+
+	return true
+}
+
+// PushBackList moves all the elements of other to the end of the list, leaving other empty. If
+// other is a DoublyLinkedList, its elements are spliced in and keep their identity. If other is
+// the list itself, the list is not modified.
+//
+// Splicing takes O(len(other)) time, as the elements of other have to be marked as belonging to
+// the list.
+func (dll *DoublyLinkedList[T]) PushBackList(other Interface[T]) {
+	otherList, ok := other.(*DoublyLinkedList[T])
+	if !ok {
+		if other != nil {
+			dll.PushBack(drain(other)...)
+		}
+
+		return
+	}
+	if otherList == dll || otherList.head == nil {
+		return
+	}
+
+	otherList.adoptBy(dll)
+	if dll.tail == nil {
+		dll.head = otherList.head
+	} else {
+		dll.tail.Next = otherList.head
+		otherList.head.Prev = dll.tail
+	}
+
+	dll.tail = otherList.tail
+	dll.len += otherList.len
+	otherList.head, otherList.tail, otherList.len = nil, nil, 0
+}
+
+// PushFrontList moves all the elements of other to the start of the list, in order, leaving other
+// empty. If other is a DoublyLinkedList, its elements are spliced in and keep their identity. If
+// other is the list itself, the list is not modified.
+//
+// Splicing takes O(len(other)) time, as the elements of other have to be marked as belonging to
+// the list.
+func (dll *DoublyLinkedList[T]) PushFrontList(other Interface[T]) {
+	otherList, ok := other.(*DoublyLinkedList[T])
+	if !ok {
+		if other != nil {
+			items := drain(other)
+			reverseItems(items)
+			dll.PushFront(items...)
+		}
+
+		return
+	}
+	if otherList == dll || otherList.head == nil {
+		return
+	}
+
+	otherList.adoptBy(dll)
+	if dll.head == nil {
+		dll.tail = otherList.tail
+	} else {
+		dll.head.Prev = otherList.tail
+		otherList.tail.Next = dll.head
+	}
+
+	dll.head = otherList.head
+	dll.len += otherList.len
+	otherList.head, otherList.tail, otherList.len = nil, nil, 0
+}
+
+// Reverse reverses the order of the elements of the list in place.
+func (dll *DoublyLinkedList[T]) Reverse() {
+	for curr := dll.head; curr != nil; curr = curr.Prev {
+		curr.Next, curr.Prev = curr.Prev, curr.Next
+	}
+
+	dll.head, dll.tail = dll.tail, dll.head
+}
+
+// Rotate rotates the list by k positions, moving its last k elements to its start. Negative values
+// of k rotate the list in the other direction, moving its first -k elements to its end. Rotating by
+// a multiple of the length of the list doesn't modify it.
+func (dll *DoublyLinkedList[T]) Rotate(k int) {
+	if dll.len < 2 {
+		return
+	}
+
+	k %= dll.len
+	if k < 0 {
+		k += dll.len
+	}
+	if k == 0 {
+		return
+	}
+
+	newHead := dll.At(-k)
+	dll.tail.Next = dll.head
+	dll.head.Prev = dll.tail
+	dll.head, dll.tail = newHead, newHead.Prev
+	dll.head.Prev = nil
+	dll.tail.Next = nil
+}
+
+// SplitAt removes the elements from the (i+1)th element onwards and returns them as a new list of
+// the same type. Negative indices can also be used to split off the last -i elements. If the list
+// doesn't have enough elements, it returns nil.
+func (dll *DoublyLinkedList[T]) SplitAt(i int) Interface[T] {
+	if i < 0 {
+		i += dll.len
+	}
+	if i < 0 || i > dll.len {
+		return nil
+	}
+
+	newList := &DoublyLinkedList[T]{}
+	if i == dll.len {
+		return newList
+	}
+
+	first := dll.At(i)
+	newList.head, newList.tail, newList.len = first, dll.tail, dll.len-i
+	newList.adoptBy(newList)
+
+	dll.tail = first.Prev
+	if dll.tail == nil {
+		dll.head = nil
+	} else {
+		dll.tail.Next = nil
+	}
+	first.Prev = nil
+	dll.len = i
+
+	return newList
+}
+
+// Sort sorts the list in place in ascending order as determined by less, using a bottom-up merge
+// sort in O(n*log(n)) time. The sort is stable. The elements are relinked rather than copied, so
+// they keep their identity.
+func (dll *DoublyLinkedList[T]) Sort(less func(a, b T) bool) {
+	dll.head, dll.tail = sortElements(dll.head, less)
+
+	var prev *Element[T]
+	for curr := dll.head; curr != nil; curr = curr.Next {
+		curr.Prev = prev
+		prev = curr
+	}
+}
+
+// mergeSorted relinks the elements of other into the sorted list in sorted order, if other is a
+// DoublyLinkedList, and leaves other empty. It returns false if other is of another type.
+func (dll *DoublyLinkedList[T]) mergeSorted(other Interface[T], less func(a, b T) bool) bool {
+	otherList, ok := other.(*DoublyLinkedList[T])
+	if !ok {
+		return false
+	}
+
+	otherList.adoptBy(dll)
+	dll.head, dll.tail = mergeElements(dll.head, otherList.head, less)
+
+	var prev *Element[T]
+	for curr := dll.head; curr != nil; curr = curr.Next {
+		curr.Prev = prev
+		prev = curr
+	}
+
+	dll.len += otherList.len
+	otherList.head, otherList.tail, otherList.len = nil, nil, 0
+	return true
+}
+
 // Copy creates a new copy of the list.
-func (dll *DoublyLinkedList) Copy() Interface {
-	newList := NewDoublyLinkedList()
+func (dll *DoublyLinkedList[T]) Copy() Interface[T] {
+	newList := NewDoublyLinkedListOf[T]()
 	if dll.head == nil {
 		return newList
 	}
@@ -414,14 +692,101 @@ func (dll *DoublyLinkedList) Copy() Interface {
 	return newList
 }
 
-type doublyLinkedListIterable struct {
-	curr  *Element
-	value int
+// Validate checks the internal consistency of the list: every element belongs to the list, the
+// Next and Prev links of neighbouring elements point to each other, the head and the tail are the
+// ends of the chain and the cached length matches the number of elements. It returns an error
+// describing the first inconsistency found, or nil if the list is consistent.
+func (dll *DoublyLinkedList[T]) Validate() error {
+	if dll.head != nil && dll.head.Prev != nil {
+		return errors.New("list: head has a previous element")
+	}
+
+	count := 0
+	var prev *Element[T]
+	for curr := dll.head; curr != nil; curr = curr.Next {
+		if curr.list != dll {
+			return fmt.Errorf("list: element %d doesn't belong to the list", count)
+		}
+		if curr.Prev != prev {
+			return fmt.Errorf("list: element %d has an inconsistent previous link", count)
+		}
+
+		count++
+		if count > dll.len {
+			return fmt.Errorf("list: more elements than the length %d", dll.len)
+		}
+
+		prev = curr
+	}
+
+	if dll.tail != prev {
+		return errors.New("list: tail is not the last element")
+	}
+	if count != dll.len {
+		return fmt.Errorf("list: length is %d, but found %d elements", dll.len, count)
+	}
+
+	return nil
+}
+
+// owns checks whether e is an element of the list.
+func (dll *DoublyLinkedList[T]) owns(e *Element[T]) bool {
+	return e != nil && e.list == dll
+}
+
+// adoptBy marks all the elements of the list as belonging to owner.
+func (dll *DoublyLinkedList[T]) adoptBy(owner *DoublyLinkedList[T]) {
+	for curr := dll.head; curr != nil; curr = curr.Next {
+		curr.list = owner
+	}
+}
+
+// unlink detaches e from its neighbours without removing it from the list. It must be linked back
+// using linkAfter.
+func (dll *DoublyLinkedList[T]) unlink(e *Element[T]) {
+	if e.Prev == nil {
+		dll.head = e.Next
+	} else {
+		e.Prev.Next = e.Next
+	}
+	if e.Next == nil {
+		dll.tail = e.Prev
+	} else {
+		e.Next.Prev = e.Prev
+	}
+
+	e.Prev = nil
+	e.Next = nil
+}
+
+// linkAfter links the unlinked element e after mark. If mark is nil, e is linked at the start of
+// the list.
+func (dll *DoublyLinkedList[T]) linkAfter(e, mark *Element[T]) {
+	e.Prev = mark
+	if mark == nil {
+		e.Next = dll.head
+		dll.head = e
+	} else {
+		e.Next = mark.Next
+		mark.Next = e
+	}
+
+	if e.Next == nil {
+		dll.tail = e
+	} else {
+		e.Next.Prev = e
+	}
+}
+
+type doublyLinkedListIterable[T comparable] struct {
+	curr  *Element[T]
+	value T
 }
 
-func (dlli *doublyLinkedListIterable) Next() bool {
+func (dlli *doublyLinkedListIterable[T]) Next() bool {
 	if dlli.curr == nil {
-		dlli.value = 0
+		var zero T
+		dlli.value = zero
 		return false
 	}
 
@@ -430,6 +795,6 @@ func (dlli *doublyLinkedListIterable) Next() bool {
 	return true
 }
 
-func (dlli *doublyLinkedListIterable) Value() int {
+func (dlli *doublyLinkedListIterable[T]) Value() T {
 	return dlli.value
 }
@@ -9,3 +9,32 @@ import (
 func TestDoublyLinkedList(t *testing.T) {
 	testInterfaceHelper(t, list.NewDoublyLinkedList)
 }
+
+func TestDoublyLinkedListOf(t *testing.T) {
+	testInterfaceOfHelper(t, list.NewDoublyLinkedListOf[string])
+}
+
+func TestDoublyLinkedList_Validate(t *testing.T) {
+	newList := list.NewDoublyLinkedListOf(4, 5, 6).(*list.DoublyLinkedList[int])
+	if err := newList.Validate(); err != nil {
+		t.Errorf("Validate: expected Validate to return nil, got %v", err)
+	}
+
+	newList.At(1).Prev = nil
+	if err := newList.Validate(); err == nil {
+		t.Error("Validate: expected Validate to return an error for a broken Prev link, got nil")
+	}
+
+	newList = list.NewDoublyLinkedListOf(4, 5, 6).(*list.DoublyLinkedList[int])
+	newList.Last().Next = list.NewDoublyLinkedListOf(7).First()
+	if err := newList.Validate(); err == nil {
+		t.Error("Validate: expected Validate to return an error for a foreign Element, got nil")
+	}
+}
+
+func TestDoublyLinkedList_PushListOther(t *testing.T) {
+	newList := list.NewDoublyLinkedList(4, 5)
+	newList.PushBackList(list.NewSinglyLinkedList(6, 7))
+	newList.PushFrontList(list.NewSinglyLinkedList(2, 3))
+	assertListValues(t, "PushListOther", newList, []int{2, 3, 4, 5, 6, 7})
+}
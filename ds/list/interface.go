@@ -1,7 +1,10 @@
 package list
 
-// Interface is the interface that groups the basic methods of a list implementation.
-type Interface interface {
+import "iter"
+
+// Interface is the interface that groups the basic methods of a list implementation. Items of the
+// list must be comparable so that they can be searched for and deleted by value.
+type Interface[T comparable] interface {
 	// Len returns the number of items in the list.
 	Len() int
 
@@ -12,87 +15,163 @@ type Interface interface {
 	Clear()
 
 	// First returns the first element of the list.
-	First() *Element
+	First() *Element[T]
 
 	// Last returns the last element of the list.
-	Last() *Element
+	Last() *Element[T]
 
 	// At returns the (i+1)th element of the list. Negative indices can also be used to find the
 	// (-i)th last element.
-	At(i int) *Element
+	At(i int) *Element[T]
 
 	// Contains checks whether the list contains all the given items.
-	Contains(items ...int) bool
+	Contains(items ...T) bool
 
 	// Each iterates over the items of the list.
-	Each(fn func(int) bool)
+	Each(fn func(T) bool)
 
 	// Iterator returns a list.Iterable that can be used to iterate over the list.
-	Iterator() Iterable
+	Iterator() Iterable[T]
+
+	// All returns an iterator over the items of the list from the first to the last. The current
+	// element can be removed during iteration.
+	All() iter.Seq[T]
+
+	// Backward returns an iterator over the items of the list from the last to the first. The
+	// current element can be removed during iteration.
+	Backward() iter.Seq[T]
 
 	// PushFront adds the given items at the start of the list.
-	PushFront(items ...int)
+	PushFront(items ...T)
 
 	// PopFront removes and returns the first element from the list. If the list is empty, it
 	// returns nil.
-	PopFront() *Element
+	PopFront() *Element[T]
 
 	// PushBack adds the given items at the end of the list.
-	PushBack(items ...int)
+	PushBack(items ...T)
 
 	// PopBack removes and returns the last element from the list. If the list is empty, it returns
 	// nil.
-	PopBack() *Element
+	PopBack() *Element[T]
 
 	// InsertAt adds the item as the (i+1)th element and returns the element. Negative indices can
 	// also be used to insert after the (-i)th last element. If the list doesn't have enough
 	// elements, it returns nil.
-	InsertAt(i int, item int) *Element
+	InsertAt(i int, item T) *Element[T]
 
 	// InsertAfter adds the item after the given element and returns the inserted element. If
-	// inserting after e is not possible, it returns nil.
-	InsertAfter(e *Element, item int) *Element
+	// inserting after e is not possible, including when e doesn't belong to the list, it returns
+	// nil.
+	InsertAfter(e *Element[T], item T) *Element[T]
 
 	// InsertBefore adds the item before the given element and returns the inserted element. If
-	// inserting after e is not possible, it returns nil.
-	InsertBefore(e *Element, item int) *Element
+	// inserting before e is not possible, including when e doesn't belong to the list, it returns
+	// nil.
+	InsertBefore(e *Element[T], item T) *Element[T]
 
 	// RemoveAt removes the (i+1)th element. Negative indices can also be used to remove the (-i)th
 	// last element. If the list doesn't have enough elements, it returns nil.
-	RemoveAt(i int) *Element
+	RemoveAt(i int) *Element[T]
 
-	// Remove removes and returns the given element. If removing e is not possible, it returns nil.
-	Remove(e *Element) *Element
+	// Remove removes and returns the given element. If removing e is not possible, including when
+	// e doesn't belong to the list, it returns nil.
+	Remove(e *Element[T]) *Element[T]
 
 	// RemoveAfter removes and returns the element after the given element. If removing after e is
-	// not possible, it returns nil.
-	RemoveAfter(e *Element) *Element
+	// not possible, including when e doesn't belong to the list, it returns nil.
+	RemoveAfter(e *Element[T]) *Element[T]
 
 	// RemoveBefore removes and returns the element before the given element. If removing before e
-	// is not possible, it returns nil.
-	RemoveBefore(e *Element) *Element
+	// is not possible, including when e doesn't belong to the list, it returns nil.
+	RemoveBefore(e *Element[T]) *Element[T]
 
 	// DeleteFirst deletes the first occurrence of the given items from the list. If the same item
 	// is passed twice as an argument, only one occurrence is deleted in total.
-	DeleteFirst(items ...int)
+	DeleteFirst(items ...T)
 
 	// Delete deletes the all occurrences of the given items from the list.
-	Delete(items ...int)
+	Delete(items ...T)
+
+	// MoveToFront moves the given element to the start of the list. It returns false if e doesn't
+	// belong to the list.
+	MoveToFront(e *Element[T]) bool
+
+	// MoveToBack moves the given element to the end of the list. It returns false if e doesn't
+	// belong to the list.
+	MoveToBack(e *Element[T]) bool
+
+	// MoveAfter moves the given element after mark. It returns false if e or mark doesn't belong to
+	// the list. If e and mark are the same element, the list is not modified.
+	MoveAfter(e, mark *Element[T]) bool
+
+	// MoveBefore moves the given element before mark. It returns false if e or mark doesn't belong
+	// to the list. If e and mark are the same element, the list is not modified.
+	MoveBefore(e, mark *Element[T]) bool
+
+	// PushBackList moves all the elements of other to the end of the list, leaving other empty. If
+	// other has the same type as the list, its elements are spliced in and keep their identity. If
+	// other is the list itself, the list is not modified.
+	PushBackList(other Interface[T])
+
+	// PushFrontList moves all the elements of other to the start of the list, in order, leaving
+	// other empty. If other has the same type as the list, its elements are spliced in and keep
+	// their identity. If other is the list itself, the list is not modified.
+	PushFrontList(other Interface[T])
+
+	// Reverse reverses the order of the elements of the list in place.
+	Reverse()
+
+	// Rotate rotates the list by k positions, moving its last k elements to its start. Negative
+	// values of k rotate the list in the other direction, moving its first -k elements to its end.
+	// Rotating by a multiple of the length of the list doesn't modify it.
+	Rotate(k int)
+
+	// SplitAt removes the elements from the (i+1)th element onwards and returns them as a new list
+	// of the same type. Negative indices can also be used to split off the last -i elements. If the
+	// list doesn't have enough elements, it returns nil.
+	SplitAt(i int) Interface[T]
+
+	// Sort sorts the list in place in ascending order as determined by less, using a bottom-up
+	// merge sort in O(n*log(n)) time. The sort is stable. The elements are relinked rather than
+	// copied, so they keep their identity.
+	Sort(less func(a, b T) bool)
 
 	// Copy creates a new copy of the list.
-	Copy() Interface
+	Copy() Interface[T]
 }
 
-// Element is a single list element.
-type Element struct {
-	Value int
-	Next  *Element
-	Prev  *Element
+// Element is a single list element. An element belongs to the list it was inserted into until it
+// is removed from it. Operations of a list that take an element reject elements that don't belong
+// to that list, so that elements of one list can't corrupt another.
+//
+// Next and Prev should be treated as read-only. Use the methods of the list to change them.
+type Element[T any] struct {
+	Value T
+	Next  *Element[T]
+	Prev  *Element[T]
+
+	// list is the list the element belongs to, or nil if it has been removed. For an
+	// UnrolledLinkedList, it is the node of the list holding the element.
+	list any
+
+	// levels are the links of the element above the first level when it belongs to a SkipList. It
+	// is a pointer so that the elements of the other lists only pay for a nil pointer.
+	levels *skipLevels[T]
+}
+
+// owner returns the list the element belongs to, or nil if it has been removed.
+func (e *Element[T]) owner() any {
+	if n, ok := e.list.(*unrolledNode[T]); ok {
+		return n.list
+	}
+
+	return e.list
 }
 
 // Iterable is the interface that groups the Next and Value methods used to iterate over a
 // list.Interface.
-type Iterable interface {
+type Iterable[T any] interface {
 	// Next prepares the next item for reading with the Value method. It returns true on success,
 	// or false if there are no items left, after which the Value method would always return the
 	// zero value.
@@ -101,5 +180,34 @@ type Iterable interface {
 	Next() bool
 
 	// Value reads and returns the item prepared by the Next method.
-	Value() int
+	Value() T
+}
+
+// after returns the element after e in l, or nil if e is the last element. Unlike e.Next, it also
+// stops at the end of a CircularDoublyLinkedList, where the last element links back to the first.
+func after[T comparable](l Interface[T], e *Element[T]) *Element[T] {
+	if e.Next == l.First() {
+		return nil
+	}
+
+	return e.Next
+}
+
+// drain removes all the items from l and returns them in order.
+func drain[T comparable](l Interface[T]) []T {
+	items := make([]T, 0, l.Len())
+	l.Each(func(item T) bool {
+		items = append(items, item)
+		return false
+	})
+
+	l.Clear()
+	return items
+}
+
+// reverseItems reverses the order of the given items in place.
+func reverseItems[T any](items []T) {
+	for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
+	}
 }
@@ -1,12 +1,13 @@
 package list_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/gpahal/go-algos/ds/list"
 )
 
-func testInterfaceHelper(t *testing.T, newFn func(...int) list.Interface) {
+func testInterfaceHelper(t *testing.T, newFn func(...int) list.Interface[int]) {
 	t.Run("New", func(t *testing.T) {
 		newList := newFn(4, 5, 6)
 		if newList.Len() != 3 {
@@ -175,6 +176,56 @@ func testInterfaceHelper(t *testing.T, newFn func(...int) list.Interface) {
 		}
 	})
 
+	t.Run("All", func(t *testing.T) {
+		newList := newFn(4, 5, 6, 7)
+		got := slices.Collect(newList.All())
+		if !slicesEqual(got, []int{4, 5, 6, 7}) {
+			t.Errorf("All: expected values to be [4 5 6 7], got %v", got)
+		}
+
+		got = nil
+		for item := range newList.All() {
+			if item%2 == 0 {
+				newList.DeleteFirst(item)
+			}
+			if item == 6 {
+				break
+			}
+
+			got = append(got, item)
+		}
+		if !slicesEqual(got, []int{4, 5}) {
+			t.Errorf("All: expected visited values to be [4 5], got %v", got)
+		}
+
+		assertListValues(t, "All", newList, []int{5, 7})
+	})
+
+	t.Run("Backward", func(t *testing.T) {
+		newList := newFn(4, 5, 6, 7)
+		got := slices.Collect(newList.Backward())
+		if !slicesEqual(got, []int{7, 6, 5, 4}) {
+			t.Errorf("Backward: expected values to be [7 6 5 4], got %v", got)
+		}
+
+		got = nil
+		for item := range newList.Backward() {
+			if item%2 == 1 {
+				newList.DeleteFirst(item)
+			}
+			if item == 5 {
+				break
+			}
+
+			got = append(got, item)
+		}
+		if !slicesEqual(got, []int{7, 6}) {
+			t.Errorf("Backward: expected visited values to be [7 6], got %v", got)
+		}
+
+		assertListValues(t, "Backward", newList, []int{4, 6})
+	})
+
 	t.Run("PushFront", func(t *testing.T) {
 		newList := newFn(7)
 		newList.PushFront(4, 5, 6)
@@ -220,6 +271,13 @@ func testInterfaceHelper(t *testing.T, newFn func(...int) list.Interface) {
 		}
 
 		assertListValues(t, "PopBack", newList, []int{4, 5, 6})
+		newList = newFn(4)
+		el = newList.PopBack()
+		if el == nil || el.Value != 4 {
+			t.Errorf("PopBack: expected PopBack to return Element with Value 4, got %v", el)
+		}
+
+		assertListValues(t, "PopBack", newList, nil)
 		newList.Clear()
 		el = newList.PopBack()
 		if el != nil {
@@ -244,6 +302,13 @@ func testInterfaceHelper(t *testing.T, newFn func(...int) list.Interface) {
 		if el != nil {
 			t.Errorf("InsertAt 7, 5: expected InsertAt to return nil, got %v", el)
 		}
+
+		el = newList.InsertAt(0, 3)
+		if el == nil || el.Value != 3 {
+			t.Errorf("InsertAt 0, 3: expected InsertAt to return Element with Value 3, got %v", el)
+		}
+
+		assertListValues(t, "InsertAt", newList, []int{3, 4, 5, 6, 5, 8})
 	})
 
 	t.Run("InsertAfter", func(t *testing.T) {
@@ -404,6 +469,203 @@ func testInterfaceHelper(t *testing.T, newFn func(...int) list.Interface) {
 		assertListValues(t, "DeleteFirst", newList, []int{6, 7})
 	})
 
+	t.Run("MoveToFront", func(t *testing.T) {
+		newList := newFn(4, 5, 6)
+		if !newList.MoveToFront(newList.Last()) {
+			t.Error("MoveToFront: expected MoveToFront to return true, got false")
+		}
+
+		assertListValues(t, "MoveToFront", newList, []int{6, 4, 5})
+		newList.MoveToFront(newList.First())
+		assertListValues(t, "MoveToFront", newList, []int{6, 4, 5})
+		newList.MoveToFront(newList.At(1))
+		assertListValues(t, "MoveToFront", newList, []int{4, 6, 5})
+		if newList.MoveToFront(newFn(7).First()) {
+			t.Error("MoveToFront: expected MoveToFront foreign Element to return false, got true")
+		}
+	})
+
+	t.Run("MoveToBack", func(t *testing.T) {
+		newList := newFn(4, 5, 6)
+		if !newList.MoveToBack(newList.First()) {
+			t.Error("MoveToBack: expected MoveToBack to return true, got false")
+		}
+
+		assertListValues(t, "MoveToBack", newList, []int{5, 6, 4})
+		newList.MoveToBack(newList.Last())
+		assertListValues(t, "MoveToBack", newList, []int{5, 6, 4})
+		newList.MoveToBack(newList.At(1))
+		assertListValues(t, "MoveToBack", newList, []int{5, 4, 6})
+		newList.PushBack(7)
+		assertListValues(t, "MoveToBack", newList, []int{5, 4, 6, 7})
+		if newList.MoveToBack(newFn(7).First()) {
+			t.Error("MoveToBack: expected MoveToBack foreign Element to return false, got true")
+		}
+	})
+
+	t.Run("MoveAfter", func(t *testing.T) {
+		newList := newFn(4, 5, 6, 7)
+		if !newList.MoveAfter(newList.First(), newList.At(2)) {
+			t.Error("MoveAfter: expected MoveAfter to return true, got false")
+		}
+
+		assertListValues(t, "MoveAfter", newList, []int{5, 6, 4, 7})
+		newList.MoveAfter(newList.At(2), newList.Last())
+		assertListValues(t, "MoveAfter", newList, []int{5, 6, 7, 4})
+		newList.MoveAfter(newList.Last(), newList.First())
+		assertListValues(t, "MoveAfter", newList, []int{5, 4, 6, 7})
+		newList.MoveAfter(newList.At(1), newList.At(1))
+		newList.MoveAfter(newList.At(1), newList.First())
+		assertListValues(t, "MoveAfter", newList, []int{5, 4, 6, 7})
+		if newList.MoveAfter(newList.First(), newFn(7).First()) {
+			t.Error("MoveAfter: expected MoveAfter foreign mark to return false, got true")
+		}
+	})
+
+	t.Run("MoveBefore", func(t *testing.T) {
+		newList := newFn(4, 5, 6, 7)
+		if !newList.MoveBefore(newList.Last(), newList.At(1)) {
+			t.Error("MoveBefore: expected MoveBefore to return true, got false")
+		}
+
+		assertListValues(t, "MoveBefore", newList, []int{4, 7, 5, 6})
+		newList.MoveBefore(newList.At(2), newList.First())
+		assertListValues(t, "MoveBefore", newList, []int{5, 4, 7, 6})
+		newList.MoveBefore(newList.First(), newList.Last())
+		assertListValues(t, "MoveBefore", newList, []int{4, 7, 5, 6})
+		newList.MoveBefore(newList.At(1), newList.At(1))
+		newList.MoveBefore(newList.First(), newList.At(1))
+		assertListValues(t, "MoveBefore", newList, []int{4, 7, 5, 6})
+		if newList.MoveBefore(newFn(7).First(), newList.First()) {
+			t.Error("MoveBefore: expected MoveBefore foreign Element to return false, got true")
+		}
+	})
+
+	t.Run("PushBackList", func(t *testing.T) {
+		newList := newFn(4, 5)
+		otherList := newFn(6, 7)
+		otherEl := otherList.First()
+		newList.PushBackList(otherList)
+		assertListValues(t, "PushBackList", newList, []int{4, 5, 6, 7})
+		assertListValues(t, "PushBackList", otherList, nil)
+		if newList.At(2) != otherEl {
+			t.Errorf("PushBackList: expected At 2 to return the spliced Element, got %v", newList.At(2))
+		}
+
+		newList.Remove(otherEl)
+		assertListValues(t, "PushBackList", newList, []int{4, 5, 7})
+		newList.PushBackList(newList)
+		assertListValues(t, "PushBackList", newList, []int{4, 5, 7})
+		otherList.PushBackList(newList)
+		assertListValues(t, "PushBackList", otherList, []int{4, 5, 7})
+		assertListValues(t, "PushBackList", newList, nil)
+	})
+
+	t.Run("PushFrontList", func(t *testing.T) {
+		newList := newFn(6, 7)
+		otherList := newFn(4, 5)
+		otherEl := otherList.Last()
+		newList.PushFrontList(otherList)
+		assertListValues(t, "PushFrontList", newList, []int{4, 5, 6, 7})
+		assertListValues(t, "PushFrontList", otherList, nil)
+		if newList.At(1) != otherEl {
+			t.Errorf("PushFrontList: expected At 1 to return the spliced Element, got %v", newList.At(1))
+		}
+
+		newList.Remove(otherEl)
+		assertListValues(t, "PushFrontList", newList, []int{4, 6, 7})
+		newList.PushFrontList(newList)
+		assertListValues(t, "PushFrontList", newList, []int{4, 6, 7})
+		otherList.PushFrontList(newList)
+		assertListValues(t, "PushFrontList", otherList, []int{4, 6, 7})
+		assertListValues(t, "PushFrontList", newList, nil)
+	})
+
+	t.Run("Reverse", func(t *testing.T) {
+		newList := newFn(4, 5, 6, 7)
+		newList.Reverse()
+		assertListValues(t, "Reverse", newList, []int{7, 6, 5, 4})
+		newList.PushBack(3)
+		newList.PushFront(8)
+		assertListValues(t, "Reverse", newList, []int{8, 7, 6, 5, 4, 3})
+
+		newList = newFn()
+		newList.Reverse()
+		assertListValues(t, "Reverse", newList, nil)
+	})
+
+	t.Run("Rotate", func(t *testing.T) {
+		newList := newFn(4, 5, 6, 7)
+		newList.Rotate(1)
+		assertListValues(t, "Rotate 1", newList, []int{7, 4, 5, 6})
+		newList.Rotate(-2)
+		assertListValues(t, "Rotate -2", newList, []int{5, 6, 7, 4})
+		newList.Rotate(7)
+		assertListValues(t, "Rotate 7", newList, []int{6, 7, 4, 5})
+		newList.Rotate(-8)
+		assertListValues(t, "Rotate -8", newList, []int{6, 7, 4, 5})
+		newList.PushBack(3)
+		assertListValues(t, "Rotate", newList, []int{6, 7, 4, 5, 3})
+
+		newList = newFn()
+		newList.Rotate(3)
+		assertListValues(t, "Rotate 3", newList, nil)
+	})
+
+	t.Run("SplitAt", func(t *testing.T) {
+		newList := newFn(4, 5, 6, 7)
+		splitList := newList.SplitAt(1)
+		assertListValues(t, "SplitAt 1", newList, []int{4})
+		assertListValues(t, "SplitAt 1", splitList, []int{5, 6, 7})
+
+		splitList2 := splitList.SplitAt(-1)
+		assertListValues(t, "SplitAt -1", splitList, []int{5, 6})
+		assertListValues(t, "SplitAt -1", splitList2, []int{7})
+
+		splitList3 := splitList.SplitAt(0)
+		assertListValues(t, "SplitAt 0", splitList, nil)
+		assertListValues(t, "SplitAt 0", splitList3, []int{5, 6})
+		if el := newList.Remove(splitList3.First()); el != nil {
+			t.Errorf("SplitAt: expected Remove of split Element to return nil, got %v", el)
+		}
+
+		splitList4 := splitList3.SplitAt(2)
+		assertListValues(t, "SplitAt 2", splitList3, []int{5, 6})
+		assertListValues(t, "SplitAt 2", splitList4, nil)
+		if splitList3.SplitAt(3) != nil || splitList3.SplitAt(-3) != nil {
+			t.Error("SplitAt: expected SplitAt out of range to return nil, got non-nil")
+		}
+
+		splitList3.PushBack(8)
+		splitList4.PushBack(9)
+		assertListValues(t, "SplitAt", splitList3, []int{5, 6, 8})
+		assertListValues(t, "SplitAt", splitList4, []int{9})
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		less := func(a, b int) bool { return a < b }
+		newList := newFn(6, 3, 8, 1, 9, 2, 7, 3, 5)
+		firstEl := newList.First()
+		newList.Sort(less)
+		assertListValues(t, "Sort", newList, []int{1, 2, 3, 3, 5, 6, 7, 8, 9})
+		if newList.At(5) != firstEl {
+			t.Errorf("Sort: expected At 5 to return the original first Element, got %v", newList.At(5))
+		}
+
+		newList.Sort(func(a, b int) bool { return a > b })
+		assertListValues(t, "Sort", newList, []int{9, 8, 7, 6, 5, 3, 3, 2, 1})
+		newList.PushBack(4)
+		newList.Remove(firstEl)
+		assertListValues(t, "Sort", newList, []int{9, 8, 7, 5, 3, 3, 2, 1, 4})
+
+		newList = newFn()
+		newList.Sort(less)
+		assertListValues(t, "Sort", newList, nil)
+		newList.PushBack(1)
+		newList.Sort(less)
+		assertListValues(t, "Sort", newList, []int{1})
+	})
+
 	t.Run("Copy", func(t *testing.T) {
 		newList := newFn(4, 5, 6)
 		copiedList := newList.Copy()
@@ -412,11 +674,65 @@ func testInterfaceHelper(t *testing.T, newFn func(...int) list.Interface) {
 		assertListValues(t, "Copy", copiedList, []int{4, 5, 6, 3})
 		assertListValues(t, "Copy", newList, []int{4, 5, 6})
 	})
+
+	t.Run("Ownership", func(t *testing.T) {
+		newList := newFn(4, 5, 6)
+		otherList := newFn(7, 8)
+		foreignEl := otherList.First()
+		if el := newList.Remove(foreignEl); el != nil {
+			t.Errorf("Ownership: expected Remove of foreign Element to return nil, got %v", el)
+		}
+		if el := newList.InsertAfter(foreignEl, 1); el != nil {
+			t.Errorf("Ownership: expected InsertAfter foreign Element to return nil, got %v", el)
+		}
+		if el := newList.InsertBefore(foreignEl, 1); el != nil {
+			t.Errorf("Ownership: expected InsertBefore foreign Element to return nil, got %v", el)
+		}
+		if el := newList.RemoveAfter(foreignEl); el != nil {
+			t.Errorf("Ownership: expected RemoveAfter foreign Element to return nil, got %v", el)
+		}
+		if el := newList.RemoveBefore(otherList.Last()); el != nil {
+			t.Errorf("Ownership: expected RemoveBefore foreign Element to return nil, got %v", el)
+		}
+
+		assertListValues(t, "Ownership", newList, []int{4, 5, 6})
+		assertListValues(t, "Ownership", otherList, []int{7, 8})
+
+		removedEl := newList.Remove(newList.At(1))
+		if removedEl == nil || removedEl.Value != 5 {
+			t.Errorf("Ownership: expected Remove to return Element with Value 5, got %v", removedEl)
+		}
+		if el := newList.Remove(removedEl); el != nil {
+			t.Errorf("Ownership: expected Remove of removed Element to return nil, got %v", el)
+		}
+		if el := newList.InsertAfter(removedEl, 1); el != nil {
+			t.Errorf("Ownership: expected InsertAfter removed Element to return nil, got %v", el)
+		}
+
+		assertListValues(t, "Ownership", newList, []int{4, 6})
+
+		clearedEl := otherList.First()
+		otherList.Clear()
+		if el := otherList.InsertAfter(clearedEl, 1); el != nil {
+			t.Errorf("Ownership: expected InsertAfter cleared Element to return nil, got %v", el)
+		}
+
+		assertListValues(t, "Ownership", otherList, nil)
+	})
 }
 
-func assertListValues(t *testing.T, name string, l list.Interface, expected []int) {
+func assertListValues(t *testing.T, name string, l list.Interface[int], expected []int) {
 	t.Helper()
 
+	if v, ok := l.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			t.Errorf("%s: expected Validate to return nil, got %v", name, err)
+		}
+	}
+	if l.Len() != len(expected) {
+		t.Errorf("%s: expected Len to be %d, got %d", name, len(expected), l.Len())
+	}
+
 	if l.Empty() && len(expected) != 0 {
 		t.Errorf("%s: expected List values to be %v, got []", name, expected)
 	}
@@ -445,3 +761,42 @@ func slicesEqual(arr1, arr2 []int) bool {
 
 	return true
 }
+
+func testInterfaceOfHelper(t *testing.T, newFn func(...string) list.Interface[string]) {
+	newList := newFn("b", "c")
+	newList.PushFront("a")
+	newList.PushBack("d", "a")
+	if !newList.Contains("a", "d") {
+		t.Errorf("Contains a, d: expected Contains to return true, got false")
+	}
+
+	newList.DeleteFirst("a")
+	el := newList.First()
+	if el == nil || el.Value != "b" {
+		t.Errorf("DeleteFirst a: expected First to return Element with Value b, got %v", el)
+	}
+
+	newList.Delete("a", "c")
+	var got []string
+	for it := newList.Iterator(); it.Next(); {
+		got = append(got, it.Value())
+	}
+	if len(got) != 2 || got[0] != "b" || got[1] != "d" {
+		t.Errorf("Delete a, c: expected List values to be [b d], got %v", got)
+	}
+
+	newList = newFn("bb", "c", "aa", "d", "ab", "b")
+	newList.Sort(func(a, b string) bool { return len(a) < len(b) })
+	got = nil
+	newList.Each(func(item string) bool {
+		got = append(got, item)
+		return false
+	})
+	expected := []string{"c", "d", "b", "bb", "aa", "ab"}
+	for i := range expected {
+		if len(got) != len(expected) || got[i] != expected[i] {
+			t.Errorf("Sort: expected a stable sort to give %v, got %v", expected, got)
+			break
+		}
+	}
+}
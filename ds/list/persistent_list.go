@@ -0,0 +1,119 @@
+package list
+
+import "iter"
+
+// PersistentList represents an immutable singly linked list. Operations that modify the list
+// return a new version of it and leave the original unchanged. Versions share the elements they
+// have in common, so PushFront and PopFront take O(1) time and space.
+//
+// As no version is ever modified, a list can be shared between goroutines and kept as a snapshot
+// without copying it. The zero value is an empty list.
+type PersistentList[T any] struct {
+	head *persistentCell[T]
+	len  int
+}
+
+type persistentCell[T any] struct {
+	value T
+	next  *persistentCell[T]
+}
+
+// NewPersistentList returns a new persistent list of ints with the given items in order.
+func NewPersistentList(items ...int) PersistentList[int] {
+	return NewPersistentListOf(items...)
+}
+
+// NewPersistentListOf returns a new persistent list with the given items in order.
+func NewPersistentListOf[T any](items ...T) PersistentList[T] {
+	var l PersistentList[T]
+	for i := len(items) - 1; i >= 0; i-- {
+		l = l.PushFront(items[i])
+	}
+
+	return l
+}
+
+// Len returns the number of items in the list.
+func (l PersistentList[T]) Len() int {
+	return l.len
+}
+
+// Empty checks whether the list is empty.
+func (l PersistentList[T]) Empty() bool {
+	return l.head == nil
+}
+
+// First returns the first item of the list. If the list is empty, the second return value is
+// false.
+func (l PersistentList[T]) First() (T, bool) {
+	if l.head == nil {
+		var zero T
+		return zero, false
+	}
+
+	return l.head.value, true
+}
+
+// Rest returns the list without its first item. If the list is empty, it returns the empty list.
+func (l PersistentList[T]) Rest() PersistentList[T] {
+	if l.head == nil {
+		return l
+	}
+
+	return PersistentList[T]{head: l.head.next, len: l.len - 1}
+}
+
+// At returns the (i+1)th item of the list in O(i) time. If the list doesn't have enough items, the
+// second return value is false.
+func (l PersistentList[T]) At(i int) (T, bool) {
+	if i < 0 || i >= l.len {
+		var zero T
+		return zero, false
+	}
+
+	curr := l.head
+	for ; i > 0; i-- {
+		curr = curr.next
+	}
+
+	return curr.value, true
+}
+
+// PushFront returns a new list with the given items added at the start of the list, one after the
+// other, so the last item ends up first. The list itself is not modified.
+func (l PersistentList[T]) PushFront(items ...T) PersistentList[T] {
+	for _, item := range items {
+		l = PersistentList[T]{head: &persistentCell[T]{value: item, next: l.head}, len: l.len + 1}
+	}
+
+	return l
+}
+
+// PopFront returns the list without its first item, and the first item. If the list is empty, the
+// last return value is false. The list itself is not modified.
+func (l PersistentList[T]) PopFront() (PersistentList[T], T, bool) {
+	item, ok := l.First()
+	return l.Rest(), item, ok
+}
+
+// Reverse returns a new list with the items of the list in reverse order. It takes O(n) time, and
+// the new list shares no elements with the list.
+func (l PersistentList[T]) Reverse() PersistentList[T] {
+	var reversed PersistentList[T]
+	for curr := l.head; curr != nil; curr = curr.next {
+		reversed = reversed.PushFront(curr.value)
+	}
+
+	return reversed
+}
+
+// All returns an iterator over the items of the list from the first to the last.
+func (l PersistentList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for curr := l.head; curr != nil; curr = curr.next {
+			if !yield(curr.value) {
+				return
+			}
+		}
+	}
+}
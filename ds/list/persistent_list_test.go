@@ -0,0 +1,51 @@
+package list_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/list"
+)
+
+func TestPersistentList(t *testing.T) {
+	var empty list.PersistentList[int]
+	if !empty.Empty() || empty.Len() != 0 {
+		t.Errorf("Empty: expected the zero value to be empty, got Len %d", empty.Len())
+	}
+	if _, ok := empty.First(); ok {
+		t.Error("First: expected First of an empty list to return false, got true")
+	}
+	if rest := empty.Rest(); !rest.Empty() {
+		t.Errorf("Rest: expected Rest of an empty list to be empty, got Len %d", rest.Len())
+	}
+
+	l1 := list.NewPersistentList(4, 5, 6)
+	l2 := l1.PushFront(3, 2)
+	l3, item, ok := l1.PopFront()
+	if !ok || item != 4 {
+		t.Errorf("PopFront: expected PopFront to return 4, true, got %d, %v", item, ok)
+	}
+
+	assertPersistentListValues(t, "New", l1, []int{4, 5, 6})
+	assertPersistentListValues(t, "PushFront", l2, []int{2, 3, 4, 5, 6})
+	assertPersistentListValues(t, "PopFront", l3, []int{5, 6})
+	assertPersistentListValues(t, "Reverse", l2.Reverse(), []int{6, 5, 4, 3, 2})
+
+	if v, ok := l2.At(3); !ok || v != 5 {
+		t.Errorf("At 3: expected At to return 5, true, got %d, %v", v, ok)
+	}
+	if _, ok := l2.At(5); ok {
+		t.Error("At 5: expected At to return false, got true")
+	}
+}
+
+func assertPersistentListValues(t *testing.T, name string, l list.PersistentList[int], expected []int) {
+	t.Helper()
+
+	if l.Len() != len(expected) {
+		t.Errorf("%s: expected Len to be %d, got %d", name, len(expected), l.Len())
+	}
+	if got := slices.Collect(l.All()); !slicesEqual(got, expected) {
+		t.Errorf("%s: expected List values to be %v, got %v", name, expected, got)
+	}
+}
@@ -1,15 +1,29 @@
 package list
 
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
 // SinglyLinkedList represents a list instance implemented as a singly linked list. The Prev field
-// of every is nil.
-type SinglyLinkedList struct {
-	head *Element
+// of every element is nil.
+type SinglyLinkedList[T comparable] struct {
+	head *Element[T]
+	tail *Element[T]
+	len  int
+}
+
+// NewSinglyLinkedList returns a new singly linked list instance of ints with the given items
+// inserted in order.
+func NewSinglyLinkedList(items ...int) Interface[int] {
+	return NewSinglyLinkedListOf(items...)
 }
 
-// NewSinglyLinkedList returns a new singly linked list instance with the given items inserted in
+// NewSinglyLinkedListOf returns a new singly linked list instance with the given items inserted in
 // order.
-func NewSinglyLinkedList(items ...int) Interface {
-	newList := &SinglyLinkedList{}
+func NewSinglyLinkedListOf[T comparable](items ...T) Interface[T] {
+	newList := &SinglyLinkedList[T]{}
 	for i := len(items) - 1; i >= 0; i-- {
 		newList.PushFront(items[i])
 	}
@@ -17,54 +31,45 @@ func NewSinglyLinkedList(items ...int) Interface {
 }
 
 // Len returns the number of items in the list.
-func (sll *SinglyLinkedList) Len() int {
-	if sll.head == nil {
-		return 0
-	}
-
-	count := 1
-	for curr := sll.head; curr.Next != nil; curr = curr.Next {
-		count++
-	}
-
-	return count
+func (sll *SinglyLinkedList[T]) Len() int {
+	return sll.len
 }
 
 // Empty checks whether the list is empty.
-func (sll *SinglyLinkedList) Empty() bool {
+func (sll *SinglyLinkedList[T]) Empty() bool {
 	return sll.head == nil
 }
 
 // Clear deletes all the items from the list.
-func (sll *SinglyLinkedList) Clear() {
+func (sll *SinglyLinkedList[T]) Clear() {
+	var next *Element[T]
+	for curr := sll.head; curr != nil; curr = next {
+		next = curr.Next
+		curr.Next, curr.list = nil, nil
+	}
+
 	sll.head = nil
+	sll.tail = nil
+	sll.len = 0
 }
 
 // First returns the first element of the list.
-func (sll *SinglyLinkedList) First() *Element {
+func (sll *SinglyLinkedList[T]) First() *Element[T] {
 	return sll.head
 }
 
 // Last returns the last element of the list.
-func (sll *SinglyLinkedList) Last() *Element {
-	if sll.head == nil {
-		return nil
-	}
-
-	var curr *Element
-	for curr = sll.head; curr.Next != nil; curr = curr.Next {
-	}
-
-	return curr
+func (sll *SinglyLinkedList[T]) Last() *Element[T] {
+	return sll.tail
 }
 
 // secondLast returns the second last element of the list.
-func (sll *SinglyLinkedList) secondLast() *Element {
+func (sll *SinglyLinkedList[T]) secondLast() *Element[T] {
 	if sll.head == nil || sll.head.Next == nil {
 		return nil
 	}
 
-	var curr *Element
+	var curr *Element[T]
 	for curr = sll.head; curr.Next != nil && curr.Next.Next != nil; curr = curr.Next {
 	}
 
@@ -73,7 +78,7 @@ func (sll *SinglyLinkedList) secondLast() *Element {
 
 // At returns the (i+1)th element of the list. Negative indices can also be used to find the (-i)th
 // last element.
-func (sll *SinglyLinkedList) At(i int) *Element {
+func (sll *SinglyLinkedList[T]) At(i int) *Element[T] {
 	if sll.head == nil {
 		return nil
 	}
@@ -103,7 +108,7 @@ func (sll *SinglyLinkedList) At(i int) *Element {
 }
 
 // Contains checks whether the list contains all the given items.
-func (sll *SinglyLinkedList) Contains(items ...int) bool {
+func (sll *SinglyLinkedList[T]) Contains(items ...T) bool {
 	if len(items) == 0 {
 		return true
 	}
@@ -111,7 +116,7 @@ func (sll *SinglyLinkedList) Contains(items ...int) bool {
 		return false
 	}
 
-	itemsMap := make(map[int]struct{}, len(items))
+	itemsMap := make(map[T]struct{}, len(items))
 	for _, item := range items {
 		itemsMap[item] = struct{}{}
 	}
@@ -126,7 +131,7 @@ func (sll *SinglyLinkedList) Contains(items ...int) bool {
 }
 
 // Each iterates over the items of the list.
-func (sll *SinglyLinkedList) Each(fn func(int) bool) {
+func (sll *SinglyLinkedList[T]) Each(fn func(T) bool) {
 	if sll.head == nil {
 		return
 	}
@@ -139,101 +144,138 @@ func (sll *SinglyLinkedList) Each(fn func(int) bool) {
 }
 
 // Iterator returns a list.Iterable that can be used to iterate over the list.
-func (sll *SinglyLinkedList) Iterator() Iterable {
-	return &singlyLinkedListIterable{
+func (sll *SinglyLinkedList[T]) Iterator() Iterable[T] {
+	return &singlyLinkedListIterable[T]{
 		curr: sll.head,
 	}
 }
 
+// All returns an iterator over the items of the list from the first to the last. The current
+// element can be removed during iteration.
+func (sll *SinglyLinkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var next *Element[T]
+		for curr := sll.head; curr != nil; curr = next {
+			next = curr.Next
+			if !yield(curr.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the list from the last to the first. The current
+// element can be removed during iteration.
+//
+// As the list has no Prev links, the elements are collected before iterating, which takes O(n)
+// memory.
+func (sll *SinglyLinkedList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		elements := make([]*Element[T], 0, sll.len)
+		for curr := sll.head; curr != nil; curr = curr.Next {
+			elements = append(elements, curr)
+		}
+
+		for i := len(elements) - 1; i >= 0; i-- {
+			if !yield(elements[i].Value) {
+				return
+			}
+		}
+	}
+}
+
 // PushFront adds the given items at the start of the list.
-func (sll *SinglyLinkedList) PushFront(items ...int) {
+func (sll *SinglyLinkedList[T]) PushFront(items ...T) {
 	if len(items) == 0 {
 		return
 	}
 
-	var curr *Element
+	var curr *Element[T]
 	for _, item := range items {
 		if curr == nil {
-			curr = &Element{
+			curr = &Element[T]{
 				Value: item,
 				Next:  sll.head,
+				list:  sll,
+			}
+
+			if sll.tail == nil {
+				sll.tail = curr
 			}
 		} else {
-			curr = &Element{
+			curr = &Element[T]{
 				Value: item,
 				Next:  curr,
+				list:  sll,
 			}
 		}
 	}
 
 	sll.head = curr
+	sll.len += len(items)
 }
 
 // PopFront removes and returns the first element from the list. If the list is empty, it returns
 // nil.
-func (sll *SinglyLinkedList) PopFront() *Element {
+func (sll *SinglyLinkedList[T]) PopFront() *Element[T] {
 	if sll.head == nil {
 		return nil
 	}
 
 	removedEl := sll.head
 	sll.head = sll.head.Next
+	if sll.head == nil {
+		sll.tail = nil
+	}
+
 	removedEl.Next = nil
+	removedEl.list = nil
+	sll.len--
 	return removedEl
 }
 
 // PushBack adds the given items at the end of the list.
-func (sll *SinglyLinkedList) PushBack(items ...int) {
+func (sll *SinglyLinkedList[T]) PushBack(items ...T) {
 	if len(items) == 0 {
 		return
 	}
 
-	var curr *Element
-	for i := len(items) - 1; i >= 0; i-- {
-		item := items[i]
-		if curr == nil {
-			curr = &Element{
-				Value: item,
-			}
+	for _, item := range items {
+		newEl := &Element[T]{
+			Value: item,
+			list:  sll,
+		}
+
+		if sll.tail == nil {
+			sll.head = newEl
 		} else {
-			curr = &Element{
-				Value: item,
-				Next:  curr,
-			}
+			sll.tail.Next = newEl
 		}
+		sll.tail = newEl
 	}
 
-	tail := sll.Last()
-	if tail == nil {
-		sll.head = curr
-	} else {
-		tail.Next = curr
-	}
+	sll.len += len(items)
 }
 
 // PopBack removes and returns the last element from the list. If the list is empty, it returns
 // nil.
-func (sll *SinglyLinkedList) PopBack() *Element {
+func (sll *SinglyLinkedList[T]) PopBack() *Element[T] {
 	if sll.head == nil {
 		return nil
 	}
 
-	var tail *Element
 	preTail := sll.secondLast()
 	if preTail == nil {
-		tail = sll.head
-	} else {
-		tail = preTail.Next
+		return sll.PopFront()
 	}
 
-	preTail.Next = nil
-	return tail
+	return sll.RemoveAfter(preTail)
 }
 
 // InsertAt adds the item as the (i+1)th element and returns the element. Negative indices can also
 // be used to insert after the (-i)th last element. If the list doesn't have enough elements, it
 // returns nil.
-func (sll *SinglyLinkedList) InsertAt(i int, item int) *Element {
+func (sll *SinglyLinkedList[T]) InsertAt(i int, item T) *Element[T] {
 	if i < 0 {
 		if i == -1 {
 			return sll.InsertAfter(sll.Last(), item)
@@ -255,23 +297,29 @@ func (sll *SinglyLinkedList) InsertAt(i int, item int) *Element {
 }
 
 // InsertAfter adds the item after the given element and returns the inserted element. If inserting
-// after e is not possible, it returns nil.
-func (sll *SinglyLinkedList) InsertAfter(e *Element, item int) *Element {
-	if e == nil {
+// after e is not possible, including when e doesn't belong to the list, it returns nil.
+func (sll *SinglyLinkedList[T]) InsertAfter(e *Element[T], item T) *Element[T] {
+	if !sll.owns(e) {
 		return nil
 	}
 
-	e.Next = &Element{
+	e.Next = &Element[T]{
 		Value: item,
 		Next:  e.Next,
+		list:  sll,
+	}
+	if e == sll.tail {
+		sll.tail = e.Next
 	}
+
+	sll.len++
 	return e.Next
 }
 
 // InsertBefore adds the item before the given element and returns the inserted element. If
-// inserting after e is not possible, it returns nil.
-func (sll *SinglyLinkedList) InsertBefore(e *Element, item int) *Element {
-	if e == nil || sll.head == nil {
+// inserting before e is not possible, including when e doesn't belong to the list, it returns nil.
+func (sll *SinglyLinkedList[T]) InsertBefore(e *Element[T], item T) *Element[T] {
+	if !sll.owns(e) {
 		return nil
 	}
 	if e == sll.head {
@@ -279,7 +327,7 @@ func (sll *SinglyLinkedList) InsertBefore(e *Element, item int) *Element {
 		return sll.head
 	}
 
-	var curr *Element
+	var curr *Element[T]
 	for curr = sll.head; curr.Next != nil && curr.Next != e; curr = curr.Next {
 	}
 
@@ -292,7 +340,7 @@ func (sll *SinglyLinkedList) InsertBefore(e *Element, item int) *Element {
 
 // RemoveAt removes the (i+1)th element. Negative indices can also be used to remove the (-i)th
 // last element. If the list doesn't have enough elements, it returns nil.
-func (sll *SinglyLinkedList) RemoveAt(i int) *Element {
+func (sll *SinglyLinkedList[T]) RemoveAt(i int) *Element[T] {
 	if i < 0 {
 		if i == -1 {
 			return sll.RemoveAfter(sll.secondLast())
@@ -310,16 +358,17 @@ func (sll *SinglyLinkedList) RemoveAt(i int) *Element {
 	return sll.RemoveAfter(sll.At(i - 1))
 }
 
-// Remove removes and returns the given element. If removing e is not possible, it returns nil.
-func (sll *SinglyLinkedList) Remove(e *Element) *Element {
-	if e == nil || sll.head == nil {
+// Remove removes and returns the given element. If removing e is not possible, including when e
+// doesn't belong to the list, it returns nil.
+func (sll *SinglyLinkedList[T]) Remove(e *Element[T]) *Element[T] {
+	if !sll.owns(e) {
 		return nil
 	}
 	if e == sll.head {
 		return sll.PopFront()
 	}
 
-	var curr *Element
+	var curr *Element[T]
 	for curr = sll.head; curr.Next != nil && curr.Next != e; curr = curr.Next {
 	}
 
@@ -331,29 +380,35 @@ func (sll *SinglyLinkedList) Remove(e *Element) *Element {
 }
 
 // RemoveAfter removes and returns the element after the given element. If removing after e is not
-// possible, it returns nil.
-func (sll *SinglyLinkedList) RemoveAfter(e *Element) *Element {
-	if e == nil || e.Next == nil {
+// possible, including when e doesn't belong to the list, it returns nil.
+func (sll *SinglyLinkedList[T]) RemoveAfter(e *Element[T]) *Element[T] {
+	if !sll.owns(e) || e.Next == nil {
 		return nil
 	}
 
 	removedEl := e.Next
 	e.Next = removedEl.Next
+	if removedEl == sll.tail {
+		sll.tail = e
+	}
+
 	removedEl.Next = nil
+	removedEl.list = nil
+	sll.len--
 	return removedEl
 }
 
 // RemoveBefore removes and returns the element before the given element. If removing before e is
-// not possible, it returns nil.
-func (sll *SinglyLinkedList) RemoveBefore(e *Element) *Element {
-	if e == nil || e == sll.head || sll.head == nil || sll.head.Next == nil {
+// not possible, including when e doesn't belong to the list, it returns nil.
+func (sll *SinglyLinkedList[T]) RemoveBefore(e *Element[T]) *Element[T] {
+	if !sll.owns(e) || e == sll.head {
 		return nil
 	}
 	if e == sll.head.Next {
 		return sll.PopFront()
 	}
 
-	var curr *Element
+	var curr *Element[T]
 	for curr = sll.head; curr.Next.Next != nil && curr.Next.Next != e; curr = curr.Next {
 	}
 
@@ -366,18 +421,18 @@ func (sll *SinglyLinkedList) RemoveBefore(e *Element) *Element {
 
 // DeleteFirst deletes the first occurrence of the given items from the list. If the same item is
 // passed twice as an argument, only one occurrence is deleted in total.
-func (sll *SinglyLinkedList) DeleteFirst(items ...int) {
+func (sll *SinglyLinkedList[T]) DeleteFirst(items ...T) {
 	if len(items) == 0 || sll.head == nil {
 		return
 	}
 
-	itemsMap := make(map[int]struct{}, len(items))
+	itemsMap := make(map[T]struct{}, len(items))
 	for _, item := range items {
 		itemsMap[item] = struct{}{}
 	}
 
-	var prev *Element
-	var next *Element
+	var prev *Element[T]
+	var next *Element[T]
 	for curr := sll.head; curr != nil; curr = next {
 		next = curr.Next
 		if _, ok := itemsMap[curr.Value]; ok {
@@ -398,18 +453,18 @@ func (sll *SinglyLinkedList) DeleteFirst(items ...int) {
 }
 
 // Delete deletes the all occurrences of the given items from the list.
-func (sll *SinglyLinkedList) Delete(items ...int) {
+func (sll *SinglyLinkedList[T]) Delete(items ...T) {
 	if len(items) == 0 || sll.head == nil {
 		return
 	}
 
-	itemsMap := make(map[int]struct{}, len(items))
+	itemsMap := make(map[T]struct{}, len(items))
 	for _, item := range items {
 		itemsMap[item] = struct{}{}
 	}
 
-	var prev *Element
-	var next *Element
+	var prev *Element[T]
+	var next *Element[T]
 	for curr := sll.head; curr != nil; curr = next {
 		next = curr.Next
 		if _, ok := itemsMap[curr.Value]; ok {
@@ -424,28 +479,337 @@ func (sll *SinglyLinkedList) Delete(items ...int) {
 	}
 }
 
+// MoveToFront moves the given element to the start of the list. It returns false if e doesn't
+// belong to the list.
+//
+// Finding the element before e takes O(n) time.
+func (sll *SinglyLinkedList[T]) MoveToFront(e *Element[T]) bool {
+	if !sll.owns(e) {
+		return false
+	}
+	if e != sll.head {
+		sll.unlink(e)
+		sll.linkAfter(e, nil)
+	}
+
+	return true
+}
+
+// MoveToBack moves the given element to the end of the list. It returns false if e doesn't belong
+// to the list.
+//
+// Finding the element before e takes O(n) time.
+func (sll *SinglyLinkedList[T]) MoveToBack(e *Element[T]) bool {
+	if !sll.owns(e) {
+		return false
+	}
+	if e != sll.tail {
+		sll.unlink(e)
+		sll.linkAfter(e, sll.tail)
+	}
+
+	return true
+}
+
+// MoveAfter moves the given element after mark. It returns false if e or mark doesn't belong to
+// the list. If e and mark are the same element, the list is not modified.
+//
+// Finding the element before e takes O(n) time.
+func (sll *SinglyLinkedList[T]) MoveAfter(e, mark *Element[T]) bool {
+	if !sll.owns(e) || !sll.owns(mark) {
+		return false
+	}
+	if e != mark && e != mark.Next {
+		sll.unlink(e)
+		sll.linkAfter(e, mark)
+	}
+
+	return true
+}
+
+// MoveBefore moves the given element before mark. It returns false if e or mark doesn't belong to
+// the list. If e and mark are the same element, the list is not modified.
+//
+// Finding the elements before e and mark takes O(n) time.
+func (sll *SinglyLinkedList[T]) MoveBefore(e, mark *Element[T]) bool {
+	if !sll.owns(e) || !sll.owns(mark) {
+		return false
+	}
+	if e != mark && e.Next != mark {
+		sll.unlink(e)
+		sll.linkAfter(e, sll.before(mark))
+	}
+
+	return true
+}
+
+// PushBackList moves all the elements of other to the end of the list, leaving other empty. If
+// other is a SinglyLinkedList, its elements are spliced in and keep their identity. If other is
+// the list itself, the list is not modified.
+//
+// Splicing takes O(len(other)) time, as the elements of other have to be marked as belonging to
+// the list.
+func (sll *SinglyLinkedList[T]) PushBackList(other Interface[T]) {
+	otherList, ok := other.(*SinglyLinkedList[T])
+	if !ok {
+		if other != nil {
+			sll.PushBack(drain(other)...)
+		}
+
+		return
+	}
+	if otherList == sll || otherList.head == nil {
+		return
+	}
+
+	otherList.adoptBy(sll)
+	if sll.tail == nil {
+		sll.head = otherList.head
+	} else {
+		sll.tail.Next = otherList.head
+	}
+
+	sll.tail = otherList.tail
+	sll.len += otherList.len
+	otherList.head, otherList.tail, otherList.len = nil, nil, 0
+}
+
+// PushFrontList moves all the elements of other to the start of the list, in order, leaving other
+// empty. If other is a SinglyLinkedList, its elements are spliced in and keep their identity. If
+// other is the list itself, the list is not modified.
+//
+// Splicing takes O(len(other)) time, as the elements of other have to be marked as belonging to
+// the list.
+func (sll *SinglyLinkedList[T]) PushFrontList(other Interface[T]) {
+	otherList, ok := other.(*SinglyLinkedList[T])
+	if !ok {
+		if other != nil {
+			items := drain(other)
+			reverseItems(items)
+			sll.PushFront(items...)
+		}
+
+		return
+	}
+	if otherList == sll || otherList.head == nil {
+		return
+	}
+
+	otherList.adoptBy(sll)
+	if sll.head == nil {
+		sll.tail = otherList.tail
+	} else {
+		otherList.tail.Next = sll.head
+	}
+
+	sll.head = otherList.head
+	sll.len += otherList.len
+	otherList.head, otherList.tail, otherList.len = nil, nil, 0
+}
+
+// Reverse reverses the order of the elements of the list in place.
+func (sll *SinglyLinkedList[T]) Reverse() {
+	var prev *Element[T]
+	var next *Element[T]
+	for curr := sll.head; curr != nil; curr = next {
+		next = curr.Next
+		curr.Next = prev
+		prev = curr
+	}
+
+	sll.head, sll.tail = sll.tail, sll.head
+}
+
+// Rotate rotates the list by k positions, moving its last k elements to its start. Negative values
+// of k rotate the list in the other direction, moving its first -k elements to its end. Rotating by
+// a multiple of the length of the list doesn't modify it.
+func (sll *SinglyLinkedList[T]) Rotate(k int) {
+	if sll.len < 2 {
+		return
+	}
+
+	k %= sll.len
+	if k < 0 {
+		k += sll.len
+	}
+	if k == 0 {
+		return
+	}
+
+	newTail := sll.At(sll.len - k - 1)
+	sll.tail.Next = sll.head
+	sll.head, sll.tail = newTail.Next, newTail
+	sll.tail.Next = nil
+}
+
+// SplitAt removes the elements from the (i+1)th element onwards and returns them as a new list of
+// the same type. Negative indices can also be used to split off the last -i elements. If the list
+// doesn't have enough elements, it returns nil.
+func (sll *SinglyLinkedList[T]) SplitAt(i int) Interface[T] {
+	if i < 0 {
+		i += sll.len
+	}
+	if i < 0 || i > sll.len {
+		return nil
+	}
+
+	newList := &SinglyLinkedList[T]{}
+	if i == sll.len {
+		return newList
+	}
+
+	newList.tail, newList.len = sll.tail, sll.len-i
+	if i == 0 {
+		newList.head = sll.head
+		sll.head, sll.tail = nil, nil
+	} else {
+		last := sll.At(i - 1)
+		newList.head = last.Next
+		last.Next = nil
+		sll.tail = last
+	}
+
+	newList.adoptBy(newList)
+	sll.len = i
+
+	return newList
+}
+
+// Sort sorts the list in place in ascending order as determined by less, using a bottom-up merge
+// sort in O(n*log(n)) time. The sort is stable. The elements are relinked rather than copied, so
+// they keep their identity.
+func (sll *SinglyLinkedList[T]) Sort(less func(a, b T) bool) {
+	sll.head, sll.tail = sortElements(sll.head, less)
+}
+
+// mergeSorted relinks the elements of other into the sorted list in sorted order, if other is a
+// SinglyLinkedList, and leaves other empty. It returns false if other is of another type.
+func (sll *SinglyLinkedList[T]) mergeSorted(other Interface[T], less func(a, b T) bool) bool {
+	otherList, ok := other.(*SinglyLinkedList[T])
+	if !ok {
+		return false
+	}
+
+	otherList.adoptBy(sll)
+	sll.head, sll.tail = mergeElements(sll.head, otherList.head, less)
+	sll.len += otherList.len
+	otherList.head, otherList.tail, otherList.len = nil, nil, 0
+	return true
+}
+
 // Copy creates a new copy of the list.
-func (sll *SinglyLinkedList) Copy() Interface {
+func (sll *SinglyLinkedList[T]) Copy() Interface[T] {
 	if sll.head == nil {
-		return NewSinglyLinkedList()
+		return NewSinglyLinkedListOf[T]()
 	}
 
-	var arr []int
+	var arr []T
 	for curr := sll.head; curr != nil; curr = curr.Next {
 		arr = append(arr, curr.Value)
 	}
 
-	return NewSinglyLinkedList(arr...)
+	return NewSinglyLinkedListOf(arr...)
+}
+
+// Validate checks the internal consistency of the list: every element belongs to the list, no
+// element has a Prev link, the tail is the end of the chain and the cached length matches the
+// number of elements. It returns an error describing the first inconsistency found, or nil if the
+// list is consistent.
+func (sll *SinglyLinkedList[T]) Validate() error {
+	count := 0
+	var prev *Element[T]
+	for curr := sll.head; curr != nil; curr = curr.Next {
+		if curr.list != sll {
+			return fmt.Errorf("list: element %d doesn't belong to the list", count)
+		}
+		if curr.Prev != nil {
+			return fmt.Errorf("list: element %d has a previous link", count)
+		}
+
+		count++
+		if count > sll.len {
+			return fmt.Errorf("list: more elements than the length %d", sll.len)
+		}
+
+		prev = curr
+	}
+
+	if sll.tail != prev {
+		return errors.New("list: tail is not the last element")
+	}
+	if count != sll.len {
+		return fmt.Errorf("list: length is %d, but found %d elements", sll.len, count)
+	}
+
+	return nil
+}
+
+// owns checks whether e is an element of the list.
+func (sll *SinglyLinkedList[T]) owns(e *Element[T]) bool {
+	return e != nil && e.list == sll
+}
+
+// adoptBy marks all the elements of the list as belonging to owner.
+func (sll *SinglyLinkedList[T]) adoptBy(owner *SinglyLinkedList[T]) {
+	for curr := sll.head; curr != nil; curr = curr.Next {
+		curr.list = owner
+	}
+}
+
+// before returns the element before e, or nil if e is the first element.
+func (sll *SinglyLinkedList[T]) before(e *Element[T]) *Element[T] {
+	if e == sll.head {
+		return nil
+	}
+
+	var curr *Element[T]
+	for curr = sll.head; curr.Next != e; curr = curr.Next {
+	}
+
+	return curr
+}
+
+// unlink detaches e from its neighbours without removing it from the list. It must be linked back
+// using linkAfter.
+func (sll *SinglyLinkedList[T]) unlink(e *Element[T]) {
+	prev := sll.before(e)
+	if prev == nil {
+		sll.head = e.Next
+	} else {
+		prev.Next = e.Next
+	}
+	if e == sll.tail {
+		sll.tail = prev
+	}
+
+	e.Next = nil
+}
+
+// linkAfter links the unlinked element e after mark. If mark is nil, e is linked at the start of
+// the list.
+func (sll *SinglyLinkedList[T]) linkAfter(e, mark *Element[T]) {
+	if mark == nil {
+		e.Next = sll.head
+		sll.head = e
+	} else {
+		e.Next = mark.Next
+		mark.Next = e
+	}
+
+	if e.Next == nil {
+		sll.tail = e
+	}
 }
 
-type singlyLinkedListIterable struct {
-	curr  *Element
-	value int
+type singlyLinkedListIterable[T comparable] struct {
+	curr  *Element[T]
+	value T
 }
 
-func (slli *singlyLinkedListIterable) Next() bool {
+func (slli *singlyLinkedListIterable[T]) Next() bool {
 	if slli.curr == nil {
-		slli.value = 0
+		var zero T
+		slli.value = zero
 		return false
 	}
 
@@ -454,6 +818,6 @@ func (slli *singlyLinkedListIterable) Next() bool {
 	return true
 }
 
-func (slli *singlyLinkedListIterable) Value() int {
+func (slli *singlyLinkedListIterable[T]) Value() T {
 	return slli.value
 }
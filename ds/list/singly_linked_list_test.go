@@ -9,3 +9,34 @@ import (
 func TestSinglyLinkedList(t *testing.T) {
 	testInterfaceHelper(t, list.NewSinglyLinkedList)
 }
+
+func TestSinglyLinkedListOf(t *testing.T) {
+	testInterfaceOfHelper(t, list.NewSinglyLinkedListOf[string])
+}
+
+func TestSinglyLinkedList_Validate(t *testing.T) {
+	newList := list.NewSinglyLinkedListOf(4, 5, 6).(*list.SinglyLinkedList[int])
+	if err := newList.Validate(); err != nil {
+		t.Errorf("Validate: expected Validate to return nil, got %v", err)
+	}
+
+	newList.Last().Next = newList.First()
+	if err := newList.Validate(); err == nil {
+		t.Error("Validate: expected Validate to return an error for a cycle, got nil")
+	}
+
+	newList = list.NewSinglyLinkedListOf(4, 5, 6).(*list.SinglyLinkedList[int])
+	newList.First().Next = nil
+	if err := newList.Validate(); err == nil {
+		t.Error("Validate: expected Validate to return an error for a wrong length, got nil")
+	}
+}
+
+func TestSinglyLinkedList_PushListOther(t *testing.T) {
+	newList := list.NewSinglyLinkedList(4, 5)
+	otherList := list.NewDoublyLinkedList(6, 7)
+	newList.PushBackList(otherList)
+	newList.PushFrontList(list.NewDoublyLinkedList(2, 3))
+	assertListValues(t, "PushListOther", newList, []int{2, 3, 4, 5, 6, 7})
+	assertListValues(t, "PushListOther", otherList, nil)
+}
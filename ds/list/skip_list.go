@@ -0,0 +1,895 @@
+package list
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/rand"
+)
+
+const (
+	// skipListMaxLevel is the maximum number of levels of a skip list, enough for 4^31 elements.
+	skipListMaxLevel = 32
+
+	// skipListP is the inverse of the probability with which an element is promoted to the next
+	// level.
+	skipListP = 4
+)
+
+// SkipList represents a list instance implemented as an indexable skip list. Positional operations
+// like At, InsertAt and RemoveAt take O(log(n)) expected time, instead of O(n) for linked lists.
+//
+// The elements are linked in order using their Next and Prev links like a doubly linked list, so
+// the list can be traversed in the same way. In addition, every element is promoted to a random
+// number of higher levels that skip over elements, and every link of a higher level records the
+// number of elements it spans. This allows finding an element by its index, and the index of an
+// element, by following O(log(n)) links.
+//
+// Operations that reorder the whole list, like Sort, Reverse, Rotate and splicing, rebuild the
+// higher levels in O(n) time.
+type SkipList[T comparable] struct {
+	// head holds the links from before the first element for the levels above the first level.
+	// head[i] is the link of level i+1.
+	head  [skipListMaxLevel - 1]skipLink[T]
+	level int
+
+	first *Element[T]
+	last  *Element[T]
+	len   int
+
+	rand *rand.Rand
+}
+
+// skipLink is a link of a higher level of a skip list. span is the number of elements between the
+// element with the link and next, counting next. If next is nil, span counts the elements up to
+// and including the end of the list, as if there was an element after the last one.
+type skipLink[T any] struct {
+	next *Element[T]
+	span int
+}
+
+// skipLevels holds the links of an element of a skip list above the first level, starting with
+// the second level.
+type skipLevels[T any] struct {
+	links []skipLink[T]
+}
+
+// NewSkipList returns a new skip list instance of ints with the given items inserted in order.
+func NewSkipList(items ...int) Interface[int] {
+	return NewSkipListOf(items...)
+}
+
+// NewSkipListOf returns a new skip list instance with the given items inserted in order. The levels
+// of the elements are chosen using a randomly seeded source. Use NewSeededSkipListOf to get
+// reproducible levels.
+func NewSkipListOf[T comparable](items ...T) Interface[T] {
+	return NewSeededSkipListOf(rand.Int63(), items...)
+}
+
+// NewSeededSkipListOf returns a new skip list instance with the given items inserted in order. The
+// levels of the elements are chosen using a source seeded with the given seed, so lists built with
+// the same seed and the same sequence of operations have the same structure.
+func NewSeededSkipListOf[T comparable](seed int64, items ...T) Interface[T] {
+	return newSkipList(rand.New(rand.NewSource(seed)), items...)
+}
+
+func newSkipList[T comparable](r *rand.Rand, items ...T) *SkipList[T] {
+	sl := &SkipList[T]{level: 1, rand: r}
+	sl.PushBack(items...)
+	return sl
+}
+
+// Len returns the number of items in the list.
+func (sl *SkipList[T]) Len() int {
+	return sl.len
+}
+
+// Empty checks whether the list is empty.
+func (sl *SkipList[T]) Empty() bool {
+	return sl.len == 0
+}
+
+// Clear deletes all the items from the list.
+func (sl *SkipList[T]) Clear() {
+	var next *Element[T]
+	for curr := sl.first; curr != nil; curr = next {
+		next = curr.Next
+		curr.Next, curr.Prev, curr.list, curr.levels = nil, nil, nil, nil
+	}
+
+	sl.first = nil
+	sl.last = nil
+	sl.len = 0
+	sl.level = 1
+}
+
+// First returns the first element of the list.
+func (sl *SkipList[T]) First() *Element[T] {
+	return sl.first
+}
+
+// Last returns the last element of the list.
+func (sl *SkipList[T]) Last() *Element[T] {
+	return sl.last
+}
+
+// At returns the (i+1)th element of the list. Negative indices can also be used to find the (-i)th
+// last element.
+func (sl *SkipList[T]) At(i int) *Element[T] {
+	if i < 0 {
+		i += sl.len
+	}
+	if i < 0 || i >= sl.len {
+		return nil
+	}
+
+	var curr *Element[T]
+	pos := -1
+	for lvl := sl.level - 1; lvl >= 1; lvl-- {
+		for l := sl.link(curr, lvl); l.next != nil && pos+l.span <= i; l = sl.link(curr, lvl) {
+			pos += l.span
+			curr = l.next
+		}
+	}
+
+	for ; pos < i; pos++ {
+		if curr == nil {
+			curr = sl.first
+		} else {
+			curr = curr.Next
+		}
+	}
+
+	return curr
+}
+
+// Index returns the index of the given element in the list in O(log(n)) expected time. If e
+// doesn't belong to the list, it returns -1.
+func (sl *SkipList[T]) Index(e *Element[T]) int {
+	if !sl.owns(e) {
+		return -1
+	}
+
+	return sl.indexOf(e)
+}
+
+// Contains checks whether the list contains all the given items.
+func (sl *SkipList[T]) Contains(items ...T) bool {
+	if len(items) == 0 {
+		return true
+	}
+	if sl.first == nil {
+		return false
+	}
+
+	itemsMap := make(map[T]struct{}, len(items))
+	for _, item := range items {
+		itemsMap[item] = struct{}{}
+	}
+
+	for curr := sl.first; curr != nil; curr = curr.Next {
+		if _, ok := itemsMap[curr.Value]; ok {
+			delete(itemsMap, curr.Value)
+		}
+	}
+
+	return len(itemsMap) == 0
+}
+
+// Each iterates over the items of the list.
+func (sl *SkipList[T]) Each(fn func(T) bool) {
+	for curr := sl.first; curr != nil; curr = curr.Next {
+		if fn(curr.Value) {
+			break
+		}
+	}
+}
+
+// Iterator returns a list.Iterable that can be used to iterate over the list.
+func (sl *SkipList[T]) Iterator() Iterable[T] {
+	return &doublyLinkedListIterable[T]{
+		curr: sl.first,
+	}
+}
+
+// All returns an iterator over the items of the list from the first to the last. The current
+// element can be removed during iteration.
+func (sl *SkipList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var next *Element[T]
+		for curr := sl.first; curr != nil; curr = next {
+			next = curr.Next
+			if !yield(curr.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the list from the last to the first. The current
+// element can be removed during iteration.
+func (sl *SkipList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var prev *Element[T]
+		for curr := sl.last; curr != nil; curr = prev {
+			prev = curr.Prev
+			if !yield(curr.Value) {
+				return
+			}
+		}
+	}
+}
+
+// PushFront adds the given items at the start of the list.
+func (sl *SkipList[T]) PushFront(items ...T) {
+	for _, item := range items {
+		sl.linkAt(0, sl.newElement(item))
+	}
+}
+
+// PopFront removes and returns the first element from the list. If the list is empty, it returns
+// nil.
+func (sl *SkipList[T]) PopFront() *Element[T] {
+	return sl.RemoveAt(0)
+}
+
+// PushBack adds the given items at the end of the list.
+func (sl *SkipList[T]) PushBack(items ...T) {
+	for _, item := range items {
+		sl.linkAt(sl.len, sl.newElement(item))
+	}
+}
+
+// PopBack removes and returns the last element from the list. If the list is empty, it returns
+// nil.
+func (sl *SkipList[T]) PopBack() *Element[T] {
+	return sl.RemoveAt(-1)
+}
+
+// InsertAt adds the item as the (i+1)th element and returns the element. Negative indices can also
+// be used to insert after the (-i)th last element. If the list doesn't have enough elements, it
+// returns nil.
+func (sl *SkipList[T]) InsertAt(i int, item T) *Element[T] {
+	if i < 0 {
+		if sl.len == 0 && i == -1 {
+			i = 0
+		} else {
+			i += sl.len + 1
+			if i <= 0 {
+				return nil
+			}
+		}
+	}
+	if i > sl.len {
+		return nil
+	}
+
+	newEl := sl.newElement(item)
+	sl.linkAt(i, newEl)
+	return newEl
+}
+
+// InsertAfter adds the item after the given element and returns the inserted element. If inserting
+// after e is not possible, including when e doesn't belong to the list, it returns nil.
+func (sl *SkipList[T]) InsertAfter(e *Element[T], item T) *Element[T] {
+	if !sl.owns(e) {
+		return nil
+	}
+
+	newEl := sl.newElement(item)
+	sl.linkAt(sl.indexOf(e)+1, newEl)
+	return newEl
+}
+
+// InsertBefore adds the item before the given element and returns the inserted element. If
+// inserting before e is not possible, including when e doesn't belong to the list, it returns nil.
+func (sl *SkipList[T]) InsertBefore(e *Element[T], item T) *Element[T] {
+	if !sl.owns(e) {
+		return nil
+	}
+
+	newEl := sl.newElement(item)
+	sl.linkAt(sl.indexOf(e), newEl)
+	return newEl
+}
+
+// RemoveAt removes the (i+1)th element. Negative indices can also be used to remove the (-i)th
+// last element. If the list doesn't have enough elements, it returns nil.
+func (sl *SkipList[T]) RemoveAt(i int) *Element[T] {
+	if i < 0 {
+		i += sl.len
+	}
+	if i < 0 || i >= sl.len {
+		return nil
+	}
+
+	removedEl := sl.unlinkAt(i)
+	removedEl.list = nil
+	removedEl.levels = nil
+	return removedEl
+}
+
+// Remove removes and returns the given element. If removing e is not possible, including when e
+// doesn't belong to the list, it returns nil.
+func (sl *SkipList[T]) Remove(e *Element[T]) *Element[T] {
+	if !sl.owns(e) {
+		return nil
+	}
+
+	return sl.RemoveAt(sl.indexOf(e))
+}
+
+// RemoveAfter removes and returns the element after the given element. If removing after e is not
+// possible, including when e doesn't belong to the list, it returns nil.
+func (sl *SkipList[T]) RemoveAfter(e *Element[T]) *Element[T] {
+	if !sl.owns(e) {
+		return nil
+	}
+
+	return sl.Remove(e.Next)
+}
+
+// RemoveBefore removes and returns the element before the given element. If removing before e is
+// not possible, including when e doesn't belong to the list, it returns nil.
+func (sl *SkipList[T]) RemoveBefore(e *Element[T]) *Element[T] {
+	if !sl.owns(e) {
+		return nil
+	}
+
+	return sl.Remove(e.Prev)
+}
+
+// DeleteFirst deletes the first occurrence of the given items from the list. If the same item is
+// passed twice as an argument, only one occurrence is deleted in total.
+func (sl *SkipList[T]) DeleteFirst(items ...T) {
+	if len(items) == 0 || sl.first == nil {
+		return
+	}
+
+	itemsMap := make(map[T]struct{}, len(items))
+	for _, item := range items {
+		itemsMap[item] = struct{}{}
+	}
+
+	var next *Element[T]
+	for curr := sl.first; curr != nil; curr = next {
+		next = curr.Next
+		if _, ok := itemsMap[curr.Value]; ok {
+			delete(itemsMap, curr.Value)
+			sl.Remove(curr)
+
+			if len(itemsMap) == 0 {
+				break
+			}
+		}
+	}
+}
+
+// Delete deletes the all occurrences of the given items from the list.
+func (sl *SkipList[T]) Delete(items ...T) {
+	if len(items) == 0 || sl.first == nil {
+		return
+	}
+
+	itemsMap := make(map[T]struct{}, len(items))
+	for _, item := range items {
+		itemsMap[item] = struct{}{}
+	}
+
+	var next *Element[T]
+	for curr := sl.first; curr != nil; curr = next {
+		next = curr.Next
+		if _, ok := itemsMap[curr.Value]; ok {
+			sl.Remove(curr)
+		}
+	}
+}
+
+// MoveToFront moves the given element to the start of the list. It returns false if e doesn't
+// belong to the list.
+func (sl *SkipList[T]) MoveToFront(e *Element[T]) bool {
+	if !sl.owns(e) {
+		return false
+	}
+	if e != sl.first {
+		sl.unlinkAt(sl.indexOf(e))
+		sl.linkAt(0, e)
+	}
+
+	return true
+}
+
+// MoveToBack moves the given element to the end of the list. It returns false if e doesn't belong
+// to the list.
+func (sl *SkipList[T]) MoveToBack(e *Element[T]) bool {
+	if !sl.owns(e) {
+		return false
+	}
+	if e != sl.last {
+		sl.unlinkAt(sl.indexOf(e))
+		sl.linkAt(sl.len, e)
+	}
+
+	return true
+}
+
+// MoveAfter moves the given element after mark. It returns false if e or mark doesn't belong to
+// the list. If e and mark are the same element, the list is not modified.
+func (sl *SkipList[T]) MoveAfter(e, mark *Element[T]) bool {
+	if !sl.owns(e) || !sl.owns(mark) {
+		return false
+	}
+	if e != mark && e != mark.Next {
+		sl.unlinkAt(sl.indexOf(e))
+		sl.linkAt(sl.indexOf(mark)+1, e)
+	}
+
+	return true
+}
+
+// MoveBefore moves the given element before mark. It returns false if e or mark doesn't belong to
+// the list. If e and mark are the same element, the list is not modified.
+func (sl *SkipList[T]) MoveBefore(e, mark *Element[T]) bool {
+	if !sl.owns(e) || !sl.owns(mark) {
+		return false
+	}
+	if e != mark && e != mark.Prev {
+		sl.unlinkAt(sl.indexOf(e))
+		sl.linkAt(sl.indexOf(mark), e)
+	}
+
+	return true
+}
+
+// PushBackList moves all the elements of other to the end of the list, leaving other empty. If
+// other is a SkipList, its elements are spliced in and keep their identity. If other is the list
+// itself, the list is not modified.
+//
+// Splicing rebuilds the higher levels of the list, which takes O(n+len(other)) time.
+func (sl *SkipList[T]) PushBackList(other Interface[T]) {
+	otherList, ok := other.(*SkipList[T])
+	if !ok {
+		if other != nil {
+			sl.PushBack(drain(other)...)
+		}
+
+		return
+	}
+	if otherList == sl || otherList.first == nil {
+		return
+	}
+
+	if sl.last == nil {
+		sl.first = otherList.first
+	} else {
+		sl.last.Next = otherList.first
+		otherList.first.Prev = sl.last
+	}
+
+	sl.last = otherList.last
+	sl.len += otherList.len
+	otherList.first, otherList.last, otherList.len, otherList.level = nil, nil, 0, 1
+	sl.rebuild()
+}
+
+// PushFrontList moves all the elements of other to the start of the list, in order, leaving other
+// empty. If other is a SkipList, its elements are spliced in and keep their identity. If other is
+// the list itself, the list is not modified.
+//
+// Splicing rebuilds the higher levels of the list, which takes O(n+len(other)) time.
+func (sl *SkipList[T]) PushFrontList(other Interface[T]) {
+	otherList, ok := other.(*SkipList[T])
+	if !ok {
+		if other != nil {
+			items := drain(other)
+			reverseItems(items)
+			sl.PushFront(items...)
+		}
+
+		return
+	}
+	if otherList == sl || otherList.first == nil {
+		return
+	}
+
+	if sl.first == nil {
+		sl.last = otherList.last
+	} else {
+		sl.first.Prev = otherList.last
+		otherList.last.Next = sl.first
+	}
+
+	sl.first = otherList.first
+	sl.len += otherList.len
+	otherList.first, otherList.last, otherList.len, otherList.level = nil, nil, 0, 1
+	sl.rebuild()
+}
+
+// Reverse reverses the order of the elements of the list in place. It rebuilds the higher levels
+// of the list in O(n) time.
+func (sl *SkipList[T]) Reverse() {
+	for curr := sl.first; curr != nil; curr = curr.Prev {
+		curr.Next, curr.Prev = curr.Prev, curr.Next
+	}
+
+	sl.first, sl.last = sl.last, sl.first
+	sl.rebuild()
+}
+
+// Rotate rotates the list by k positions, moving its last k elements to its start. Negative values
+// of k rotate the list in the other direction, moving its first -k elements to its end. Rotating by
+// a multiple of the length of the list doesn't modify it. It rebuilds the higher levels of the list
+// in O(n) time.
+func (sl *SkipList[T]) Rotate(k int) {
+	if sl.len < 2 {
+		return
+	}
+
+	k %= sl.len
+	if k < 0 {
+		k += sl.len
+	}
+	if k == 0 {
+		return
+	}
+
+	newFirst := sl.At(-k)
+	sl.last.Next = sl.first
+	sl.first.Prev = sl.last
+	sl.first, sl.last = newFirst, newFirst.Prev
+	sl.first.Prev = nil
+	sl.last.Next = nil
+	sl.rebuild()
+}
+
+// SplitAt removes the elements from the (i+1)th element onwards and returns them as a new list of
+// the same type. Negative indices can also be used to split off the last -i elements. If the list
+// doesn't have enough elements, it returns nil.
+//
+// The higher levels of both lists are rebuilt, which takes O(n) time.
+func (sl *SkipList[T]) SplitAt(i int) Interface[T] {
+	if i < 0 {
+		i += sl.len
+	}
+	if i < 0 || i > sl.len {
+		return nil
+	}
+
+	newList := newSkipList[T](rand.New(rand.NewSource(sl.rand.Int63())))
+	if i == sl.len {
+		return newList
+	}
+
+	first := sl.At(i)
+	newList.first, newList.last, newList.len = first, sl.last, sl.len-i
+	sl.last = first.Prev
+	if sl.last == nil {
+		sl.first = nil
+	} else {
+		sl.last.Next = nil
+	}
+	first.Prev = nil
+	sl.len = i
+
+	sl.rebuild()
+	newList.rebuild()
+	return newList
+}
+
+// Sort sorts the list in place in ascending order as determined by less, using a bottom-up merge
+// sort in O(n*log(n)) time. The sort is stable. The elements are relinked rather than copied, so
+// they keep their identity.
+func (sl *SkipList[T]) Sort(less func(a, b T) bool) {
+	sl.first, sl.last = sortElements(sl.first, less)
+
+	var prev *Element[T]
+	for curr := sl.first; curr != nil; curr = curr.Next {
+		curr.Prev = prev
+		prev = curr
+	}
+
+	sl.rebuild()
+}
+
+// mergeSorted relinks the elements of other into the sorted list in sorted order, if other is a
+// SkipList, and leaves other empty. The elements keep their number of levels, and the higher
+// levels of the list are rebuilt once. It returns false if other is of another type.
+func (sl *SkipList[T]) mergeSorted(other Interface[T], less func(a, b T) bool) bool {
+	otherList, ok := other.(*SkipList[T])
+	if !ok {
+		return false
+	}
+
+	sl.first, sl.last = mergeElements(sl.first, otherList.first, less)
+
+	var prev *Element[T]
+	for curr := sl.first; curr != nil; curr = curr.Next {
+		curr.Prev = prev
+		prev = curr
+	}
+
+	sl.len += otherList.len
+	otherList.first, otherList.last, otherList.len, otherList.level = nil, nil, 0, 1
+	sl.rebuild()
+	return true
+}
+
+// Copy creates a new copy of the list. The source of the copy is seeded from the source of the
+// list, so copies are reproducible too.
+func (sl *SkipList[T]) Copy() Interface[T] {
+	newList := newSkipList[T](rand.New(rand.NewSource(sl.rand.Int63())))
+	for curr := sl.first; curr != nil; curr = curr.Next {
+		newList.PushBack(curr.Value)
+	}
+
+	return newList
+}
+
+// Validate checks the internal consistency of the list: every element belongs to the list, the
+// Next and Prev links of neighbouring elements point to each other, the cached length matches the
+// number of elements, and the links of every higher level point to the next element of that level
+// and span the right number of elements. It returns an error describing the first inconsistency
+// found, or nil if the list is consistent.
+func (sl *SkipList[T]) Validate() error {
+	if sl.first != nil && sl.first.Prev != nil {
+		return errors.New("list: first element has a previous element")
+	}
+
+	var elements []*Element[T]
+	var prev *Element[T]
+	for curr := sl.first; curr != nil; curr = curr.Next {
+		if curr.list != sl {
+			return fmt.Errorf("list: element %d doesn't belong to the list", len(elements))
+		}
+		if curr.Prev != prev {
+			return fmt.Errorf("list: element %d has an inconsistent previous link", len(elements))
+		}
+		if skipHeight(curr) > sl.level {
+			return fmt.Errorf("list: element %d is higher than the list", len(elements))
+		}
+
+		elements = append(elements, curr)
+		if len(elements) > sl.len {
+			return fmt.Errorf("list: more elements than the length %d", sl.len)
+		}
+
+		prev = curr
+	}
+
+	if sl.last != prev {
+		return errors.New("list: last element is not the end of the chain")
+	}
+	if len(elements) != sl.len {
+		return fmt.Errorf("list: length is %d, but found %d elements", sl.len, len(elements))
+	}
+
+	for lvl := 1; lvl < sl.level; lvl++ {
+		var from *Element[T]
+		fromPos := -1
+		for pos, e := range elements {
+			if skipHeight(e) <= lvl {
+				continue
+			}
+
+			l := sl.link(from, lvl)
+			if l.next != e || l.span != pos-fromPos {
+				return fmt.Errorf("list: level %d link to element %d is inconsistent", lvl, pos)
+			}
+
+			from, fromPos = e, pos
+		}
+
+		l := sl.link(from, lvl)
+		if l.next != nil || l.span != sl.len-fromPos {
+			return fmt.Errorf("list: level %d link to the end is inconsistent", lvl)
+		}
+	}
+
+	return nil
+}
+
+// owns checks whether e is an element of the list.
+func (sl *SkipList[T]) owns(e *Element[T]) bool {
+	return e != nil && e.list == sl
+}
+
+// link returns the link of level lvl, which must be at least 1, of e. If e is nil, the link of
+// the head is returned.
+func (sl *SkipList[T]) link(e *Element[T], lvl int) *skipLink[T] {
+	if e == nil {
+		return &sl.head[lvl-1]
+	}
+
+	return &e.levels.links[lvl-1]
+}
+
+// skipHeight returns the number of levels of e, which is 1 if it has no links above the first
+// level.
+func skipHeight[T any](e *Element[T]) int {
+	if e.levels == nil {
+		return 1
+	}
+
+	return len(e.levels.links) + 1
+}
+
+// newElement returns a new element with the given item and a random number of levels.
+func (sl *SkipList[T]) newElement(item T) *Element[T] {
+	height := 1
+	for height < skipListMaxLevel && sl.rand.Intn(skipListP) == 0 {
+		height++
+	}
+
+	newEl := &Element[T]{Value: item}
+	if height > 1 {
+		newEl.levels = &skipLevels[T]{links: make([]skipLink[T], height-1)}
+	}
+
+	return newEl
+}
+
+// indexOf returns the index of e, which must belong to the list. It follows the highest link of
+// every element up to the end of the list and subtracts the distance covered from the length.
+func (sl *SkipList[T]) indexOf(e *Element[T]) int {
+	dist := 0
+	for curr := e; ; {
+		if height := skipHeight(curr); height > 1 {
+			l := curr.levels.links[height-2]
+			dist += l.span
+			if l.next == nil {
+				break
+			}
+
+			curr = l.next
+		} else {
+			dist++
+			if curr.Next == nil {
+				break
+			}
+
+			curr = curr.Next
+		}
+	}
+
+	return sl.len - dist
+}
+
+// search finds, for every higher level, the last element before the (i+1)th position and its
+// index, storing them in update and rank. It returns the element before the (i+1)th position, or
+// nil if i is 0.
+func (sl *SkipList[T]) search(i int, update *[skipListMaxLevel]*Element[T], rank *[skipListMaxLevel]int) *Element[T] {
+	var curr *Element[T]
+	pos := -1
+	for lvl := sl.level - 1; lvl >= 1; lvl-- {
+		for l := sl.link(curr, lvl); l.next != nil && pos+l.span < i; l = sl.link(curr, lvl) {
+			pos += l.span
+			curr = l.next
+		}
+
+		update[lvl], rank[lvl] = curr, pos
+	}
+
+	for ; pos < i-1; pos++ {
+		if curr == nil {
+			curr = sl.first
+		} else {
+			curr = curr.Next
+		}
+	}
+
+	return curr
+}
+
+// linkAt links e, which must not be linked, as the (i+1)th element, keeping its number of levels.
+func (sl *SkipList[T]) linkAt(i int, e *Element[T]) {
+	var update [skipListMaxLevel]*Element[T]
+	var rank [skipListMaxLevel]int
+	prev := sl.search(i, &update, &rank)
+
+	height := skipHeight(e)
+	for ; sl.level < height; sl.level++ {
+		update[sl.level], rank[sl.level] = nil, -1
+		sl.head[sl.level-1] = skipLink[T]{span: sl.len + 1}
+	}
+
+	for lvl := 1; lvl < sl.level; lvl++ {
+		l := sl.link(update[lvl], lvl)
+		if lvl < height {
+			e.levels.links[lvl-1] = skipLink[T]{next: l.next, span: l.span - (i - rank[lvl]) + 1}
+			*l = skipLink[T]{next: e, span: i - rank[lvl]}
+		} else {
+			l.span++
+		}
+	}
+
+	e.Prev = prev
+	if prev == nil {
+		e.Next = sl.first
+		sl.first = e
+	} else {
+		e.Next = prev.Next
+		prev.Next = e
+	}
+	if e.Next == nil {
+		sl.last = e
+	} else {
+		e.Next.Prev = e
+	}
+
+	e.list = sl
+	sl.len++
+}
+
+// unlinkAt unlinks and returns the (i+1)th element, which must exist. The element keeps its levels
+// and still belongs to the list, so that it can be linked back using linkAt.
+func (sl *SkipList[T]) unlinkAt(i int) *Element[T] {
+	var update [skipListMaxLevel]*Element[T]
+	var rank [skipListMaxLevel]int
+	prev := sl.search(i, &update, &rank)
+
+	var e *Element[T]
+	if prev == nil {
+		e = sl.first
+	} else {
+		e = prev.Next
+	}
+
+	height := skipHeight(e)
+	for lvl := 1; lvl < sl.level; lvl++ {
+		l := sl.link(update[lvl], lvl)
+		if lvl < height {
+			l.next = e.levels.links[lvl-1].next
+			l.span += e.levels.links[lvl-1].span - 1
+		} else {
+			l.span--
+		}
+	}
+
+	if e.Prev == nil {
+		sl.first = e.Next
+	} else {
+		e.Prev.Next = e.Next
+	}
+	if e.Next == nil {
+		sl.last = e.Prev
+	} else {
+		e.Next.Prev = e.Prev
+	}
+
+	e.Prev = nil
+	e.Next = nil
+	sl.len--
+	return e
+}
+
+// rebuild marks all the elements as belonging to the list and relinks all the higher levels from
+// the order of the elements, keeping the number of levels of every element.
+func (sl *SkipList[T]) rebuild() {
+	var last [skipListMaxLevel]*Element[T]
+	var lastPos [skipListMaxLevel]int
+	for lvl := range lastPos {
+		lastPos[lvl] = -1
+	}
+
+	sl.level = 1
+	pos := 0
+	for curr := sl.first; curr != nil; curr = curr.Next {
+		curr.list = sl
+
+		height := skipHeight(curr)
+		if height > sl.level {
+			sl.level = height
+		}
+
+		for lvl := 1; lvl < height; lvl++ {
+			*sl.link(last[lvl], lvl) = skipLink[T]{next: curr, span: pos - lastPos[lvl]}
+			last[lvl], lastPos[lvl] = curr, pos
+		}
+
+		pos++
+	}
+
+	for lvl := 1; lvl < sl.level; lvl++ {
+		*sl.link(last[lvl], lvl) = skipLink[T]{span: sl.len - lastPos[lvl]}
+	}
+}
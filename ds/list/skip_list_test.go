@@ -0,0 +1,79 @@
+package list_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/list"
+)
+
+func TestSkipList(t *testing.T) {
+	testInterfaceHelper(t, list.NewSkipList)
+}
+
+func TestSkipListOf(t *testing.T) {
+	testInterfaceOfHelper(t, list.NewSkipListOf[string])
+}
+
+func TestSkipList_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	newList := list.NewSeededSkipListOf[int](1).(*list.SkipList[int])
+	var expected []int
+	for n := 0; n < 3000; n++ {
+		switch op := r.Intn(10); {
+		case op < 4:
+			i := r.Intn(len(expected) + 1)
+			if el := newList.InsertAt(i, n); el == nil || el.Value != n {
+				t.Fatalf("Random: expected InsertAt %d to return Element with Value %d, got %v", i, n, el)
+			}
+
+			expected = append(expected[:i], append([]int{n}, expected[i:]...)...)
+		case op < 6 && len(expected) > 0:
+			i := r.Intn(len(expected))
+			if el := newList.RemoveAt(i); el == nil || el.Value != expected[i] {
+				t.Fatalf("Random: expected RemoveAt %d to return Element with Value %d, got %v", i, expected[i], el)
+			}
+
+			expected = append(expected[:i], expected[i+1:]...)
+		case op < 8 && len(expected) > 1:
+			i, j := r.Intn(len(expected)), r.Intn(len(expected))
+			el, mark := newList.At(i), newList.At(j)
+			newList.MoveAfter(el, mark)
+
+			item := expected[i]
+			expected = append(expected[:i], expected[i+1:]...)
+			j = slices.Index(expected, mark.Value)
+			if el == mark {
+				j = i - 1
+			}
+			expected = append(expected[:j+1], append([]int{item}, expected[j+1:]...)...)
+		case len(expected) > 0:
+			i := r.Intn(len(expected))
+			if idx := newList.Index(newList.At(i)); idx != i {
+				t.Fatalf("Random: expected Index of At %d to be %d, got %d", i, i, idx)
+			}
+		}
+
+		if err := newList.Validate(); err != nil {
+			t.Fatalf("Random: expected Validate to return nil after %d operations, got %v", n, err)
+		}
+	}
+
+	assertListValues(t, "Random", newList, expected)
+	for i, item := range expected {
+		if el := newList.At(i); el == nil || el.Value != item {
+			t.Fatalf("Random: expected At %d to return Element with Value %d, got %v", i, item, el)
+		}
+	}
+}
+
+func TestSkipList_Index(t *testing.T) {
+	newList := list.NewSeededSkipListOf(1, 4, 5, 6).(*list.SkipList[int])
+	if idx := newList.Index(newList.Last()); idx != 2 {
+		t.Errorf("Index: expected Index of Last to be 2, got %d", idx)
+	}
+	if idx := newList.Index(list.NewSkipList(4).First()); idx != -1 {
+		t.Errorf("Index: expected Index of foreign Element to be -1, got %d", idx)
+	}
+}
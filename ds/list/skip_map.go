@@ -0,0 +1,343 @@
+package list
+
+import (
+	"cmp"
+	"iter"
+	"math/rand"
+)
+
+// SkipMap represents an ordered map implemented as an indexable skip list. Keys are kept in
+// ascending order. Lookups, insertions, deletions and positional operations like At and Rank take
+// O(log(n)) expected time.
+type SkipMap[K cmp.Ordered, V any] struct {
+	// head is a sentinel node before the first node with a link for every level.
+	head  skipMapNode[K, V]
+	level int
+
+	last *skipMapNode[K, V]
+	len  int
+
+	rand *rand.Rand
+}
+
+type skipMapNode[K cmp.Ordered, V any] struct {
+	key   K
+	value V
+	prev  *skipMapNode[K, V]
+
+	// links are the links of the node for every level of the node, starting from the first level.
+	links []skipMapLink[K, V]
+}
+
+// skipMapLink is a link of a skip map. span is the number of nodes between the node with the link
+// and next, counting next. If next is nil, span counts the nodes up to and including the end of
+// the map, as if there was a node after the last one.
+type skipMapLink[K cmp.Ordered, V any] struct {
+	next *skipMapNode[K, V]
+	span int
+}
+
+// NewSkipMap returns a new empty skip map instance. The levels of the nodes are chosen using a
+// randomly seeded source. Use NewSeededSkipMap to get reproducible levels.
+func NewSkipMap[K cmp.Ordered, V any]() *SkipMap[K, V] {
+	return NewSeededSkipMap[K, V](rand.Int63())
+}
+
+// NewSeededSkipMap returns a new empty skip map instance. The levels of the nodes are chosen using
+// a source seeded with the given seed, so maps built with the same seed and the same sequence of
+// operations have the same structure.
+func NewSeededSkipMap[K cmp.Ordered, V any](seed int64) *SkipMap[K, V] {
+	m := &SkipMap[K, V]{rand: rand.New(rand.NewSource(seed))}
+	m.Clear()
+	return m
+}
+
+// Len returns the number of keys in the map.
+func (m *SkipMap[K, V]) Len() int {
+	return m.len
+}
+
+// Empty checks whether the map is empty.
+func (m *SkipMap[K, V]) Empty() bool {
+	return m.len == 0
+}
+
+// Clear deletes all the keys from the map.
+func (m *SkipMap[K, V]) Clear() {
+	m.head.links = make([]skipMapLink[K, V], skipListMaxLevel)
+	m.head.links[0].span = 1
+	m.level = 1
+	m.last = nil
+	m.len = 0
+}
+
+// Get returns the value of the given key. If the key doesn't exist, the second return value is
+// false.
+func (m *SkipMap[K, V]) Get(key K) (V, bool) {
+	if n := m.ceiling(key); n != nil && n.key == key {
+		return n.value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Contains checks whether the map contains the given key.
+func (m *SkipMap[K, V]) Contains(key K) bool {
+	n := m.ceiling(key)
+	return n != nil && n.key == key
+}
+
+// Set sets the value of the given key. It returns true if the key is new, or false if the value
+// of an existing key was updated.
+func (m *SkipMap[K, V]) Set(key K, value V) bool {
+	var update [skipListMaxLevel]*skipMapNode[K, V]
+	var rank [skipListMaxLevel]int
+	m.search(key, &update, &rank)
+
+	if next := update[0].links[0].next; next != nil && next.key == key {
+		next.value = value
+		return false
+	}
+
+	height := 1
+	for height < skipListMaxLevel && m.rand.Intn(skipListP) == 0 {
+		height++
+	}
+
+	for ; m.level < height; m.level++ {
+		update[m.level], rank[m.level] = &m.head, -1
+		m.head.links[m.level] = skipMapLink[K, V]{span: m.len + 1}
+	}
+
+	pos := rank[0] + 1
+	n := &skipMapNode[K, V]{key: key, value: value, links: make([]skipMapLink[K, V], height)}
+	for lvl := 0; lvl < m.level; lvl++ {
+		l := &update[lvl].links[lvl]
+		if lvl < height {
+			n.links[lvl] = skipMapLink[K, V]{next: l.next, span: l.span - (pos - rank[lvl]) + 1}
+			*l = skipMapLink[K, V]{next: n, span: pos - rank[lvl]}
+		} else {
+			l.span++
+		}
+	}
+
+	if update[0] != &m.head {
+		n.prev = update[0]
+	}
+	if next := n.links[0].next; next == nil {
+		m.last = n
+	} else {
+		next.prev = n
+	}
+
+	m.len++
+	return true
+}
+
+// Delete deletes the given key from the map. It returns false if the key doesn't exist.
+func (m *SkipMap[K, V]) Delete(key K) bool {
+	var update [skipListMaxLevel]*skipMapNode[K, V]
+	var rank [skipListMaxLevel]int
+	m.search(key, &update, &rank)
+
+	n := update[0].links[0].next
+	if n == nil || n.key != key {
+		return false
+	}
+
+	for lvl := 0; lvl < m.level; lvl++ {
+		l := &update[lvl].links[lvl]
+		if l.next == n {
+			l.next = n.links[lvl].next
+			l.span += n.links[lvl].span - 1
+		} else {
+			l.span--
+		}
+	}
+
+	if next := n.links[0].next; next == nil {
+		m.last = n.prev
+	} else {
+		next.prev = n.prev
+	}
+
+	m.len--
+	return true
+}
+
+// Min returns the smallest key and its value. If the map is empty, the last return value is false.
+func (m *SkipMap[K, V]) Min() (K, V, bool) {
+	return m.entry(m.head.links[0].next)
+}
+
+// Max returns the largest key and its value. If the map is empty, the last return value is false.
+func (m *SkipMap[K, V]) Max() (K, V, bool) {
+	return m.entry(m.last)
+}
+
+// Floor returns the largest key less than or equal to the given key and its value. If there is no
+// such key, the last return value is false.
+func (m *SkipMap[K, V]) Floor(key K) (K, V, bool) {
+	curr := &m.head
+	for lvl := m.level - 1; lvl >= 0; lvl-- {
+		for next := curr.links[lvl].next; next != nil && next.key <= key; next = curr.links[lvl].next {
+			curr = next
+		}
+	}
+
+	if curr == &m.head {
+		return m.entry(nil)
+	}
+
+	return m.entry(curr)
+}
+
+// Ceiling returns the smallest key greater than or equal to the given key and its value. If there
+// is no such key, the last return value is false.
+func (m *SkipMap[K, V]) Ceiling(key K) (K, V, bool) {
+	return m.entry(m.ceiling(key))
+}
+
+// At returns the (i+1)th smallest key and its value. Negative indices can also be used to find the
+// (-i)th largest key. If the map doesn't have enough keys, the last return value is false.
+func (m *SkipMap[K, V]) At(i int) (K, V, bool) {
+	if i < 0 {
+		i += m.len
+	}
+	if i < 0 || i >= m.len {
+		return m.entry(nil)
+	}
+
+	curr := &m.head
+	pos := -1
+	for lvl := m.level - 1; lvl >= 0; lvl-- {
+		for l := curr.links[lvl]; l.next != nil && pos+l.span <= i; l = curr.links[lvl] {
+			pos += l.span
+			curr = l.next
+		}
+	}
+
+	return m.entry(curr)
+}
+
+// Rank returns the number of keys in the map less than the given key. If the key exists, it is
+// the index of the key.
+func (m *SkipMap[K, V]) Rank(key K) int {
+	var update [skipListMaxLevel]*skipMapNode[K, V]
+	var rank [skipListMaxLevel]int
+	m.search(key, &update, &rank)
+	return rank[0] + 1
+}
+
+// All returns an iterator over the keys and values of the map in ascending order of the keys. The
+// map should not be modified during iteration, except for deleting the current key.
+func (m *SkipMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var next *skipMapNode[K, V]
+		for curr := m.head.links[0].next; curr != nil; curr = next {
+			next = curr.links[0].next
+			if !yield(curr.key, curr.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the keys and values of the map in descending order of the
+// keys. The map should not be modified during iteration, except for deleting the current key.
+func (m *SkipMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var prev *skipMapNode[K, V]
+		for curr := m.last; curr != nil; curr = prev {
+			prev = curr.prev
+			if !yield(curr.key, curr.value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys of the map in ascending order.
+func (m *SkipMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the map in ascending order of their keys.
+func (m *SkipMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the keys and values of the map with keys in the range [from, to)
+// in ascending order of the keys.
+func (m *SkipMap[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for curr := m.ceiling(from); curr != nil && curr.key < to; curr = curr.links[0].next {
+			if !yield(curr.key, curr.value) {
+				return
+			}
+		}
+	}
+}
+
+// Copy creates a new copy of the map. The source of the copy is seeded from the source of the map,
+// so copies are reproducible too.
+func (m *SkipMap[K, V]) Copy() *SkipMap[K, V] {
+	newMap := NewSeededSkipMap[K, V](m.rand.Int63())
+	for k, v := range m.All() {
+		newMap.Set(k, v)
+	}
+
+	return newMap
+}
+
+// entry returns the key and the value of n. If n is nil, it returns zero values and false.
+func (m *SkipMap[K, V]) entry(n *skipMapNode[K, V]) (K, V, bool) {
+	if n == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+
+	return n.key, n.value, true
+}
+
+// search finds, for every level, the last node with a key less than the given key and its index,
+// storing them in update and rank. The head is used, with the index -1, if there is no such node.
+func (m *SkipMap[K, V]) search(key K, update *[skipListMaxLevel]*skipMapNode[K, V], rank *[skipListMaxLevel]int) {
+	curr := &m.head
+	pos := -1
+	for lvl := m.level - 1; lvl >= 0; lvl-- {
+		for l := curr.links[lvl]; l.next != nil && l.next.key < key; l = curr.links[lvl] {
+			pos += l.span
+			curr = l.next
+		}
+
+		update[lvl], rank[lvl] = curr, pos
+	}
+}
+
+// ceiling returns the node with the smallest key greater than or equal to the given key, or nil if
+// there is no such node.
+func (m *SkipMap[K, V]) ceiling(key K) *skipMapNode[K, V] {
+	curr := &m.head
+	for lvl := m.level - 1; lvl >= 0; lvl-- {
+		for next := curr.links[lvl].next; next != nil && next.key < key; next = curr.links[lvl].next {
+			curr = next
+		}
+	}
+
+	return curr.links[0].next
+}
@@ -0,0 +1,145 @@
+package list_test
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/list"
+)
+
+func TestSkipMap(t *testing.T) {
+	m := list.NewSeededSkipMap[string, int](1)
+	if !m.Empty() {
+		t.Error("SkipMap: expected Empty to be true, got false")
+	}
+
+	if !m.Set("b", 2) || !m.Set("d", 4) || !m.Set("a", 1) {
+		t.Error("SkipMap: expected Set of a new key to return true, got false")
+	}
+	if m.Set("b", 20) {
+		t.Error("SkipMap: expected Set of an existing key to return false, got true")
+	}
+	if v, ok := m.Get("b"); !ok || v != 20 {
+		t.Errorf("SkipMap: expected Get b to return (20, true), got (%d, %t)", v, ok)
+	}
+	if _, ok := m.Get("c"); ok || m.Contains("c") {
+		t.Error("SkipMap: expected c not to exist")
+	}
+
+	keys := slices.Collect(m.Keys())
+	if !slices.Equal(keys, []string{"a", "b", "d"}) {
+		t.Errorf("SkipMap: expected Keys to be [a b d], got %v", keys)
+	}
+	values := slices.Collect(m.Values())
+	if !slices.Equal(values, []int{1, 20, 4}) {
+		t.Errorf("SkipMap: expected Values to be [1 20 4], got %v", values)
+	}
+
+	var backward []string
+	for k := range m.Backward() {
+		backward = append(backward, k)
+	}
+	if !slices.Equal(backward, []string{"d", "b", "a"}) {
+		t.Errorf("SkipMap: expected Backward keys to be [d b a], got %v", backward)
+	}
+
+	if k, _, ok := m.Floor("c"); !ok || k != "b" {
+		t.Errorf("SkipMap: expected Floor c to return b, got %q", k)
+	}
+	if k, _, ok := m.Ceiling("c"); !ok || k != "d" {
+		t.Errorf("SkipMap: expected Ceiling c to return d, got %q", k)
+	}
+	if _, _, ok := m.Floor("0"); ok {
+		t.Error("SkipMap: expected Floor 0 to return false, got true")
+	}
+	if _, _, ok := m.Ceiling("e"); ok {
+		t.Error("SkipMap: expected Ceiling e to return false, got true")
+	}
+	if k, _, ok := m.Min(); !ok || k != "a" {
+		t.Errorf("SkipMap: expected Min to return a, got %q", k)
+	}
+	if k, _, ok := m.Max(); !ok || k != "d" {
+		t.Errorf("SkipMap: expected Max to return d, got %q", k)
+	}
+	if k, v, ok := m.At(-1); !ok || k != "d" || v != 4 {
+		t.Errorf("SkipMap: expected At -1 to return (d, 4), got (%q, %d)", k, v)
+	}
+	if r := m.Rank("c"); r != 2 {
+		t.Errorf("SkipMap: expected Rank c to be 2, got %d", r)
+	}
+
+	copiedMap := m.Copy()
+	if !m.Delete("b") || m.Delete("b") {
+		t.Error("SkipMap: expected Delete b to return true once")
+	}
+	if m.Len() != 2 || copiedMap.Len() != 3 {
+		t.Errorf("SkipMap: expected Len to be 2 and Len of Copy to be 3, got %d and %d", m.Len(), copiedMap.Len())
+	}
+
+	m.Clear()
+	if !m.Empty() || slices.Collect(m.Keys()) != nil {
+		t.Error("SkipMap: expected Clear to delete all keys")
+	}
+}
+
+func TestSkipMap_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := list.NewSeededSkipMap[int, int](1)
+	expected := make(map[int]int)
+	for n := 0; n < 5000; n++ {
+		key := r.Intn(500)
+		if r.Intn(3) == 0 {
+			_, ok := expected[key]
+			if m.Delete(key) != ok {
+				t.Fatalf("Random: expected Delete %d to return %t", key, ok)
+			}
+
+			delete(expected, key)
+		} else {
+			_, ok := expected[key]
+			if m.Set(key, n) == ok {
+				t.Fatalf("Random: expected Set %d to return %t", key, !ok)
+			}
+
+			expected[key] = n
+		}
+	}
+
+	keys := slices.Sorted(maps.Keys(expected))
+	if m.Len() != len(keys) {
+		t.Fatalf("Random: expected Len to be %d, got %d", len(keys), m.Len())
+	}
+	for i, key := range keys {
+		if k, v, ok := m.At(i); !ok || k != key || v != expected[key] {
+			t.Fatalf("Random: expected At %d to return (%d, %d), got (%d, %d)", i, key, expected[key], k, v)
+		}
+		if r := m.Rank(key); r != i {
+			t.Fatalf("Random: expected Rank %d to be %d, got %d", key, i, r)
+		}
+	}
+
+	var got []int
+	for k := range m.Range(100, 200) {
+		got = append(got, k)
+	}
+	var want []int
+	for _, key := range keys {
+		if key >= 100 && key < 200 {
+			want = append(want, key)
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Random: expected Range 100, 200 to be %v, got %v", want, got)
+	}
+
+	var backward []int
+	for k := range m.Backward() {
+		backward = append(backward, k)
+	}
+	slices.Reverse(backward)
+	if !slices.Equal(backward, keys) {
+		t.Errorf("Random: expected Backward to be the reverse of %v, got %v", keys, backward)
+	}
+}
@@ -0,0 +1,142 @@
+package list
+
+// MergeSorted moves all the items of b into a, leaving b empty. Both a and b should already be
+// sorted in ascending order as determined by less, and a remains sorted after the merge. The merge
+// is stable: equal items of a come before those of b.
+//
+// If a and b are lists of the same type, the elements of b are relinked into a and keep their
+// identity, which takes O(len(a)+len(b)) time. Otherwise the items of b are inserted into a as new
+// elements, unless all of them end up at the end of a in which case they are spliced using
+// PushBackList.
+func MergeSorted[T comparable](a, b Interface[T], less func(x, y T) bool) {
+	if a == b {
+		return
+	}
+	if m, ok := a.(sortedMerger[T]); ok && m.mergeSorted(b, less) {
+		return
+	}
+
+	var prev *Element[T]
+	curr := a.First()
+	for !b.Empty() {
+		item := b.First().Value
+		for curr != nil && !less(item, curr.Value) {
+			prev = curr
+			curr = after(a, curr)
+		}
+		if curr == nil {
+			a.PushBackList(b)
+			return
+		}
+
+		b.PopFront()
+		if prev == nil {
+			a.PushFront(item)
+			prev = a.First()
+		} else {
+			prev = a.InsertAfter(prev, item)
+		}
+	}
+}
+
+// sortedMerger is implemented by the lists that can merge another sorted list by relinking its
+// elements. mergeSorted returns false, without modifying either list, if it can't merge other.
+type sortedMerger[T comparable] interface {
+	mergeSorted(other Interface[T], less func(a, b T) bool) bool
+}
+
+// Dedup removes the consecutive duplicate items of the list, keeping the first of each run. If
+// the list is sorted, every item is left only once.
+func Dedup[T comparable](l Interface[T]) {
+	curr := l.First()
+	if curr == nil {
+		return
+	}
+
+	for next := after(l, curr); next != nil; next = after(l, curr) {
+		if next.Value == curr.Value {
+			l.RemoveAfter(curr)
+		} else {
+			curr = next
+		}
+	}
+}
+
+// sortElements sorts the chain of elements starting at head using their Next links, in ascending
+// order as determined by less. It returns the new first and last elements of the chain. The Prev
+// links are not updated.
+//
+// It is a bottom-up merge sort: runs of width 1, 2, 4, ... are merged pairwise until a single run
+// remains, so it needs no recursion and no extra memory.
+func sortElements[T any](head *Element[T], less func(a, b T) bool) (*Element[T], *Element[T]) {
+	if head == nil {
+		return nil, nil
+	}
+
+	for width := 1; ; width *= 2 {
+		var newHead, tail *Element[T]
+		merges := 0
+		p := head
+		for p != nil {
+			merges++
+
+			q := p
+			pSize := 0
+			for pSize < width && q != nil {
+				pSize++
+				q = q.Next
+			}
+
+			qSize := width
+			for pSize > 0 || (qSize > 0 && q != nil) {
+				var e *Element[T]
+				if pSize == 0 || (qSize > 0 && q != nil && less(q.Value, p.Value)) {
+					e, q = q, q.Next
+					qSize--
+				} else {
+					e, p = p, p.Next
+					pSize--
+				}
+
+				if tail == nil {
+					newHead = e
+				} else {
+					tail.Next = e
+				}
+				tail = e
+			}
+
+			p = q
+		}
+
+		tail.Next = nil
+		head = newHead
+		if merges <= 1 {
+			return head, tail
+		}
+	}
+}
+
+// mergeElements merges the sorted chains of elements starting at a and b using their Next links,
+// in ascending order as determined by less. Equal elements of a come before those of b. It returns
+// the first and last elements of the merged chain. The Prev links are not updated.
+func mergeElements[T any](a, b *Element[T], less func(x, y T) bool) (*Element[T], *Element[T]) {
+	var head, tail *Element[T]
+	for a != nil || b != nil {
+		var e *Element[T]
+		if a == nil || (b != nil && less(b.Value, a.Value)) {
+			e, b = b, b.Next
+		} else {
+			e, a = a, a.Next
+		}
+
+		if tail == nil {
+			head = e
+		} else {
+			tail.Next = e
+		}
+		tail = e
+	}
+
+	return head, tail
+}
@@ -0,0 +1,140 @@
+package list_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/list"
+)
+
+func TestSort_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 70; n++ {
+		items := make([]int, n)
+		for i := range items {
+			items[i] = r.Intn(20)
+		}
+
+		for _, newFn := range []func(...int) list.Interface[int]{
+			list.NewDoublyLinkedList,
+			list.NewSinglyLinkedList,
+			list.NewCircularDoublyLinkedList,
+			newSmallUnrolledLinkedList,
+		} {
+			newList := newFn(items...)
+			newList.Sort(func(a, b int) bool { return a < b })
+
+			expected := append([]int(nil), items...)
+			sort.Ints(expected)
+			assertListValues(t, "Sort", newList, expected)
+		}
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	for _, newFn := range []func(...int) list.Interface[int]{
+		list.NewDoublyLinkedList,
+		list.NewSinglyLinkedList,
+		list.NewCircularDoublyLinkedList,
+		list.NewSkipList,
+		newSmallUnrolledLinkedList,
+	} {
+		a := newFn(1, 3, 5, 5, 9)
+		b := newFn(0, 2, 5, 6, 10, 11)
+		list.MergeSorted(a, b, less)
+		assertListValues(t, "MergeSorted", a, []int{0, 1, 2, 3, 5, 5, 5, 6, 9, 10, 11})
+		assertListValues(t, "MergeSorted", b, nil)
+
+		a = newFn()
+		list.MergeSorted(a, newFn(1, 2), less)
+		assertListValues(t, "MergeSorted", a, []int{1, 2})
+		list.MergeSorted(a, newFn(), less)
+		assertListValues(t, "MergeSorted", a, []int{1, 2})
+		list.MergeSorted(a, a, less)
+		assertListValues(t, "MergeSorted", a, []int{1, 2})
+	}
+
+	a := list.NewDoublyLinkedList(1, 4)
+	list.MergeSorted(a, list.NewSinglyLinkedList(2, 3, 5), less)
+	assertListValues(t, "MergeSorted", a, []int{1, 2, 3, 4, 5})
+}
+
+func TestMergeSorted_Elements(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	for _, newFn := range []func(...int) list.Interface[int]{
+		list.NewDoublyLinkedList,
+		list.NewSinglyLinkedList,
+		list.NewCircularDoublyLinkedList,
+		list.NewSkipList,
+		newSmallUnrolledLinkedList,
+	} {
+		a := newFn(1, 4, 7, 10)
+		b := newFn(2, 4, 8, 11, 12)
+		var elements []*list.Element[int]
+		for e, i := b.First(), 0; i < b.Len(); e, i = e.Next, i+1 {
+			elements = append(elements, e)
+		}
+
+		// The elements of b are moved into a, so they can be used with a and not with b.
+		list.MergeSorted(a, b, less)
+		assertListValues(t, "MergeSorted", a, []int{1, 2, 4, 4, 7, 8, 10, 11, 12})
+		for _, e := range elements {
+			if b.Remove(e) != nil {
+				t.Errorf("MergeSorted: expected element %d to not belong to b", e.Value)
+			}
+			if a.Remove(e) != e {
+				t.Errorf("MergeSorted: expected element %d to be removed from a", e.Value)
+			}
+		}
+		assertListValues(t, "MergeSorted", a, []int{1, 4, 7, 10})
+	}
+}
+
+func TestMergeSorted_Stable(t *testing.T) {
+	type item struct {
+		key  int
+		list string
+	}
+
+	less := func(a, b item) bool { return a.key < b.key }
+	a := list.NewDoublyLinkedListOf(item{1, "a"}, item{2, "a"})
+	b := list.NewDoublyLinkedListOf(item{1, "b"}, item{2, "b"})
+	list.MergeSorted(a, b, less)
+
+	var got []item
+	a.Each(func(it item) bool {
+		got = append(got, it)
+		return false
+	})
+
+	expected := []item{{1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}}
+	for i := range expected {
+		if len(got) != len(expected) || got[i] != expected[i] {
+			t.Errorf("MergeSorted: expected a stable merge to give %v, got %v", expected, got)
+			break
+		}
+	}
+}
+
+func TestDedup(t *testing.T) {
+	for _, newFn := range []func(...int) list.Interface[int]{
+		list.NewDoublyLinkedList,
+		list.NewSinglyLinkedList,
+		list.NewCircularDoublyLinkedList,
+		newSmallUnrolledLinkedList,
+	} {
+		newList := newFn(1, 1, 2, 3, 3, 3, 4, 5, 5)
+		list.Dedup(newList)
+		assertListValues(t, "Dedup", newList, []int{1, 2, 3, 4, 5})
+
+		newList = newFn(2, 2, 2)
+		list.Dedup(newList)
+		assertListValues(t, "Dedup", newList, []int{2})
+
+		newList = newFn()
+		list.Dedup(newList)
+		assertListValues(t, "Dedup", newList, nil)
+	}
+}
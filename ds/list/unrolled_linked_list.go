@@ -0,0 +1,898 @@
+package list
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// unrolledLinkedListNodeSize is the default maximum number of elements in a node of an unrolled
+// linked list.
+const unrolledLinkedListNodeSize = 32
+
+// UnrolledLinkedList represents a list instance implemented as an unrolled linked list: a doubly
+// linked list of nodes, each holding a small array of up to a fixed number of elements.
+//
+// Positional operations like At, InsertAt and RemoveAt skip over whole nodes and take O(n/B + B)
+// time, where B is the node size. Each and Contains read the arrays of the nodes, and the elements
+// themselves are allocated in blocks of B, so iterating over a list built in order walks contiguous
+// memory rather than chasing a pointer per element. As blocks are shared, a removed element that is
+// still referenced keeps its block alive.
+//
+// The elements are also linked in order using their Next and Prev links like a doubly linked list,
+// so the list can be traversed in the same way. Nodes that fall below half full on removal are
+// merged with a neighbour when they fit together. Splicing with PushBackList and PushFrontList and
+// SplitAt move whole nodes, so they take O(n/B) time when the node sizes match.
+type UnrolledLinkedList[T comparable] struct {
+	head     *unrolledNode[T]
+	tail     *unrolledNode[T]
+	len      int
+	nodeSize int
+
+	// block is the unused part of the last block of elements allocated by the list.
+	block []Element[T]
+}
+
+// unrolledNode is a node of an UnrolledLinkedList. The list field of its elements points to the
+// node, and the list field of the node to the list it belongs to.
+type unrolledNode[T any] struct {
+	elements []*Element[T]
+	next     *unrolledNode[T]
+	prev     *unrolledNode[T]
+	list     any
+}
+
+// NewUnrolledLinkedList returns a new unrolled linked list instance of ints with the given items
+// inserted in order.
+func NewUnrolledLinkedList(items ...int) Interface[int] {
+	return NewUnrolledLinkedListOf(items...)
+}
+
+// NewUnrolledLinkedListOf returns a new unrolled linked list instance with the given items inserted
+// in order. Every node holds up to 32 elements. Use NewUnrolledLinkedListOfSize to choose the node
+// size.
+func NewUnrolledLinkedListOf[T comparable](items ...T) Interface[T] {
+	return NewUnrolledLinkedListOfSize(unrolledLinkedListNodeSize, items...)
+}
+
+// NewUnrolledLinkedListOfSize returns a new unrolled linked list instance with nodes of up to
+// nodeSize elements and the given items inserted in order. If nodeSize is less than 2, 2 is used.
+func NewUnrolledLinkedListOfSize[T comparable](nodeSize int, items ...T) Interface[T] {
+	newList := &UnrolledLinkedList[T]{nodeSize: max(nodeSize, 2)}
+	newList.PushBack(items...)
+	return newList
+}
+
+// Len returns the number of items in the list.
+func (ul *UnrolledLinkedList[T]) Len() int {
+	return ul.len
+}
+
+// Empty checks whether the list is empty.
+func (ul *UnrolledLinkedList[T]) Empty() bool {
+	return ul.head == nil
+}
+
+// Clear deletes all the items from the list.
+func (ul *UnrolledLinkedList[T]) Clear() {
+	for n := ul.head; n != nil; n = n.next {
+		for _, e := range n.elements {
+			e.Next, e.Prev, e.list = nil, nil, nil
+		}
+		n.list = nil
+	}
+
+	ul.head = nil
+	ul.tail = nil
+	ul.len = 0
+}
+
+// First returns the first element of the list.
+func (ul *UnrolledLinkedList[T]) First() *Element[T] {
+	if ul.head == nil {
+		return nil
+	}
+
+	return ul.head.elements[0]
+}
+
+// Last returns the last element of the list.
+func (ul *UnrolledLinkedList[T]) Last() *Element[T] {
+	if ul.tail == nil {
+		return nil
+	}
+
+	return ul.tail.elements[len(ul.tail.elements)-1]
+}
+
+// At returns the (i+1)th element of the list. Negative indices can also be used to find the (-i)th
+// last element.
+func (ul *UnrolledLinkedList[T]) At(i int) *Element[T] {
+	if i < 0 {
+		i += ul.len
+	}
+	if i < 0 || i >= ul.len {
+		return nil
+	}
+
+	n, idx := ul.locate(i)
+	return n.elements[idx]
+}
+
+// Contains checks whether the list contains all the given items.
+func (ul *UnrolledLinkedList[T]) Contains(items ...T) bool {
+	if len(items) == 0 {
+		return true
+	}
+	if ul.head == nil {
+		return false
+	}
+
+	itemsMap := make(map[T]struct{}, len(items))
+	for _, item := range items {
+		itemsMap[item] = struct{}{}
+	}
+
+	for n := ul.head; n != nil; n = n.next {
+		for _, e := range n.elements {
+			delete(itemsMap, e.Value)
+		}
+	}
+
+	return len(itemsMap) == 0
+}
+
+// Each iterates over the items of the list.
+func (ul *UnrolledLinkedList[T]) Each(fn func(T) bool) {
+	for n := ul.head; n != nil; n = n.next {
+		for _, e := range n.elements {
+			if fn(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Iterator returns a list.Iterable that can be used to iterate over the list.
+func (ul *UnrolledLinkedList[T]) Iterator() Iterable[T] {
+	return &doublyLinkedListIterable[T]{
+		curr: ul.First(),
+	}
+}
+
+// All returns an iterator over the items of the list from the first to the last. The current
+// element can be removed during iteration.
+func (ul *UnrolledLinkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var next *Element[T]
+		for curr := ul.First(); curr != nil; curr = next {
+			next = curr.Next
+			if !yield(curr.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the list from the last to the first. The current
+// element can be removed during iteration.
+func (ul *UnrolledLinkedList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var prev *Element[T]
+		for curr := ul.Last(); curr != nil; curr = prev {
+			prev = curr.Prev
+			if !yield(curr.Value) {
+				return
+			}
+		}
+	}
+}
+
+// PushFront adds the given items at the start of the list.
+func (ul *UnrolledLinkedList[T]) PushFront(items ...T) {
+	for _, item := range items {
+		ul.insert(ul.head, 0, ul.newElement(item))
+	}
+	ul.len += len(items)
+}
+
+// PopFront removes and returns the first element from the list. If the list is empty, it returns
+// nil.
+func (ul *UnrolledLinkedList[T]) PopFront() *Element[T] {
+	return ul.Remove(ul.First())
+}
+
+// PushBack adds the given items at the end of the list.
+func (ul *UnrolledLinkedList[T]) PushBack(items ...T) {
+	for _, item := range items {
+		if ul.tail == nil {
+			ul.insert(nil, 0, ul.newElement(item))
+		} else {
+			ul.insert(ul.tail, len(ul.tail.elements), ul.newElement(item))
+		}
+	}
+	ul.len += len(items)
+}
+
+// PopBack removes and returns the last element from the list. If the list is empty, it returns
+// nil.
+func (ul *UnrolledLinkedList[T]) PopBack() *Element[T] {
+	return ul.Remove(ul.Last())
+}
+
+// InsertAt adds the item as the (i+1)th element and returns the element. Negative indices can also
+// be used to insert after the (-i)th last element. If the list doesn't have enough elements, it
+// returns nil.
+func (ul *UnrolledLinkedList[T]) InsertAt(i int, item T) *Element[T] {
+	if ul.head == nil {
+		if i == 0 || i == -1 {
+			ul.PushFront(item)
+			return ul.First()
+		}
+
+		return nil
+	}
+	if i == 0 {
+		return ul.InsertBefore(ul.First(), item)
+	}
+	if i > 0 {
+		i--
+	}
+
+	return ul.InsertAfter(ul.At(i), item)
+}
+
+// InsertAfter adds the item after the given element and returns the inserted element. If inserting
+// after e is not possible, including when e doesn't belong to the list, it returns nil.
+func (ul *UnrolledLinkedList[T]) InsertAfter(e *Element[T], item T) *Element[T] {
+	if !ul.owns(e) {
+		return nil
+	}
+
+	n, idx := ul.position(e)
+	newEl := ul.newElement(item)
+	ul.insert(n, idx+1, newEl)
+	ul.len++
+	return newEl
+}
+
+// InsertBefore adds the item before the given element and returns the inserted element. If
+// inserting before e is not possible, including when e doesn't belong to the list, it returns nil.
+func (ul *UnrolledLinkedList[T]) InsertBefore(e *Element[T], item T) *Element[T] {
+	if !ul.owns(e) {
+		return nil
+	}
+
+	n, idx := ul.position(e)
+	newEl := ul.newElement(item)
+	ul.insert(n, idx, newEl)
+	ul.len++
+	return newEl
+}
+
+// RemoveAt removes the (i+1)th element. Negative indices can also be used to remove the (-i)th
+// last element. If the list doesn't have enough elements, it returns nil.
+func (ul *UnrolledLinkedList[T]) RemoveAt(i int) *Element[T] {
+	return ul.Remove(ul.At(i))
+}
+
+// Remove removes and returns the given element. If removing e is not possible, including when e
+// doesn't belong to the list, it returns nil.
+func (ul *UnrolledLinkedList[T]) Remove(e *Element[T]) *Element[T] {
+	if !ul.owns(e) {
+		return nil
+	}
+
+	ul.detach(e)
+	e.list = nil
+	ul.len--
+	return e
+}
+
+// RemoveAfter removes and returns the element after the given element. If removing after e is not
+// possible, including when e doesn't belong to the list, it returns nil.
+func (ul *UnrolledLinkedList[T]) RemoveAfter(e *Element[T]) *Element[T] {
+	if !ul.owns(e) {
+		return nil
+	}
+
+	return ul.Remove(e.Next)
+}
+
+// RemoveBefore removes and returns the element before the given element. If removing before e is
+// not possible, including when e doesn't belong to the list, it returns nil.
+func (ul *UnrolledLinkedList[T]) RemoveBefore(e *Element[T]) *Element[T] {
+	if !ul.owns(e) {
+		return nil
+	}
+
+	return ul.Remove(e.Prev)
+}
+
+// DeleteFirst deletes the first occurrence of the given items from the list. If the same item is
+// passed twice as an argument, only one occurrence is deleted in total.
+func (ul *UnrolledLinkedList[T]) DeleteFirst(items ...T) {
+	if len(items) == 0 || ul.head == nil {
+		return
+	}
+
+	itemsMap := make(map[T]struct{}, len(items))
+	for _, item := range items {
+		itemsMap[item] = struct{}{}
+	}
+
+	var next *Element[T]
+	for curr := ul.First(); curr != nil; curr = next {
+		next = curr.Next
+		if _, ok := itemsMap[curr.Value]; ok {
+			delete(itemsMap, curr.Value)
+			ul.Remove(curr)
+
+			if len(itemsMap) == 0 {
+				break
+			}
+		}
+	}
+}
+
+// Delete deletes the all occurrences of the given items from the list.
+func (ul *UnrolledLinkedList[T]) Delete(items ...T) {
+	if len(items) == 0 || ul.head == nil {
+		return
+	}
+
+	itemsMap := make(map[T]struct{}, len(items))
+	for _, item := range items {
+		itemsMap[item] = struct{}{}
+	}
+
+	var next *Element[T]
+	for curr := ul.First(); curr != nil; curr = next {
+		next = curr.Next
+		if _, ok := itemsMap[curr.Value]; ok {
+			ul.Remove(curr)
+		}
+	}
+}
+
+// MoveToFront moves the given element to the start of the list. It returns false if e doesn't
+// belong to the list.
+func (ul *UnrolledLinkedList[T]) MoveToFront(e *Element[T]) bool {
+	if !ul.owns(e) {
+		return false
+	}
+	if e != ul.First() {
+		ul.detach(e)
+		ul.insert(ul.head, 0, e)
+	}
+
+	return true
+}
+
+// MoveToBack moves the given element to the end of the list. It returns false if e doesn't belong
+// to the list.
+func (ul *UnrolledLinkedList[T]) MoveToBack(e *Element[T]) bool {
+	if !ul.owns(e) {
+		return false
+	}
+	if e != ul.Last() {
+		ul.detach(e)
+		ul.insert(ul.tail, len(ul.tail.elements), e)
+	}
+
+	return true
+}
+
+// MoveAfter moves the given element after mark. It returns false if e or mark doesn't belong to
+// the list. If e and mark are the same element, the list is not modified.
+func (ul *UnrolledLinkedList[T]) MoveAfter(e, mark *Element[T]) bool {
+	if !ul.owns(e) || !ul.owns(mark) {
+		return false
+	}
+	if e != mark && e != mark.Next {
+		ul.detach(e)
+		n, idx := ul.position(mark)
+		ul.insert(n, idx+1, e)
+	}
+
+	return true
+}
+
+// MoveBefore moves the given element before mark. It returns false if e or mark doesn't belong to
+// the list. If e and mark are the same element, the list is not modified.
+func (ul *UnrolledLinkedList[T]) MoveBefore(e, mark *Element[T]) bool {
+	if !ul.owns(e) || !ul.owns(mark) {
+		return false
+	}
+	if e != mark && e != mark.Prev {
+		ul.detach(e)
+		n, idx := ul.position(mark)
+		ul.insert(n, idx, e)
+	}
+
+	return true
+}
+
+// PushBackList moves all the elements of other to the end of the list, leaving other empty. If
+// other is an UnrolledLinkedList, its elements are spliced in and keep their identity. If other is
+// the list itself, the list is not modified.
+//
+// Splicing moves whole nodes and takes O(len(other)/B) time if both lists have the same node size,
+// or O(len(other)) time otherwise, as the nodes of other have to be regrouped.
+func (ul *UnrolledLinkedList[T]) PushBackList(other Interface[T]) {
+	otherList, ok := other.(*UnrolledLinkedList[T])
+	if !ok {
+		if other != nil {
+			ul.PushBack(drain(other)...)
+		}
+
+		return
+	}
+	if otherList == ul || otherList.head == nil {
+		return
+	}
+
+	head, tail := otherList.takeNodes(ul)
+	if ul.tail == nil {
+		ul.head = head
+	} else {
+		ul.linkNodes(ul.tail, head)
+	}
+
+	ul.tail = tail
+	ul.len += otherList.len
+	otherList.len = 0
+}
+
+// PushFrontList moves all the elements of other to the start of the list, in order, leaving other
+// empty. If other is an UnrolledLinkedList, its elements are spliced in and keep their identity. If
+// other is the list itself, the list is not modified.
+//
+// Splicing moves whole nodes and takes O(len(other)/B) time if both lists have the same node size,
+// or O(len(other)) time otherwise, as the nodes of other have to be regrouped.
+func (ul *UnrolledLinkedList[T]) PushFrontList(other Interface[T]) {
+	otherList, ok := other.(*UnrolledLinkedList[T])
+	if !ok {
+		if other != nil {
+			items := drain(other)
+			reverseItems(items)
+			ul.PushFront(items...)
+		}
+
+		return
+	}
+	if otherList == ul || otherList.head == nil {
+		return
+	}
+
+	head, tail := otherList.takeNodes(ul)
+	if ul.head == nil {
+		ul.tail = tail
+	} else {
+		ul.linkNodes(tail, ul.head)
+	}
+
+	ul.head = head
+	ul.len += otherList.len
+	otherList.len = 0
+}
+
+// Reverse reverses the order of the elements of the list in place.
+func (ul *UnrolledLinkedList[T]) Reverse() {
+	for n := ul.head; n != nil; n = n.prev {
+		slices.Reverse(n.elements)
+		for _, e := range n.elements {
+			e.Next, e.Prev = e.Prev, e.Next
+		}
+
+		n.next, n.prev = n.prev, n.next
+	}
+
+	ul.head, ul.tail = ul.tail, ul.head
+}
+
+// Rotate rotates the list by k positions, moving its last k elements to its start. Negative values
+// of k rotate the list in the other direction, moving its first -k elements to its end. Rotating by
+// a multiple of the length of the list doesn't modify it.
+func (ul *UnrolledLinkedList[T]) Rotate(k int) {
+	if ul.len < 2 {
+		return
+	}
+
+	k %= ul.len
+	if k < 0 {
+		k += ul.len
+	}
+	if k != 0 {
+		ul.PushFrontList(ul.SplitAt(-k))
+	}
+}
+
+// SplitAt removes the elements from the (i+1)th element onwards and returns them as a new list of
+// the same type. Negative indices can also be used to split off the last -i elements. If the list
+// doesn't have enough elements, it returns nil.
+func (ul *UnrolledLinkedList[T]) SplitAt(i int) Interface[T] {
+	if i < 0 {
+		i += ul.len
+	}
+	if i < 0 || i > ul.len {
+		return nil
+	}
+
+	newList := &UnrolledLinkedList[T]{nodeSize: ul.nodeSize}
+	if i == ul.len {
+		return newList
+	}
+
+	n, idx := ul.locate(i)
+	if idx > 0 {
+		n = ul.split(n, idx)
+	}
+
+	newList.head, newList.tail, newList.len = n, ul.tail, ul.len-i
+	ul.tail = n.prev
+	if ul.tail == nil {
+		ul.head = nil
+	} else {
+		ul.tail.next = nil
+		ul.tail.elements[len(ul.tail.elements)-1].Next = nil
+	}
+	n.prev = nil
+	n.elements[0].Prev = nil
+	ul.len = i
+
+	for curr := newList.head; curr != nil; curr = curr.next {
+		curr.list = newList
+	}
+
+	return newList
+}
+
+// Sort sorts the list in place in ascending order as determined by less, using a bottom-up merge
+// sort in O(n*log(n)) time. The sort is stable. The elements are relinked rather than copied, so
+// they keep their identity, and are then regrouped into full nodes.
+func (ul *UnrolledLinkedList[T]) Sort(less func(a, b T) bool) {
+	if ul.len < 2 {
+		return
+	}
+
+	head, _ := sortElements(ul.First(), less)
+
+	var prev *Element[T]
+	for curr := head; curr != nil; curr = curr.Next {
+		curr.Prev = prev
+		prev = curr
+	}
+
+	ul.regroup(head, ul.nodeSize)
+}
+
+// mergeSorted relinks the elements of other into the sorted list in sorted order, if other is an
+// UnrolledLinkedList, and leaves other empty. The elements are then regrouped into full nodes. It
+// returns false if other is of another type.
+func (ul *UnrolledLinkedList[T]) mergeSorted(other Interface[T], less func(a, b T) bool) bool {
+	otherList, ok := other.(*UnrolledLinkedList[T])
+	if !ok {
+		return false
+	}
+	if otherList.head == nil {
+		return true
+	}
+
+	head, _ := mergeElements(ul.First(), otherList.First(), less)
+
+	var prev *Element[T]
+	for curr := head; curr != nil; curr = curr.Next {
+		curr.Prev = prev
+		prev = curr
+	}
+
+	ul.len += otherList.len
+	otherList.head, otherList.tail, otherList.len = nil, nil, 0
+	ul.regroup(head, ul.nodeSize)
+	return true
+}
+
+// Copy creates a new copy of the list with the same node size.
+func (ul *UnrolledLinkedList[T]) Copy() Interface[T] {
+	newList := &UnrolledLinkedList[T]{nodeSize: ul.nodeSize}
+	ul.Each(func(item T) bool {
+		newList.PushBack(item)
+		return false
+	})
+
+	return newList
+}
+
+// Validate checks the internal consistency of the list: every node belongs to the list and holds
+// between 1 and the node size elements, every element belongs to the node holding it, the Next and
+// Prev links of neighbouring elements and nodes point to each other, the head and the tail are the
+// ends of the chain and the cached length matches the number of elements. It returns an error
+// describing the first inconsistency found, or nil if the list is consistent.
+func (ul *UnrolledLinkedList[T]) Validate() error {
+	if ul.head != nil && ul.head.prev != nil {
+		return errors.New("list: head node has a previous node")
+	}
+
+	count := 0
+	var prevNode *unrolledNode[T]
+	var prev *Element[T]
+	for n := ul.head; n != nil; n = n.next {
+		if n.list != ul {
+			return fmt.Errorf("list: node of element %d doesn't belong to the list", count)
+		}
+		if n.prev != prevNode {
+			return fmt.Errorf("list: node of element %d has an inconsistent previous link", count)
+		}
+		if len(n.elements) == 0 || len(n.elements) > ul.nodeSize {
+			return fmt.Errorf("list: node of element %d has %d elements", count, len(n.elements))
+		}
+
+		for _, e := range n.elements {
+			if e.list != n {
+				return fmt.Errorf("list: element %d doesn't belong to its node", count)
+			}
+			if e.Prev != prev || (prev != nil && prev.Next != e) {
+				return fmt.Errorf("list: element %d has an inconsistent previous link", count)
+			}
+
+			count++
+			prev = e
+		}
+
+		if count > ul.len {
+			return fmt.Errorf("list: more elements than the length %d", ul.len)
+		}
+		prevNode = n
+	}
+
+	if ul.tail != prevNode {
+		return errors.New("list: tail is not the last node")
+	}
+	if prev != nil && prev.Next != nil {
+		return errors.New("list: last element has a next element")
+	}
+	if count != ul.len {
+		return fmt.Errorf("list: length is %d, but found %d elements", ul.len, count)
+	}
+
+	return nil
+}
+
+// owns checks whether e is an element of the list.
+func (ul *UnrolledLinkedList[T]) owns(e *Element[T]) bool {
+	if e == nil {
+		return false
+	}
+
+	n, ok := e.list.(*unrolledNode[T])
+	return ok && n.list == ul
+}
+
+// newElement returns a new element of the list with the given item, allocated from the current
+// block of elements.
+func (ul *UnrolledLinkedList[T]) newElement(item T) *Element[T] {
+	if len(ul.block) == 0 {
+		ul.block = make([]Element[T], ul.nodeSize)
+	}
+
+	e := &ul.block[0]
+	ul.block = ul.block[1:]
+	e.Value = item
+	return e
+}
+
+// locate returns the node holding the (i+1)th element and the index of the element in the node.
+// The nodes are walked from whichever end of the list is closer.
+func (ul *UnrolledLinkedList[T]) locate(i int) (*unrolledNode[T], int) {
+	if i < ul.len/2 {
+		n := ul.head
+		for i >= len(n.elements) {
+			i -= len(n.elements)
+			n = n.next
+		}
+
+		return n, i
+	}
+
+	n := ul.tail
+	i = ul.len - 1 - i
+	for i >= len(n.elements) {
+		i -= len(n.elements)
+		n = n.prev
+	}
+
+	return n, len(n.elements) - 1 - i
+}
+
+// position returns the node holding e and the index of e in the node.
+func (ul *UnrolledLinkedList[T]) position(e *Element[T]) (*unrolledNode[T], int) {
+	n := e.list.(*unrolledNode[T])
+	return n, slices.Index(n.elements, e)
+}
+
+// insert links the unlinked element e at index idx of node n. If n is nil, the list must be empty
+// and a new node is created for e. A full node is split in half, except when inserting at the end
+// of the last node or at the start of the first node, where a new node is started instead so that
+// pushing fills whole nodes.
+func (ul *UnrolledLinkedList[T]) insert(n *unrolledNode[T], idx int, e *Element[T]) {
+	if n == nil {
+		n = ul.newNode()
+		ul.head, ul.tail = n, n
+	} else if len(n.elements) == ul.nodeSize {
+		switch {
+		case idx == len(n.elements) && n.next == nil:
+			n, idx = ul.insertNode(n, n.next), 0
+		case idx == 0 && n.prev == nil:
+			n = ul.insertNode(nil, n)
+		default:
+			half := len(n.elements) / 2
+			newNode := ul.split(n, half)
+			if idx > half {
+				n, idx = newNode, idx-half
+			}
+		}
+	}
+
+	var prev, next *Element[T]
+	if idx > 0 {
+		prev = n.elements[idx-1]
+	} else if n.prev != nil {
+		prev = n.prev.elements[len(n.prev.elements)-1]
+	}
+	if idx < len(n.elements) {
+		next = n.elements[idx]
+	} else if n.next != nil {
+		next = n.next.elements[0]
+	}
+
+	e.Prev, e.Next = prev, next
+	if prev != nil {
+		prev.Next = e
+	}
+	if next != nil {
+		next.Prev = e
+	}
+
+	n.elements = slices.Insert(n.elements, idx, e)
+	e.list = n
+}
+
+// detach unlinks e from its neighbours and its node without removing it from the list. A node left
+// empty is removed, and a node left less than half full is merged with a neighbour if they fit in
+// one node. It must be linked back using insert.
+func (ul *UnrolledLinkedList[T]) detach(e *Element[T]) {
+	n, idx := ul.position(e)
+	if e.Prev != nil {
+		e.Prev.Next = e.Next
+	}
+	if e.Next != nil {
+		e.Next.Prev = e.Prev
+	}
+	e.Prev = nil
+	e.Next = nil
+
+	n.elements = slices.Delete(n.elements, idx, idx+1)
+	switch {
+	case len(n.elements) == 0:
+		ul.removeNode(n)
+	case len(n.elements) < ul.nodeSize/2:
+		if n.next != nil && len(n.elements)+len(n.next.elements) <= ul.nodeSize {
+			ul.merge(n, n.next)
+		} else if n.prev != nil && len(n.prev.elements)+len(n.elements) <= ul.nodeSize {
+			ul.merge(n.prev, n)
+		}
+	}
+}
+
+// newNode returns a new empty node of the list that is not linked to any other node.
+func (ul *UnrolledLinkedList[T]) newNode() *unrolledNode[T] {
+	return &unrolledNode[T]{elements: make([]*Element[T], 0, ul.nodeSize), list: ul}
+}
+
+// insertNode links a new empty node between prev and next and returns it. Either of them can be
+// nil to insert the node at the start or the end of the list.
+func (ul *UnrolledLinkedList[T]) insertNode(prev, next *unrolledNode[T]) *unrolledNode[T] {
+	n := ul.newNode()
+	n.prev, n.next = prev, next
+	if prev == nil {
+		ul.head = n
+	} else {
+		prev.next = n
+	}
+	if next == nil {
+		ul.tail = n
+	} else {
+		next.prev = n
+	}
+
+	return n
+}
+
+// removeNode unlinks the node n from the list.
+func (ul *UnrolledLinkedList[T]) removeNode(n *unrolledNode[T]) {
+	if n.prev == nil {
+		ul.head = n.next
+	} else {
+		n.prev.next = n.next
+	}
+	if n.next == nil {
+		ul.tail = n.prev
+	} else {
+		n.next.prev = n.prev
+	}
+
+	n.prev, n.next, n.list = nil, nil, nil
+}
+
+// split moves the elements of node n from index idx onwards to a new node after n and returns the
+// new node. The links of the elements are not changed.
+func (ul *UnrolledLinkedList[T]) split(n *unrolledNode[T], idx int) *unrolledNode[T] {
+	newNode := ul.insertNode(n, n.next)
+	newNode.elements = append(newNode.elements, n.elements[idx:]...)
+	for _, e := range newNode.elements {
+		e.list = newNode
+	}
+
+	clear(n.elements[idx:])
+	n.elements = n.elements[:idx]
+	return newNode
+}
+
+// merge moves all the elements of next into n, which must be the node before it, and removes next.
+func (ul *UnrolledLinkedList[T]) merge(n, next *unrolledNode[T]) {
+	for _, e := range next.elements {
+		e.list = n
+	}
+
+	n.elements = append(n.elements, next.elements...)
+	ul.removeNode(next)
+}
+
+// linkNodes links the node next after the node prev, along with the last element of prev and the
+// first element of next.
+func (ul *UnrolledLinkedList[T]) linkNodes(prev, next *unrolledNode[T]) {
+	prev.next, next.prev = next, prev
+	last, first := prev.elements[len(prev.elements)-1], next.elements[0]
+	last.Next, first.Prev = first, last
+}
+
+// takeNodes removes all the nodes from the list, marks them as belonging to owner and returns the
+// first and the last of them. If the node sizes of the lists differ, the elements are regrouped
+// into nodes of the size of owner first. The length of the list is not changed.
+func (ul *UnrolledLinkedList[T]) takeNodes(owner *UnrolledLinkedList[T]) (*unrolledNode[T], *unrolledNode[T]) {
+	if ul.nodeSize != owner.nodeSize {
+		ul.regroup(ul.First(), owner.nodeSize)
+	}
+
+	head, tail := ul.head, ul.tail
+	for n := head; n != nil; n = n.next {
+		n.list = owner
+	}
+
+	ul.head, ul.tail = nil, nil
+	return head, tail
+}
+
+// regroup replaces the nodes of the list with full nodes of up to nodeSize elements holding the
+// chain of elements starting at first, in order.
+func (ul *UnrolledLinkedList[T]) regroup(first *Element[T], nodeSize int) {
+	ul.head, ul.tail = nil, nil
+
+	var n *unrolledNode[T]
+	for curr := first; curr != nil; curr = curr.Next {
+		if n == nil || len(n.elements) == nodeSize {
+			n = &unrolledNode[T]{elements: make([]*Element[T], 0, nodeSize), list: ul, prev: ul.tail}
+			if ul.tail == nil {
+				ul.head = n
+			} else {
+				ul.tail.next = n
+			}
+			ul.tail = n
+		}
+
+		n.elements = append(n.elements, curr)
+		curr.list = n
+	}
+}
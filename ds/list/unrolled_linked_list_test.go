@@ -0,0 +1,110 @@
+package list_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/list"
+)
+
+// newSmallUnrolledLinkedList returns an unrolled linked list with nodes of 3 elements, so that the
+// short lists of the tests span several nodes and exercise splitting and merging them.
+func newSmallUnrolledLinkedList(items ...int) list.Interface[int] {
+	return list.NewUnrolledLinkedListOfSize(3, items...)
+}
+
+func TestUnrolledLinkedList(t *testing.T) {
+	testInterfaceHelper(t, list.NewUnrolledLinkedList)
+}
+
+func TestUnrolledLinkedList_SmallNodes(t *testing.T) {
+	testInterfaceHelper(t, newSmallUnrolledLinkedList)
+}
+
+func TestUnrolledLinkedListOf(t *testing.T) {
+	testInterfaceOfHelper(t, list.NewUnrolledLinkedListOf[string])
+}
+
+func TestUnrolledLinkedList_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	newList := list.NewUnrolledLinkedListOfSize[int](4)
+	var expected []int
+	for n := 0; n < 3000; n++ {
+		switch op := r.Intn(10); {
+		case op < 4:
+			i := r.Intn(len(expected) + 1)
+			if el := newList.InsertAt(i, n); el == nil || el.Value != n {
+				t.Fatalf("Random: expected InsertAt %d to return Element with Value %d, got %v", i, n, el)
+			}
+
+			expected = append(expected[:i], append([]int{n}, expected[i:]...)...)
+		case op < 7 && len(expected) > 0:
+			i := r.Intn(len(expected))
+			if el := newList.RemoveAt(i); el == nil || el.Value != expected[i] {
+				t.Fatalf("Random: expected RemoveAt %d to return Element with Value %d, got %v", i, expected[i], el)
+			}
+
+			expected = append(expected[:i], expected[i+1:]...)
+		case op < 9 && len(expected) > 1:
+			i, j := r.Intn(len(expected)), r.Intn(len(expected))
+			if !newList.MoveAfter(newList.At(i), newList.At(j)) {
+				t.Fatalf("Random: expected MoveAfter %d, %d to return true, got false", i, j)
+			}
+
+			if i != j {
+				item := expected[i]
+				expected = append(expected[:i], expected[i+1:]...)
+				if i < j {
+					j--
+				}
+				expected = append(expected[:j+1], append([]int{item}, expected[j+1:]...)...)
+			}
+		default:
+			k := r.Intn(7) - 3
+			newList.Rotate(k)
+			if m := len(expected); m > 0 {
+				k = ((k % m) + m) % m
+				expected = append(expected[m-k:], expected[:m-k]...)
+			}
+		}
+
+		if err := newList.(*list.UnrolledLinkedList[int]).Validate(); err != nil {
+			t.Fatalf("Random: expected Validate to return nil after %d operations, got %v", n+1, err)
+		}
+	}
+
+	assertListValues(t, "Random", newList, expected)
+}
+
+func TestUnrolledLinkedList_Validate(t *testing.T) {
+	newList := list.NewUnrolledLinkedListOfSize(2, 4, 5, 6).(*list.UnrolledLinkedList[int])
+	if err := newList.Validate(); err != nil {
+		t.Errorf("Validate: expected Validate to return nil, got %v", err)
+	}
+
+	newList.At(1).Prev = nil
+	if err := newList.Validate(); err == nil {
+		t.Error("Validate: expected Validate to return an error for a broken Prev link, got nil")
+	}
+
+	newList = list.NewUnrolledLinkedListOfSize(2, 4, 5, 6).(*list.UnrolledLinkedList[int])
+	newList.Last().Next = list.NewUnrolledLinkedList(7).First()
+	if err := newList.Validate(); err == nil {
+		t.Error("Validate: expected Validate to return an error for a foreign Element, got nil")
+	}
+}
+
+func TestUnrolledLinkedList_PushListOther(t *testing.T) {
+	newList := newSmallUnrolledLinkedList(4, 5)
+	newList.PushBackList(list.NewDoublyLinkedList(6, 7))
+	newList.PushFrontList(list.NewSinglyLinkedList(2, 3))
+	assertListValues(t, "PushListOther", newList, []int{2, 3, 4, 5, 6, 7})
+
+	otherList := list.NewUnrolledLinkedList(8, 9, 10, 11)
+	el := otherList.At(2)
+	newList.PushBackList(otherList)
+	assertListValues(t, "PushListOther", newList, []int{2, 3, 4, 5, 6, 7, 8, 9, 10, 11})
+	if newList.At(8) != el {
+		t.Errorf("PushListOther: expected Elements of a list with another node size to keep their identity, got %v", newList.At(8))
+	}
+}
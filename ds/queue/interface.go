@@ -1,7 +1,9 @@
 package queue
 
+import "iter"
+
 // Interface is the interface that groups the basic methods of a queue implementation.
-type Interface interface {
+type Interface[T any] interface {
 	// Length returns the number of items in the queue.
 	Len() int
 
@@ -13,15 +15,19 @@ type Interface interface {
 
 	// Front returns the front/oldest enqueued element of the queue. If the queue is empty, second
 	// return value is false.
-	Front() (int, bool)
+	Front() (T, bool)
 
 	// Enqueue adds the items at the end of the queue.
-	Enqueue(items ...int)
+	Enqueue(items ...T)
 
 	// Dequeue removes the item from the front of the queue and returns it. If the queue is empty,
 	// second return value is false.
-	Dequeue() (int, bool)
+	Dequeue() (T, bool)
+
+	// All returns an iterator over the items of the queue in FIFO (First In First Out) order,
+	// without dequeuing them. The queue should not be modified during iteration.
+	All() iter.Seq[T]
 
 	// Copy creates a new copy of the queue.
-	Copy() Interface
+	Copy() Interface[T]
 }
@@ -1,12 +1,13 @@
 package queue_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/gpahal/go-algos/ds/queue"
 )
 
-func testInterfaceHelper(t *testing.T, newFn func(items ...int) queue.Interface) {
+func testInterfaceHelper(t *testing.T, newFn func(items ...int) queue.Interface[int]) {
 	t.Run("New", func(t *testing.T) {
 		newQueue := newFn(4, 5, 6)
 		if newQueue.Len() != 3 {
@@ -87,6 +88,25 @@ func testInterfaceHelper(t *testing.T, newFn func(items ...int) queue.Interface)
 		}
 	})
 
+	t.Run("All", func(t *testing.T) {
+		newQueue := newFn(4, 5, 6)
+		newQueue.Dequeue()
+		newQueue.Enqueue(7, 8)
+		got := slices.Collect(newQueue.All())
+		if !slicesEqual(got, []int{5, 6, 7, 8}) {
+			t.Errorf("All: expected values to be [5 6 7 8], got %v", got)
+		}
+
+		for item := range newQueue.All() {
+			if item != 5 {
+				t.Errorf("All: expected first value to be 5, got %d", item)
+			}
+			break
+		}
+
+		assertQueueValues(t, "All", newQueue, []int{5, 6, 7, 8})
+	})
+
 	t.Run("Copy", func(t *testing.T) {
 		newQueue := newFn(4, 5, 6)
 		copiedQueue := newQueue.Copy()
@@ -97,7 +117,7 @@ func testInterfaceHelper(t *testing.T, newFn func(items ...int) queue.Interface)
 	})
 }
 
-func assertQueueValues(t *testing.T, name string, q queue.Interface, expected []int) {
+func assertQueueValues(t *testing.T, name string, q queue.Interface[int], expected []int) {
 	t.Helper()
 
 	var got []int
@@ -129,3 +149,27 @@ func slicesEqual(arr1, arr2 []int) bool {
 
 	return true
 }
+
+func testInterfaceOfHelper(t *testing.T, newFn func(items ...string) queue.Interface[string]) {
+	newQueue := newFn("a", "b")
+	newQueue.Enqueue("c")
+	copiedQueue := newQueue.Copy()
+
+	var got []string
+	for {
+		val, ok := newQueue.Dequeue()
+		if !ok {
+			break
+		}
+
+		got = append(got, val)
+	}
+	if len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Errorf("Dequeue: expected Queue values to be [a b c], got %v", got)
+	}
+
+	val, ok := copiedQueue.Front()
+	if !ok || val != "a" {
+		t.Errorf("Front: expected Front to return (a, true), got (%s, %t)", val, ok)
+	}
+}
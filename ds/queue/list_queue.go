@@ -1,70 +1,85 @@
 package queue
 
 import (
+	"iter"
+
 	"github.com/gpahal/go-algos/ds/list"
 )
 
 // ListQueue represents a queue instance implemented as a singly linked list.
-type ListQueue struct {
-	l *list.SinglyLinkedList
+type ListQueue[T comparable] struct {
+	l *list.SinglyLinkedList[T]
+}
+
+// NewListQueue returns a new list queue instance of ints with the given items enqueued into it.
+func NewListQueue(items ...int) Interface[int] {
+	return NewListQueueOf(items...)
 }
 
-// NewListQueue returns a new list queue instance with the given items enqueued into it.
-func NewListQueue(items ...int) Interface {
-	q := &ListQueue{l: &list.SinglyLinkedList{}}
+// NewListQueueOf returns a new list queue instance with the given items enqueued into it.
+func NewListQueueOf[T comparable](items ...T) Interface[T] {
+	q := &ListQueue[T]{l: &list.SinglyLinkedList[T]{}}
 	q.Enqueue(items...)
 	return q
 }
 
 // Len returns the number of items in the queue.
-func (q *ListQueue) Len() int {
+func (q *ListQueue[T]) Len() int {
 	return q.l.Len()
 }
 
 // Empty checks whether the queue is empty.
-func (q *ListQueue) Empty() bool {
+func (q *ListQueue[T]) Empty() bool {
 	return q.l.Empty()
 }
 
 // Clear deletes all the items from the queue.
-func (q *ListQueue) Clear() {
+func (q *ListQueue[T]) Clear() {
 	q.l.Clear()
 }
 
 // Front returns the front/oldest enqueued element of the queue. If the queue is empty, second
 // return value is false.
-func (q *ListQueue) Front() (int, bool) {
+func (q *ListQueue[T]) Front() (T, bool) {
 	el := q.l.First()
 	if el == nil {
-		return 0, false
+		var zero T
+		return zero, false
 	}
 
 	return el.Value, true
 }
 
 // Enqueue adds the items at the end of the queue.
-func (q *ListQueue) Enqueue(items ...int) {
+func (q *ListQueue[T]) Enqueue(items ...T) {
 	q.l.PushBack(items...)
 }
 
 // Dequeue removes the item from the front of the queue and returns it. If the queue is empty,
 // second return value is false.
-func (q *ListQueue) Dequeue() (int, bool) {
+func (q *ListQueue[T]) Dequeue() (T, bool) {
 	el := q.l.PopFront()
 	if el == nil {
-		return 0, false
+		var zero T
+		return zero, false
 	}
 
 	return el.Value, true
 }
 
+// All returns an iterator over the items of the queue in FIFO (First In First Out) order, without
+// dequeuing them. The queue should not be modified during iteration.
+func (q *ListQueue[T]) All() iter.Seq[T] {
+	return q.l.All()
+}
+
 // Copy creates a new copy of the queue.
-func (q *ListQueue) Copy() Interface {
-	var arr []int
-	q.l.Each(func(item int) bool {
+func (q *ListQueue[T]) Copy() Interface[T] {
+	var arr []T
+	q.l.Each(func(item T) bool {
 		arr = append(arr, item)
 		return false
 	})
 
-	return NewListQueue(arr...)
+	return NewListQueueOf(arr...)
 }
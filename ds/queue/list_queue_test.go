@@ -9,3 +9,7 @@ import (
 func TestListQueue(t *testing.T) {
 	testInterfaceHelper(t, queue.NewListQueue)
 }
+
+func TestListQueueOf(t *testing.T) {
+	testInterfaceOfHelper(t, queue.NewListQueueOf[string])
+}
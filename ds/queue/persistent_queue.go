@@ -0,0 +1,187 @@
+package queue
+
+import (
+	"iter"
+
+	"github.com/gpahal/go-algos/ds/list"
+)
+
+// PersistentQueue represents an immutable queue. Enqueue and Dequeue return a new version of the
+// queue and leave the original unchanged, sharing the items they have in common with it.
+//
+// It is a Hood-Melville real-time queue: the items are kept in a front list and a reversed rear
+// list, and when the rear list grows longer than the front list, the rear list is reversed and
+// appended to the front list a few steps at a time over the following operations. Every operation
+// takes O(1) time in the worst case, not just amortized, so the bounds hold even when old versions
+// are reused. An amortized banker's queue would redo its expensive reversal every time the same
+// old version is dequeued from.
+//
+// Unlike the implementations of Interface, which need Copy to take a snapshot, a persistent queue
+// is its own snapshot: it can be kept or passed to other goroutines as is. The zero value is an
+// empty queue.
+type PersistentQueue[T any] struct {
+	front    list.PersistentList[T]
+	frontLen int
+	rear     list.PersistentList[T]
+	rearLen  int
+	rotation rotation[T]
+}
+
+type rotationState int
+
+const (
+	rotationIdle rotationState = iota
+	rotationReversing
+	rotationAppending
+	rotationDone
+)
+
+// rotation is the state of an incremental rotation of a persistent queue, which computes
+// front ++ reverse(rear) a few steps at a time.
+//
+// While reversing, front and rear are the parts of the lists left to reverse, and reversedFront
+// and reversedRear the reversed parts. While appending, the items of reversedFront are moved onto
+// reversedRear. valid is the number of items of reversedFront that haven't been dequeued from the
+// queue in the meantime and so still need to be appended. Once done, reversedRear is the new front
+// list.
+type rotation[T any] struct {
+	state         rotationState
+	valid         int
+	front         list.PersistentList[T]
+	reversedFront list.PersistentList[T]
+	rear          list.PersistentList[T]
+	reversedRear  list.PersistentList[T]
+}
+
+// NewPersistentQueue returns a new persistent queue of ints with the given items enqueued into it.
+func NewPersistentQueue(items ...int) PersistentQueue[int] {
+	return NewPersistentQueueOf(items...)
+}
+
+// NewPersistentQueueOf returns a new persistent queue with the given items enqueued into it.
+func NewPersistentQueueOf[T any](items ...T) PersistentQueue[T] {
+	return PersistentQueue[T]{}.Enqueue(items...)
+}
+
+// Len returns the number of items in the queue.
+func (q PersistentQueue[T]) Len() int {
+	return q.frontLen + q.rearLen
+}
+
+// Empty checks whether the queue is empty.
+func (q PersistentQueue[T]) Empty() bool {
+	return q.frontLen == 0
+}
+
+// Front returns the front/oldest enqueued element of the queue. If the queue is empty, second
+// return value is false.
+func (q PersistentQueue[T]) Front() (T, bool) {
+	return q.front.First()
+}
+
+// Enqueue returns a new queue with the items added at the end of the queue. The queue itself is
+// not modified.
+func (q PersistentQueue[T]) Enqueue(items ...T) PersistentQueue[T] {
+	for _, item := range items {
+		q.rear = q.rear.PushFront(item)
+		q.rearLen++
+		q = q.check()
+	}
+
+	return q
+}
+
+// Dequeue returns a new queue without the item at the front of the queue, and that item. If the
+// queue is empty, the last return value is false. The queue itself is not modified.
+func (q PersistentQueue[T]) Dequeue() (PersistentQueue[T], T, bool) {
+	item, ok := q.front.First()
+	if !ok {
+		return q, item, false
+	}
+
+	q.front = q.front.Rest()
+	q.frontLen--
+	q.rotation = q.rotation.invalidate()
+	return q.check(), item, true
+}
+
+// All returns an iterator over the items of the queue in FIFO (First In First Out) order.
+func (q PersistentQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for curr := q; !curr.Empty(); {
+			var item T
+			curr, item, _ = curr.Dequeue()
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// check starts a rotation if the rear list has become longer than the front list, and advances
+// the current rotation by two steps, which is enough for it to finish before the next one is
+// needed.
+func (q PersistentQueue[T]) check() PersistentQueue[T] {
+	if q.rearLen > q.frontLen {
+		q.rotation = rotation[T]{state: rotationReversing, front: q.front, rear: q.rear}
+		q.frontLen += q.rearLen
+		q.rear, q.rearLen = list.PersistentList[T]{}, 0
+	}
+
+	q.rotation = q.rotation.step().step()
+	if q.rotation.state == rotationDone {
+		q.front = q.rotation.reversedRear
+		q.rotation = rotation[T]{}
+	}
+
+	return q
+}
+
+// step advances the rotation by one step.
+func (r rotation[T]) step() rotation[T] {
+	switch r.state {
+	case rotationReversing:
+		item, _ := r.rear.First()
+		r.rear = r.rear.Rest()
+		r.reversedRear = r.reversedRear.PushFront(item)
+		if r.front.Empty() {
+			r.state = rotationAppending
+			return r
+		}
+
+		item, _ = r.front.First()
+		r.front = r.front.Rest()
+		r.reversedFront = r.reversedFront.PushFront(item)
+		r.valid++
+	case rotationAppending:
+		if r.valid == 0 {
+			r.state = rotationDone
+			return r
+		}
+
+		item, _ := r.reversedFront.First()
+		r.reversedFront = r.reversedFront.Rest()
+		r.reversedRear = r.reversedRear.PushFront(item)
+		r.valid--
+	}
+
+	return r
+}
+
+// invalidate records that an item has been dequeued from the queue during the rotation, so one
+// less item of the old front list has to be appended.
+func (r rotation[T]) invalidate() rotation[T] {
+	switch r.state {
+	case rotationReversing:
+		r.valid--
+	case rotationAppending:
+		if r.valid == 0 {
+			r.reversedRear = r.reversedRear.Rest()
+			r.state = rotationDone
+		} else {
+			r.valid--
+		}
+	}
+
+	return r
+}
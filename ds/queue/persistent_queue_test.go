@@ -0,0 +1,75 @@
+package queue_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/queue"
+)
+
+func TestPersistentQueue(t *testing.T) {
+	var empty queue.PersistentQueue[int]
+	if !empty.Empty() {
+		t.Errorf("Empty: expected the zero value to be empty, got Len %d", empty.Len())
+	}
+	if _, _, ok := empty.Dequeue(); ok {
+		t.Error("Dequeue: expected Dequeue of an empty queue to return false, got true")
+	}
+
+	q1 := queue.NewPersistentQueue(4, 5, 6)
+	q2 := q1.Enqueue(7)
+	q3, item, ok := q1.Dequeue()
+	if !ok || item != 4 {
+		t.Errorf("Dequeue: expected Dequeue to return 4, true, got %d, %v", item, ok)
+	}
+	if front, ok := q3.Front(); !ok || front != 5 {
+		t.Errorf("Front: expected Front to return 5, true, got %d, %v", front, ok)
+	}
+
+	assertPersistentQueueValues(t, "New", q1, []int{4, 5, 6})
+	assertPersistentQueueValues(t, "Enqueue", q2, []int{4, 5, 6, 7})
+	assertPersistentQueueValues(t, "Dequeue", q3, []int{5, 6})
+}
+
+func TestPersistentQueue_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	versions := []queue.PersistentQueue[int]{{}}
+	expected := [][]int{nil}
+	for n := 0; n < 3000; n++ {
+		// Operations are applied to random old versions, which must not be affected.
+		i := r.Intn(len(versions))
+		q, items := versions[i], expected[i]
+		if r.Intn(5) < 3 || len(items) == 0 {
+			q = q.Enqueue(n)
+			items = append(slices.Clone(items), n)
+		} else {
+			var item int
+			var ok bool
+			q, item, ok = q.Dequeue()
+			if !ok || item != items[0] {
+				t.Fatalf("Random: expected Dequeue to return %d, true, got %d, %v", items[0], item, ok)
+			}
+
+			items = items[1:]
+		}
+
+		versions = append(versions, q)
+		expected = append(expected, items)
+	}
+
+	for i := range versions {
+		assertPersistentQueueValues(t, "Random", versions[i], expected[i])
+	}
+}
+
+func assertPersistentQueueValues(t *testing.T, name string, q queue.PersistentQueue[int], expected []int) {
+	t.Helper()
+
+	if q.Len() != len(expected) {
+		t.Errorf("%s: expected Len to be %d, got %d", name, len(expected), q.Len())
+	}
+	if got := slices.Collect(q.All()); !slices.Equal(got, expected) {
+		t.Errorf("%s: expected Queue values to be %v, got %v", name, expected, got)
+	}
+}
@@ -1,35 +1,42 @@
 package queue
 
+import "iter"
+
 // SliceQueue represents a queue instance implemented using a slice used as a circular buffer.
-type SliceQueue struct {
-	arr   []int
+type SliceQueue[T any] struct {
+	arr   []T
 	start int
 	size  int
 }
 
-// NewSliceQueue returns a new slice queue instance with the given items enqueued into it.
-func NewSliceQueue(items ...int) Interface {
+// NewSliceQueue returns a new slice queue instance of ints with the given items enqueued into it.
+func NewSliceQueue(items ...int) Interface[int] {
+	return newSliceQueue(items...)
+}
+
+// NewSliceQueueOf returns a new slice queue instance with the given items enqueued into it.
+func NewSliceQueueOf[T any](items ...T) Interface[T] {
 	return newSliceQueue(items...)
 }
 
-func newSliceQueue(items ...int) *SliceQueue {
-	q := &SliceQueue{}
+func newSliceQueue[T any](items ...T) *SliceQueue[T] {
+	q := &SliceQueue[T]{}
 	q.Enqueue(items...)
 	return q
 }
 
 // Len returns the number of items in the queue.
-func (q *SliceQueue) Len() int {
+func (q *SliceQueue[T]) Len() int {
 	return q.size
 }
 
 // Empty checks whether the queue is empty.
-func (q *SliceQueue) Empty() bool {
+func (q *SliceQueue[T]) Empty() bool {
 	return q.size == 0
 }
 
 // Clear deletes all the items from the queue.
-func (q *SliceQueue) Clear() {
+func (q *SliceQueue[T]) Clear() {
 	q.arr = nil
 	q.start = 0
 	q.size = 0
@@ -37,16 +44,17 @@ func (q *SliceQueue) Clear() {
 
 // Front returns the front/oldest enqueued element of the queue. If the queue is empty, second
 // return value is false.
-func (q *SliceQueue) Front() (int, bool) {
+func (q *SliceQueue[T]) Front() (T, bool) {
 	if q.size == 0 {
-		return 0, false
+		var zero T
+		return zero, false
 	}
 
 	return q.arr[q.start], true
 }
 
 // Enqueue adds the items at the end of the queue.
-func (q *SliceQueue) Enqueue(items ...int) {
+func (q *SliceQueue[T]) Enqueue(items ...T) {
 	if len(items) == 0 {
 		return
 	}
@@ -85,9 +93,10 @@ func (q *SliceQueue) Enqueue(items ...int) {
 
 // Dequeue removes the item from the front of the queue and returns it. If the queue is empty,
 // second return value is false.
-func (q *SliceQueue) Dequeue() (int, bool) {
+func (q *SliceQueue[T]) Dequeue() (T, bool) {
 	if q.size == 0 {
-		return 0, false
+		var zero T
+		return zero, false
 	}
 
 	v := q.arr[q.start]
@@ -96,16 +105,28 @@ func (q *SliceQueue) Dequeue() (int, bool) {
 	return v, true
 }
 
+// All returns an iterator over the items of the queue in FIFO (First In First Out) order, without
+// dequeuing them. The queue should not be modified during iteration.
+func (q *SliceQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < q.size; i++ {
+			if !yield(q.arr[(q.start+i)%len(q.arr)]) {
+				return
+			}
+		}
+	}
+}
+
 // Copy creates a new copy of the queue.
-func (q *SliceQueue) Copy() Interface {
-	newQueue := newSliceQueue()
+func (q *SliceQueue[T]) Copy() Interface[T] {
+	newQueue := newSliceQueue[T]()
 	if q.size == 0 {
 		return newQueue
 	}
 
 	newQueue.start = 0
 	newQueue.size = q.size
-	newQueue.arr = make([]int, q.size)
+	newQueue.arr = make([]T, q.size)
 	if q.start+q.size > len(q.arr) {
 		copy(newQueue.arr[:len(q.arr)-q.start], q.arr[q.start:len(q.arr)])
 		copy(newQueue.arr[len(q.arr)-q.start:], q.arr[:q.start+q.size-len(q.arr)])
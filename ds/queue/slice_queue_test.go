@@ -9,3 +9,7 @@ import (
 func TestSliceQueue(t *testing.T) {
 	testInterfaceHelper(t, queue.NewSliceQueue)
 }
+
+func TestSliceQueueOf(t *testing.T) {
+	testInterfaceOfHelper(t, queue.NewSliceQueueOf[string])
+}
@@ -0,0 +1,387 @@
+package set
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// Bitset represents a set of non-negative ints implemented using a dynamic array of bits, where
+// the item i is in the set if the bit i is set. It uses one bit per possible item up to the
+// largest item, so it is much smaller and faster than a NativeSet for dense sets of small ints,
+// like node ids, but not for sparse sets with large items.
+//
+// Negative items can't be added to the set and are never contained in it. The items are always
+// iterated over in ascending order.
+//
+// The set algebra of two bitsets works a word of 64 bits at a time, and Union, Intersection,
+// Difference and the other helpers of this package use it when both sets are bitsets.
+type Bitset struct {
+	// words holds the bits of the set, 64 per word, without trailing zero words.
+	words []uint64
+}
+
+const wordBits = 64
+
+// NewBitset returns a new bitset instance with the given items added to it.
+func NewBitset(items ...int) *Bitset {
+	b := &Bitset{}
+	b.Add(items...)
+	return b
+}
+
+// Len returns the number of items in the set. It counts the set bits of every word, taking O(n/64)
+// time, where n is the largest item.
+func (b *Bitset) Len() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+
+	return count
+}
+
+// Empty checks whether the set is empty.
+func (b *Bitset) Empty() bool {
+	return len(b.words) == 0
+}
+
+// Clear deletes all the items from the set.
+func (b *Bitset) Clear() {
+	b.words = nil
+}
+
+// Contains checks whether the set contains all the given items.
+func (b *Bitset) Contains(items ...int) bool {
+	for _, item := range items {
+		if !b.has(item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Each iterates over the items of the set in ascending order.
+func (b *Bitset) Each(fn func(int) bool) {
+	for item := range b.All() {
+		if fn(item) {
+			break
+		}
+	}
+}
+
+// Iterator returns a set.Iterable that can be used to iterate over the set in ascending order.
+func (b *Bitset) Iterator() Iterable[int] {
+	return &bitsetIterable{b: b, current: -1}
+}
+
+// All returns an iterator over the items of the set in ascending order. Items can be added and
+// deleted during iteration: the iteration continues after the current item.
+func (b *Bitset) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for item := b.NextSetBit(0); item >= 0; item = b.NextSetBit(item + 1) {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Add adds the given items to the set. Negative items are ignored.
+func (b *Bitset) Add(items ...int) {
+	for _, item := range items {
+		if item < 0 {
+			continue
+		}
+
+		i := item / wordBits
+		if i >= len(b.words) {
+			b.words = append(b.words, make([]uint64, i+1-len(b.words))...)
+		}
+		b.words[i] |= 1 << (item % wordBits)
+	}
+}
+
+// Delete deletes the given items from the set.
+func (b *Bitset) Delete(items ...int) {
+	for _, item := range items {
+		if item < 0 || item/wordBits >= len(b.words) {
+			continue
+		}
+
+		b.words[item/wordBits] &^= 1 << (item % wordBits)
+	}
+	b.trim()
+}
+
+// Copy creates a new copy of the set.
+func (b *Bitset) Copy() Interface[int] {
+	return b.Clone()
+}
+
+// Clone creates a new copy of the set as a Bitset.
+func (b *Bitset) Clone() *Bitset {
+	return &Bitset{words: append([]uint64(nil), b.words...)}
+}
+
+// Min returns the smallest item of the set. If the set is empty, the second return value is false.
+func (b *Bitset) Min() (int, bool) {
+	item := b.NextSetBit(0)
+	return item, item >= 0
+}
+
+// Max returns the largest item of the set. If the set is empty, the second return value is false.
+func (b *Bitset) Max() (int, bool) {
+	if len(b.words) == 0 {
+		return 0, false
+	}
+
+	i := len(b.words) - 1
+	return i*wordBits + bits.Len64(b.words[i]) - 1, true
+}
+
+// NextSetBit returns the smallest item of the set that is greater than or equal to i. If there is
+// no such item, -1 is returned.
+func (b *Bitset) NextSetBit(i int) int {
+	i = max(i, 0)
+	wi := i / wordBits
+	if wi >= len(b.words) {
+		return -1
+	}
+
+	w := b.words[wi] >> (i % wordBits)
+	if w != 0 {
+		return i + bits.TrailingZeros64(w)
+	}
+
+	for wi++; wi < len(b.words); wi++ {
+		if b.words[wi] != 0 {
+			return wi*wordBits + bits.TrailingZeros64(b.words[wi])
+		}
+	}
+
+	return -1
+}
+
+// PrevSetBit returns the largest item of the set that is less than or equal to i. If there is no
+// such item, -1 is returned.
+func (b *Bitset) PrevSetBit(i int) int {
+	if i < 0 || len(b.words) == 0 {
+		return -1
+	}
+
+	wi := i / wordBits
+	if wi >= len(b.words) {
+		item, _ := b.Max()
+		return item
+	}
+
+	w := b.words[wi] << (wordBits - 1 - i%wordBits)
+	if w != 0 {
+		return i - bits.LeadingZeros64(w)
+	}
+
+	for wi--; wi >= 0; wi-- {
+		if b.words[wi] != 0 {
+			return wi*wordBits + bits.Len64(b.words[wi]) - 1
+		}
+	}
+
+	return -1
+}
+
+// NextClearBit returns the smallest non-negative int that is greater than or equal to i and is not
+// in the set.
+func (b *Bitset) NextClearBit(i int) int {
+	i = max(i, 0)
+	wi := i / wordBits
+	if wi >= len(b.words) {
+		return i
+	}
+
+	w := ^b.words[wi] >> (i % wordBits)
+	if w != 0 {
+		return i + bits.TrailingZeros64(w)
+	}
+
+	for wi++; wi < len(b.words); wi++ {
+		if b.words[wi] != ^uint64(0) {
+			return wi*wordBits + bits.TrailingZeros64(^b.words[wi])
+		}
+	}
+
+	return len(b.words) * wordBits
+}
+
+// Rank returns the number of items of the set that are less than i, which is the index of i in the
+// sorted items if it is in the set. It takes O(i/64) time.
+func (b *Bitset) Rank(i int) int {
+	if i <= 0 {
+		return 0
+	}
+
+	wi := i / wordBits
+	if wi >= len(b.words) {
+		return b.Len()
+	}
+
+	count := 0
+	for _, w := range b.words[:wi] {
+		count += bits.OnesCount64(w)
+	}
+
+	return count + bits.OnesCount64(b.words[wi]&(1<<(i%wordBits)-1))
+}
+
+// Select returns the item with the given index in the sorted items of the set, so that Rank of the
+// item is k. If k is out of range, the second return value is false. It takes O(n/64) time.
+func (b *Bitset) Select(k int) (int, bool) {
+	if k < 0 {
+		return 0, false
+	}
+
+	for wi, w := range b.words {
+		count := bits.OnesCount64(w)
+		if k >= count {
+			k -= count
+			continue
+		}
+
+		for ; k > 0; k-- {
+			w &= w - 1
+		}
+		return wi*wordBits + bits.TrailingZeros64(w), true
+	}
+
+	return 0, false
+}
+
+// UnionWith adds all the items of other to the set.
+func (b *Bitset) UnionWith(other *Bitset) {
+	if len(other.words) > len(b.words) {
+		b.words = append(b.words, make([]uint64, len(other.words)-len(b.words))...)
+	}
+
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+}
+
+// IntersectWith deletes all the items from the set that are not in other.
+func (b *Bitset) IntersectWith(other *Bitset) {
+	if len(b.words) > len(other.words) {
+		clear(b.words[len(other.words):])
+		b.words = b.words[:len(other.words)]
+	}
+
+	for i := range b.words {
+		b.words[i] &= other.words[i]
+	}
+	b.trim()
+}
+
+// DifferenceWith deletes all the items from the set that are also in other.
+func (b *Bitset) DifferenceWith(other *Bitset) {
+	for i := range min(len(b.words), len(other.words)) {
+		b.words[i] &^= other.words[i]
+	}
+	b.trim()
+}
+
+// SymmetricDifferenceWith deletes all the items from the set that are also in other and adds the
+// items of other that are not in the set.
+func (b *Bitset) SymmetricDifferenceWith(other *Bitset) {
+	if len(other.words) > len(b.words) {
+		b.words = append(b.words, make([]uint64, len(other.words)-len(b.words))...)
+	}
+
+	for i, w := range other.words {
+		b.words[i] ^= w
+	}
+	b.trim()
+}
+
+// Equal checks whether the set has the same items as other.
+func (b *Bitset) Equal(other *Bitset) bool {
+	if len(b.words) != len(other.words) {
+		return false
+	}
+
+	for i, w := range b.words {
+		if w != other.words[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b *Bitset) unionWith(other Interface[int]) bool {
+	o, ok := other.(*Bitset)
+	if ok {
+		b.UnionWith(o)
+	}
+	return ok
+}
+
+func (b *Bitset) intersectWith(other Interface[int]) bool {
+	o, ok := other.(*Bitset)
+	if ok {
+		b.IntersectWith(o)
+	}
+	return ok
+}
+
+func (b *Bitset) differenceWith(other Interface[int]) bool {
+	o, ok := other.(*Bitset)
+	if ok {
+		b.DifferenceWith(o)
+	}
+	return ok
+}
+
+// has checks whether the set contains the item.
+func (b *Bitset) has(item int) bool {
+	if item < 0 || item/wordBits >= len(b.words) {
+		return false
+	}
+
+	return b.words[item/wordBits]&(1<<(item%wordBits)) != 0
+}
+
+// trim removes the trailing zero words.
+func (b *Bitset) trim() {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	b.words = b.words[:n]
+}
+
+type bitsetIterable struct {
+	b       *Bitset
+	current int
+}
+
+func (it *bitsetIterable) Next() bool {
+	if it.current == -2 {
+		return false
+	}
+
+	next := it.b.NextSetBit(it.current + 1)
+	if next < 0 {
+		it.current = -2
+		return false
+	}
+
+	it.current = next
+	return true
+}
+
+func (it *bitsetIterable) Value() int {
+	if it.current < 0 {
+		return 0
+	}
+
+	return it.current
+}
@@ -0,0 +1,172 @@
+package set_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/set"
+)
+
+func TestNewBitset(t *testing.T) {
+	testInterfaceHelper(t, func(items ...int) set.Interface[int] {
+		return set.NewBitset(items...)
+	})
+}
+
+func TestBitset_Order(t *testing.T) {
+	b := set.NewBitset(130, 5, 64, -3, 0, 63, 5)
+	if got := slices.Collect(b.All()); !slices.Equal(got, []int{0, 5, 63, 64, 130}) {
+		t.Errorf("All: expected items in ascending order [0 5 63 64 130], got %v", got)
+	}
+	if b.Contains(-3) || b.Len() != 5 {
+		t.Errorf("Add: expected negative items to be ignored, got Len %d", b.Len())
+	}
+
+	var got []int
+	it := b.Iterator()
+	for it.Next() {
+		got = append(got, it.Value())
+	}
+	if !slices.Equal(got, []int{0, 5, 63, 64, 130}) {
+		t.Errorf("Iterator: expected items in ascending order [0 5 63 64 130], got %v", got)
+	}
+	if it.Next() || it.Value() != 0 {
+		t.Error("Iterator: expected Next to return false after the last item")
+	}
+
+	for item := range b.All() {
+		b.Delete(item + 1)
+		b.Delete(item)
+	}
+	if !b.Empty() {
+		t.Errorf("All: expected deleting during iteration to empty the set, got %v", slices.Collect(b.All()))
+	}
+}
+
+func TestBitset_Bits(t *testing.T) {
+	b := set.NewBitset(3, 64, 65, 200)
+	tests := []struct {
+		i, next, prev, nextClear, rank int
+	}{
+		{-1, 3, -1, 0, 0},
+		{0, 3, -1, 0, 0},
+		{3, 3, 3, 4, 0},
+		{4, 64, 3, 4, 1},
+		{64, 64, 64, 66, 1},
+		{66, 200, 65, 66, 3},
+		{200, 200, 200, 201, 3},
+		{201, -1, 200, 201, 4},
+		{1000, -1, 200, 1000, 4},
+	}
+	for _, test := range tests {
+		if got := b.NextSetBit(test.i); got != test.next {
+			t.Errorf("NextSetBit %d: expected %d, got %d", test.i, test.next, got)
+		}
+		if got := b.PrevSetBit(test.i); got != test.prev {
+			t.Errorf("PrevSetBit %d: expected %d, got %d", test.i, test.prev, got)
+		}
+		if got := b.NextClearBit(test.i); got != test.nextClear {
+			t.Errorf("NextClearBit %d: expected %d, got %d", test.i, test.nextClear, got)
+		}
+		if got := b.Rank(test.i); got != test.rank {
+			t.Errorf("Rank %d: expected %d, got %d", test.i, test.rank, got)
+		}
+	}
+
+	for k, expected := range []int{3, 64, 65, 200} {
+		if v, ok := b.Select(k); !ok || v != expected {
+			t.Errorf("Select %d: expected Select to return (%d, true), got (%d, %t)", k, expected, v, ok)
+		}
+	}
+	if _, ok := b.Select(4); ok {
+		t.Error("Select 4: expected Select to return false, got true")
+	}
+	if v, ok := b.Min(); !ok || v != 3 {
+		t.Errorf("Min: expected Min to return (3, true), got (%d, %t)", v, ok)
+	}
+	if v, ok := b.Max(); !ok || v != 200 {
+		t.Errorf("Max: expected Max to return (200, true), got (%d, %t)", v, ok)
+	}
+	if _, ok := set.NewBitset().Max(); ok {
+		t.Error("Max: expected Max of an empty set to return false, got true")
+	}
+}
+
+func TestBitset_Algebra(t *testing.T) {
+	a := set.NewBitset(1, 7, 8, 10, 12, 15, 300)
+	b := set.NewBitset(3, 7, 15, 18, 12, 8)
+
+	union := set.Union(a, b)
+	if _, ok := union.(*set.Bitset); !ok {
+		t.Errorf("Union: expected the union of bitsets to be a Bitset, got %T", union)
+	}
+	assertSetValues(t, "Union", union, makeSet(1, 3, 7, 8, 10, 12, 15, 18, 300))
+	assertSetValues(t, "Intersection", set.Intersection(a, b), makeSet(7, 8, 12, 15))
+	assertSetValues(t, "Difference", set.Difference(a, b), makeSet(1, 10, 300))
+	assertSetValues(t, "Difference", set.Difference(b, a), makeSet(3, 18))
+	assertSetValues(t, "SymmetricDifference", set.SymmetricDifference(a, b), makeSet(1, 3, 10, 18, 300))
+	assertSetValues(t, "Intersection", set.Intersection(a, set.NewNativeSet(1, 300, 5)), makeSet(1, 300))
+	assertSetValues(t, "Union", set.Union(set.NewNativeSet(-1), a), makeSet(-1, 1, 7, 8, 10, 12, 15, 300))
+
+	c := a.Clone()
+	c.SymmetricDifferenceWith(b)
+	if !c.Equal(set.NewBitset(1, 3, 10, 18, 300)) {
+		t.Errorf("SymmetricDifferenceWith: expected items [1 3 10 18 300], got %v", slices.Collect(c.All()))
+	}
+	c.IntersectWith(set.NewBitset(3))
+	if v, _ := c.Max(); v != 3 || c.Len() != 1 {
+		t.Errorf("IntersectWith: expected items [3], got %v", slices.Collect(c.All()))
+	}
+	if !set.AreEqual(a, a.Copy()) {
+		t.Error("Copy: expected the copy to be equal to the set")
+	}
+}
+
+func TestBitset_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	b := set.NewBitset()
+	other := set.NewBitset()
+	expected := set.NewNativeSet()
+	for n := 0; n < 3000; n++ {
+		v := r.Intn(500)
+		switch r.Intn(5) {
+		case 0, 1:
+			b.Add(v)
+			expected.Add(v)
+		case 2:
+			b.Delete(v)
+			expected.Delete(v)
+		case 3:
+			other.Add(v)
+		default:
+			switch r.Intn(3) {
+			case 0:
+				b.UnionWith(other)
+				set.MergeInto(expected, other)
+			case 1:
+				b.DifferenceWith(other)
+				set.SeparateFrom(expected, other)
+			default:
+				other.UnionWith(b)
+				b.IntersectWith(other)
+				set.RetainOnly(expected, other)
+			}
+			other.Clear()
+		}
+
+		if b.Len() != expected.Len() || !set.AreEqual(b, expected) {
+			t.Fatalf("Random: expected Len to be %d, got %d", expected.Len(), b.Len())
+		}
+	}
+
+	items := slices.Collect(b.All())
+	for k, item := range items {
+		if b.Rank(item) != k {
+			t.Errorf("Random: expected Rank of %d to be %d, got %d", item, k, b.Rank(item))
+		}
+		if v, _ := b.Select(k); v != item {
+			t.Errorf("Random: expected Select %d to be %d, got %d", k, item, v)
+		}
+	}
+}
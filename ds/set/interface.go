@@ -1,7 +1,9 @@
 package set
 
+import "iter"
+
 // Interface is the interface that groups the basic methods of a set implementation.
-type Interface interface {
+type Interface[T comparable] interface {
 	// Len returns the number of items in the set.
 	Len() int
 
@@ -12,27 +14,30 @@ type Interface interface {
 	Clear()
 
 	// Contains checks whether the set contains all the given items.
-	Contains(items ...int) bool
+	Contains(items ...T) bool
 
 	// Each iterates over the items of the set.
-	Each(fn func(int) bool)
+	Each(fn func(T) bool)
 
 	// Iterator returns a set.Iterable that can be used to iterate over the set.
-	Iterator() Iterable
+	Iterator() Iterable[T]
+
+	// All returns an iterator over the items of the set. The order of iteration is not specified.
+	All() iter.Seq[T]
 
 	// Add adds the given items to the set.
-	Add(items ...int)
+	Add(items ...T)
 
 	// Delete deletes the given items from the set.
-	Delete(items ...int)
+	Delete(items ...T)
 
 	// Copy creates a new copy of the set.
-	Copy() Interface
+	Copy() Interface[T]
 }
 
 // Iterable is the interface that groups the Next and Value methods used to iterate over a
 // set.Interface.
-type Iterable interface {
+type Iterable[T any] interface {
 	// Next prepares the next item for reading with the Value method. It returns true on success,
 	// or false if there are no items left, after which the Value method would always return the
 	// zero value.
@@ -41,17 +46,28 @@ type Iterable interface {
 	Next() bool
 
 	// Value reads and returns the item prepared by the Next method.
-	Value() int
+	Value() T
+}
+
+// algebra is implemented by the sets with fast paths for the set algebra, like Bitset. Each method
+// modifies the set in place and returns true if other is a set of a type it has a fast path for,
+// or returns false without modifying the set otherwise.
+type algebra[T comparable] interface {
+	Interface[T]
+
+	unionWith(other Interface[T]) bool
+	intersectWith(other Interface[T]) bool
+	differenceWith(other Interface[T]) bool
 }
 
 // AreEqual checks whether the two sets have the same items.
-func AreEqual(set1, set2 Interface) bool {
+func AreEqual[T comparable](set1, set2 Interface[T]) bool {
 	if set1.Len() != set2.Len() {
 		return false
 	}
 
 	equal := true
-	set1.Each(func(item int) bool {
+	set1.Each(func(item T) bool {
 		if !set2.Contains(item) {
 			equal = false
 			return true
@@ -64,13 +80,13 @@ func AreEqual(set1, set2 Interface) bool {
 }
 
 // IsSubset checks whether the first set is the subset of the second.
-func IsSubset(subSet, superSet Interface) bool {
+func IsSubset[T comparable](subSet, superSet Interface[T]) bool {
 	if subSet.Len() > superSet.Len() {
 		return false
 	}
 
 	subset := true
-	subSet.Each(func(item int) bool {
+	subSet.Each(func(item T) bool {
 		if !superSet.Contains(item) {
 			subset = false
 			return true
@@ -83,18 +99,18 @@ func IsSubset(subSet, superSet Interface) bool {
 }
 
 // IsSuperset checks whether the first set is the superset of the second.
-func IsSuperset(superSet, subSet Interface) bool {
+func IsSuperset[T comparable](superSet, subSet Interface[T]) bool {
 	return IsSubset(subSet, superSet)
 }
 
 // AreDisjoint checks whether the two sets are disjoint.
-func AreDisjoint(set1, set2 Interface) bool {
+func AreDisjoint[T comparable](set1, set2 Interface[T]) bool {
 	if set1.Len() == 0 {
 		return true
 	}
 
 	disjoint := true
-	set1.Each(func(item int) bool {
+	set1.Each(func(item T) bool {
 		if set2.Contains(item) {
 			disjoint = false
 			return true
@@ -107,16 +123,24 @@ func AreDisjoint(set1, set2 Interface) bool {
 }
 
 // MergeInto adds all the items of the second set to the first.
-func MergeInto(mainSet, otherSet Interface) {
-	otherSet.Each(func(item int) bool {
+func MergeInto[T comparable](mainSet, otherSet Interface[T]) {
+	if a, ok := mainSet.(algebra[T]); ok && a.unionWith(otherSet) {
+		return
+	}
+
+	otherSet.Each(func(item T) bool {
 		mainSet.Add(item)
 		return false
 	})
 }
 
 // RetainOnly deletes all the items from the first set that are not in the second.
-func RetainOnly(mainSet, otherSet Interface) {
-	mainSet.Each(func(item int) bool {
+func RetainOnly[T comparable](mainSet, otherSet Interface[T]) {
+	if a, ok := mainSet.(algebra[T]); ok && a.intersectWith(otherSet) {
+		return
+	}
+
+	mainSet.Each(func(item T) bool {
 		if !otherSet.Contains(item) {
 			mainSet.Delete(item)
 		}
@@ -126,17 +150,26 @@ func RetainOnly(mainSet, otherSet Interface) {
 }
 
 // SeparateFrom deletes all the items from the first set that are also in the second.
-func SeparateFrom(mainSet, otherSet Interface) {
-	otherSet.Each(func(item int) bool {
+func SeparateFrom[T comparable](mainSet, otherSet Interface[T]) {
+	if a, ok := mainSet.(algebra[T]); ok && a.differenceWith(otherSet) {
+		return
+	}
+
+	otherSet.Each(func(item T) bool {
 		mainSet.Delete(item)
 		return false
 	})
 }
 
 // Union returns a new set which is a union of the given sets. If no sets are provided, an empty
-// set is returned.
-func Union(sets ...Interface) Interface {
-	newSet := NewNativeSet()
+// set is returned. If all the sets have the same fast paths for the set algebra, like Bitset, the
+// new set has the same type, and otherwise it is a NativeSet, which can hold the items of any set.
+func Union[T comparable](sets ...Interface[T]) Interface[T] {
+	if newSet, ok := unionAlgebra(sets); ok {
+		return newSet
+	}
+
+	newSet := NewNativeSetOf[T]()
 	for _, set := range sets {
 		MergeInto(newSet, set)
 	}
@@ -144,11 +177,31 @@ func Union(sets ...Interface) Interface {
 	return newSet
 }
 
+// unionAlgebra returns the union of the sets using the fast paths of the first set. It returns
+// false if the first set has no fast paths or if any other set isn't supported by them.
+func unionAlgebra[T comparable](sets []Interface[T]) (Interface[T], bool) {
+	if len(sets) == 0 {
+		return nil, false
+	}
+	if _, ok := sets[0].(algebra[T]); !ok {
+		return nil, false
+	}
+
+	newSet := sets[0].Copy().(algebra[T])
+	for _, set := range sets[1:] {
+		if !newSet.unionWith(set) {
+			return nil, false
+		}
+	}
+
+	return newSet, true
+}
+
 // Intersection returns a new set which is an intersection of the given sets. If no sets are
 // provided, an empty set is returned.
-func Intersection(sets ...Interface) Interface {
+func Intersection[T comparable](sets ...Interface[T]) Interface[T] {
 	if len(sets) == 0 {
-		return NewNativeSet()
+		return NewNativeSetOf[T]()
 	}
 
 	newSet := sets[0].Copy()
@@ -162,9 +215,9 @@ func Intersection(sets ...Interface) Interface {
 
 // Difference returns a new set which is the first set minus all the other sets. If only one set
 // is provided, a copy of that set is returned. If no sets are provided, an empty set is returned.
-func Difference(sets ...Interface) Interface {
+func Difference[T comparable](sets ...Interface[T]) Interface[T] {
 	if len(sets) == 0 {
-		return NewNativeSet()
+		return NewNativeSetOf[T]()
 	}
 
 	newSet := sets[0].Copy()
@@ -177,6 +230,6 @@ func Difference(sets ...Interface) Interface {
 }
 
 // SymmetricDifference returns the symmetric difference of the two sets.
-func SymmetricDifference(set1, set2 Interface) Interface {
+func SymmetricDifference[T comparable](set1, set2 Interface[T]) Interface[T] {
 	return Union(Difference(set1, set2), Difference(set2, set1))
 }
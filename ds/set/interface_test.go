@@ -6,7 +6,7 @@ import (
 	"github.com/gpahal/go-algos/ds/set"
 )
 
-func testInterfaceHelper(t *testing.T, newFn func(items ...int) set.Interface) {
+func testInterfaceHelper(t *testing.T, newFn func(items ...int) set.Interface[int]) {
 	t.Run("New", func(t *testing.T) {
 		newSet := newFn(4, 5, 6)
 		if newSet.Len() != 3 {
@@ -162,6 +162,26 @@ func testInterfaceHelper(t *testing.T, newFn func(items ...int) set.Interface) {
 		}
 	})
 
+	t.Run("All", func(t *testing.T) {
+		newSet := newFn(4, 5, 6)
+		got := make(map[int]struct{})
+		for item := range newSet.All() {
+			got[item] = struct{}{}
+		}
+		if !mapsEqual(got, makeSet(4, 5, 6)) {
+			t.Errorf("All: expected values to be %v, got %v", makeSet(4, 5, 6), got)
+		}
+
+		count := 0
+		for range newSet.All() {
+			count++
+			break
+		}
+		if count != 1 {
+			t.Errorf("All: expected break to stop the iteration after 1 item, got %d", count)
+		}
+	})
+
 	t.Run("Copy", func(t *testing.T) {
 		newSet := newFn(4, 5, 6)
 		copiedSet := newSet.Copy()
@@ -287,7 +307,7 @@ func TestSymmetricDifference(t *testing.T) {
 	assertSetValues(t, "SymmetricDifference", gotDifferenceSet, makeSet(1, 10, -4, 3, -9, -2, 18))
 }
 
-func assertSetValues(t *testing.T, name string, s set.Interface, expected map[int]struct{}) {
+func assertSetValues(t *testing.T, name string, s set.Interface[int], expected map[int]struct{}) {
 	t.Helper()
 
 	m := make(map[int]struct{}, s.Len())
@@ -323,3 +343,35 @@ func makeSet(items ...int) map[int]struct{} {
 
 	return m
 }
+
+func TestUnionOf(t *testing.T) {
+	newSet1 := set.NewNativeSetOf("a", "b", "c")
+	newSet2 := set.NewNativeSetOf("c", "d")
+	gotUnionSet := set.Union(newSet1, newSet2)
+	if !set.AreEqual(gotUnionSet, set.NewNativeSetOf("a", "b", "c", "d")) {
+		t.Errorf("Union: expected Set values to be [a b c d], got %d values", gotUnionSet.Len())
+	}
+
+	gotIntersectionSet := set.Intersection(newSet1, newSet2)
+	if gotIntersectionSet.Len() != 1 || !gotIntersectionSet.Contains("c") {
+		t.Errorf("Intersection: expected Set values to be [c], got %d values", gotIntersectionSet.Len())
+	}
+}
+
+func TestUnion_Mixed(t *testing.T) {
+	bitset := set.NewBitset(1, 2)
+	native := set.NewNativeSet(-5, 3)
+	expected := makeSet(-5, 1, 2, 3)
+	assertSetValues(t, "Union Bitset, NativeSet", set.Union(bitset, native), expected)
+	assertSetValues(t, "Union NativeSet, Bitset", set.Union(native, bitset), expected)
+
+	if _, ok := set.Union(bitset, set.NewBitset(7)).(*set.Bitset); !ok {
+		t.Error("Union: expected union of bitsets to be a bitset")
+	}
+
+	bitset = set.NewBitset(1, 2, 3)
+	native = set.NewNativeSet(-5, 3)
+	expected = makeSet(-5, 1, 2)
+	assertSetValues(t, "SymmetricDifference Bitset, NativeSet", set.SymmetricDifference(bitset, native), expected)
+	assertSetValues(t, "SymmetricDifference NativeSet, Bitset", set.SymmetricDifference(native, bitset), expected)
+}
@@ -1,34 +1,41 @@
 package set
 
+import "iter"
+
 // NativeSet represents a set instance implemented using a native Go map.
-type NativeSet struct {
-	m map[int]struct{}
+type NativeSet[T comparable] struct {
+	m map[T]struct{}
+}
+
+// NewNativeSet returns a new native set of ints with the given items added to it.
+func NewNativeSet(items ...int) Interface[int] {
+	return NewNativeSetOf(items...)
 }
 
-// NewNativeSet returns a new native set with the given items added to it.
-func NewNativeSet(items ...int) Interface {
-	newSet := &NativeSet{m: nil}
+// NewNativeSetOf returns a new native set with the given items added to it.
+func NewNativeSetOf[T comparable](items ...T) Interface[T] {
+	newSet := &NativeSet[T]{m: nil}
 	newSet.Add(items...)
 	return newSet
 }
 
 // Len returns the number of items in the set.
-func (s *NativeSet) Len() int {
+func (s *NativeSet[T]) Len() int {
 	return len(s.m)
 }
 
 // Empty checks whether the set is empty.
-func (s *NativeSet) Empty() bool {
+func (s *NativeSet[T]) Empty() bool {
 	return len(s.m) == 0
 }
 
 // Clear deletes all the items from the set.
-func (s *NativeSet) Clear() {
+func (s *NativeSet[T]) Clear() {
 	s.m = nil
 }
 
 // Contains checks whether the set contains all the given items.
-func (s *NativeSet) Contains(items ...int) bool {
+func (s *NativeSet[T]) Contains(items ...T) bool {
 	if len(items) == 0 {
 		return true
 	}
@@ -47,7 +54,7 @@ func (s *NativeSet) Contains(items ...int) bool {
 }
 
 // Each iterates over the items of the set.
-func (s *NativeSet) Each(fn func(int) bool) {
+func (s *NativeSet[T]) Each(fn func(T) bool) {
 	for item := range s.m {
 		if fn(item) {
 			break
@@ -55,18 +62,30 @@ func (s *NativeSet) Each(fn func(int) bool) {
 	}
 }
 
+// All returns an iterator over the items of the set. The order of iteration is not specified. As
+// with maps, items can be deleted during iteration.
+func (s *NativeSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range s.m {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
 // Iterator returns a set.Iterable that can be used to iterate over the set.
-func (s *NativeSet) Iterator() Iterable {
-	return &nativeSetIterable{
+func (s *NativeSet[T]) Iterator() Iterable[T] {
+	return &nativeSetIterable[T]{
 		values:     s.values(),
 		currentIdx: -1,
 	}
 }
 
 // Add adds the given items to the set.
-func (s *NativeSet) Add(items ...int) {
+func (s *NativeSet[T]) Add(items ...T) {
 	if s.m == nil {
-		s.m = make(map[int]struct{}, len(items))
+		s.m = make(map[T]struct{}, len(items))
 	}
 
 	for _, item := range items {
@@ -75,7 +94,7 @@ func (s *NativeSet) Add(items ...int) {
 }
 
 // Delete deletes the given items from the set.
-func (s *NativeSet) Delete(items ...int) {
+func (s *NativeSet[T]) Delete(items ...T) {
 	if s.m == nil {
 		return
 	}
@@ -86,22 +105,22 @@ func (s *NativeSet) Delete(items ...int) {
 }
 
 // Copy creates a new copy of the set.
-func (s *NativeSet) Copy() Interface {
+func (s *NativeSet[T]) Copy() Interface[T] {
 	if s.m == nil {
-		return NewNativeSet()
+		return NewNativeSetOf[T]()
 	}
 
-	m := make(map[int]struct{}, len(s.m))
+	m := make(map[T]struct{}, len(s.m))
 	for item := range s.m {
 		m[item] = struct{}{}
 	}
 
-	return &NativeSet{m: m}
+	return &NativeSet[T]{m: m}
 }
 
 // values returns a slice of the items of the set.
-func (s *NativeSet) values() []int {
-	items := make([]int, len(s.m))
+func (s *NativeSet[T]) values() []T {
+	items := make([]T, len(s.m))
 	idx := 0
 	for item := range s.m {
 		items[idx] = item
@@ -111,12 +130,12 @@ func (s *NativeSet) values() []int {
 	return items
 }
 
-type nativeSetIterable struct {
-	values     []int
+type nativeSetIterable[T comparable] struct {
+	values     []T
 	currentIdx int
 }
 
-func (it *nativeSetIterable) Next() bool {
+func (it *nativeSetIterable[T]) Next() bool {
 	if it.currentIdx >= len(it.values)-1 {
 		return false
 	}
@@ -125,9 +144,10 @@ func (it *nativeSetIterable) Next() bool {
 	return true
 }
 
-func (it *nativeSetIterable) Value() int {
+func (it *nativeSetIterable[T]) Value() T {
 	if it.currentIdx < 0 || it.currentIdx >= len(it.values) {
-		return 0
+		var zero T
+		return zero
 	}
 
 	return it.values[it.currentIdx]
@@ -9,3 +9,15 @@ import (
 func TestNewNativeSet(t *testing.T) {
 	testInterfaceHelper(t, set.NewNativeSet)
 }
+
+func TestNewNativeSetOf(t *testing.T) {
+	type point struct{ x, y int }
+
+	newSet := set.NewNativeSetOf(point{1, 2}, point{3, 4}, point{1, 2})
+	if newSet.Len() != 2 {
+		t.Errorf("NewNativeSetOf: expected Len to be 2, got %d", newSet.Len())
+	}
+	if !newSet.Contains(point{3, 4}) || newSet.Contains(point{2, 1}) {
+		t.Errorf("NewNativeSetOf: expected Contains to compare items by value")
+	}
+}
@@ -0,0 +1,376 @@
+package set
+
+import (
+	"iter"
+	"math"
+	"slices"
+)
+
+// Roaring represents a set of uint32 items implemented as a Roaring compressed bitmap. The items
+// are split into chunks of 65536 by their high 16 bits, and the low 16 bits of the items of every
+// chunk are kept in a container chosen by the density of the chunk: a sorted array for sparse
+// chunks of up to 4096 items, a bitmap of 65536 bits for dense chunks, or, after RunOptimize, a
+// list of runs of consecutive items. This keeps large sparse sets of ids small while the set
+// algebra still works a container at a time.
+//
+// The items are always iterated over in ascending order. The set algebra of two Roaring bitmaps
+// works on the containers directly, and Union, Intersection, Difference and the other helpers of
+// this package use it when both sets are Roaring bitmaps.
+//
+// A Roaring bitmap can be serialized in the Roaring portable format, so it can be exchanged with
+// the Roaring implementations of other languages.
+type Roaring struct {
+	// keys holds the high 16 bits of the items of every container in ascending order.
+	keys       []uint16
+	containers []container
+}
+
+// NewRoaring returns a new Roaring bitmap instance with the given items added to it.
+func NewRoaring(items ...uint32) *Roaring {
+	r := &Roaring{}
+	r.Add(items...)
+	return r
+}
+
+// Len returns the number of items in the set. It takes O(n/65536) time, where n is the largest
+// item.
+func (r *Roaring) Len() int {
+	count := 0
+	for _, c := range r.containers {
+		count += c.card()
+	}
+
+	return count
+}
+
+// Empty checks whether the set is empty.
+func (r *Roaring) Empty() bool {
+	return len(r.keys) == 0
+}
+
+// Clear deletes all the items from the set.
+func (r *Roaring) Clear() {
+	r.keys = nil
+	r.containers = nil
+}
+
+// Contains checks whether the set contains all the given items.
+func (r *Roaring) Contains(items ...uint32) bool {
+	for _, item := range items {
+		i, ok := slices.BinarySearch(r.keys, high(item))
+		if !ok || !r.containers[i].has(low(item)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Each iterates over the items of the set in ascending order.
+func (r *Roaring) Each(fn func(uint32) bool) {
+	for item := range r.All() {
+		if fn(item) {
+			break
+		}
+	}
+}
+
+// Iterator returns a set.Iterable that can be used to iterate over the set in ascending order.
+func (r *Roaring) Iterator() Iterable[uint32] {
+	return &roaringIterable{r: r}
+}
+
+// All returns an iterator over the items of the set in ascending order. Items can be added and
+// deleted during iteration: the iteration continues after the current item.
+func (r *Roaring) All() iter.Seq[uint32] {
+	return func(yield func(uint32) bool) {
+		for item, ok := r.next(0); ok; item, ok = r.next(item + 1) {
+			if !yield(item) || item == math.MaxUint32 {
+				return
+			}
+		}
+	}
+}
+
+// Add adds the given items to the set.
+func (r *Roaring) Add(items ...uint32) {
+	for _, item := range items {
+		i, ok := slices.BinarySearch(r.keys, high(item))
+		if ok {
+			r.containers[i] = r.containers[i].add(low(item))
+			continue
+		}
+
+		r.keys = slices.Insert(r.keys, i, high(item))
+		r.containers = slices.Insert(r.containers, i, container(&arrayContainer{
+			items: []uint16{low(item)},
+		}))
+	}
+}
+
+// Delete deletes the given items from the set.
+func (r *Roaring) Delete(items ...uint32) {
+	for _, item := range items {
+		i, ok := slices.BinarySearch(r.keys, high(item))
+		if !ok {
+			continue
+		}
+
+		if c := r.containers[i].remove(low(item)); c != nil {
+			r.containers[i] = c
+		} else {
+			r.keys = slices.Delete(r.keys, i, i+1)
+			r.containers = slices.Delete(r.containers, i, i+1)
+		}
+	}
+}
+
+// Copy creates a new copy of the set.
+func (r *Roaring) Copy() Interface[uint32] {
+	return r.Clone()
+}
+
+// Clone creates a new copy of the set as a Roaring bitmap.
+func (r *Roaring) Clone() *Roaring {
+	containers := make([]container, len(r.containers))
+	for i, c := range r.containers {
+		containers[i] = c.clone()
+	}
+
+	return &Roaring{keys: slices.Clone(r.keys), containers: containers}
+}
+
+// Min returns the smallest item of the set. If the set is empty, the second return value is false.
+func (r *Roaring) Min() (uint32, bool) {
+	return r.next(0)
+}
+
+// Max returns the largest item of the set. If the set is empty, the second return value is false.
+func (r *Roaring) Max() (uint32, bool) {
+	if len(r.keys) == 0 {
+		return 0, false
+	}
+
+	i := len(r.keys) - 1
+	return joinItem(r.keys[i], r.containers[i].last()), true
+}
+
+// UnionWith adds all the items of other to the set.
+func (r *Roaring) UnionWith(other *Roaring) {
+	keys := make([]uint16, 0, len(r.keys)+len(other.keys))
+	containers := make([]container, 0, len(r.keys)+len(other.keys))
+	i, j := 0, 0
+	for i < len(r.keys) || j < len(other.keys) {
+		switch {
+		case j == len(other.keys) || (i < len(r.keys) && r.keys[i] < other.keys[j]):
+			keys = append(keys, r.keys[i])
+			containers = append(containers, r.containers[i])
+			i++
+		case i == len(r.keys) || r.keys[i] > other.keys[j]:
+			keys = append(keys, other.keys[j])
+			containers = append(containers, other.containers[j].clone())
+			j++
+		default:
+			keys = append(keys, r.keys[i])
+			containers = append(containers, unionContainers(r.containers[i], other.containers[j]))
+			i++
+			j++
+		}
+	}
+
+	r.keys = keys
+	r.containers = containers
+}
+
+// IntersectWith deletes all the items from the set that are not in other.
+func (r *Roaring) IntersectWith(other *Roaring) {
+	n := 0
+	for i, j := 0, 0; i < len(r.keys) && j < len(other.keys); {
+		switch {
+		case r.keys[i] < other.keys[j]:
+			i++
+		case r.keys[i] > other.keys[j]:
+			j++
+		default:
+			if c := intersectContainers(r.containers[i], other.containers[j]); c != nil {
+				r.keys[n] = r.keys[i]
+				r.containers[n] = c
+				n++
+			}
+			i++
+			j++
+		}
+	}
+
+	r.truncate(n)
+}
+
+// DifferenceWith deletes all the items from the set that are also in other.
+func (r *Roaring) DifferenceWith(other *Roaring) {
+	n := 0
+	for i, j := 0, 0; i < len(r.keys); i++ {
+		for j < len(other.keys) && other.keys[j] < r.keys[i] {
+			j++
+		}
+
+		c := r.containers[i]
+		if j < len(other.keys) && other.keys[j] == r.keys[i] {
+			c = differenceContainers(c, other.containers[j])
+		}
+		if c != nil {
+			r.keys[n] = r.keys[i]
+			r.containers[n] = c
+			n++
+		}
+	}
+
+	r.truncate(n)
+}
+
+// Equal checks whether the set has the same items as other.
+func (r *Roaring) Equal(other *Roaring) bool {
+	if !slices.Equal(r.keys, other.keys) {
+		return false
+	}
+
+	for i, c := range r.containers {
+		o := other.containers[i]
+		if c.card() != o.card() {
+			return false
+		}
+
+		for x, ok := c.next(0); ok; x, ok = c.next(x + 1) {
+			if !o.has(x) {
+				return false
+			}
+			if x == math.MaxUint16 {
+				break
+			}
+		}
+	}
+
+	return true
+}
+
+// RunOptimize converts every container to the smallest of the array, bitmap and run containers
+// for its items, which makes sets with long runs of consecutive items much smaller. Adding or
+// deleting an item of a run container converts it back to an array or a bitmap container. It
+// returns true if the set has any run containers afterward.
+func (r *Roaring) RunOptimize() bool {
+	hasRuns := false
+	for i, c := range r.containers {
+		card := c.card()
+		runsSize := runContainerSize(countRuns(c))
+		if runsSize < min(arrayContainerSize(card), bitmapContainerSize) {
+			r.containers[i] = toRuns(c)
+			hasRuns = true
+			continue
+		}
+
+		if _, ok := c.(*runContainer); ok {
+			r.containers[i] = c.bitmap().compact()
+		}
+	}
+
+	return hasRuns
+}
+
+func (r *Roaring) unionWith(other Interface[uint32]) bool {
+	o, ok := other.(*Roaring)
+	if ok {
+		r.UnionWith(o)
+	}
+	return ok
+}
+
+func (r *Roaring) intersectWith(other Interface[uint32]) bool {
+	o, ok := other.(*Roaring)
+	if ok {
+		r.IntersectWith(o)
+	}
+	return ok
+}
+
+func (r *Roaring) differenceWith(other Interface[uint32]) bool {
+	o, ok := other.(*Roaring)
+	if ok {
+		r.DifferenceWith(o)
+	}
+	return ok
+}
+
+// next returns the smallest item of the set that is greater than or equal to x.
+func (r *Roaring) next(x uint32) (uint32, bool) {
+	i, _ := slices.BinarySearch(r.keys, high(x))
+	for ; i < len(r.keys); i++ {
+		var lo uint16
+		if r.keys[i] == high(x) {
+			lo = low(x)
+		}
+
+		if v, ok := r.containers[i].next(lo); ok {
+			return joinItem(r.keys[i], v), true
+		}
+	}
+
+	return 0, false
+}
+
+// truncate keeps only the first n containers.
+func (r *Roaring) truncate(n int) {
+	clear(r.containers[n:])
+	r.keys = r.keys[:n]
+	r.containers = r.containers[:n]
+}
+
+func high(x uint32) uint16 {
+	return uint16(x >> 16)
+}
+
+func low(x uint32) uint16 {
+	return uint16(x)
+}
+
+func joinItem(key, lo uint16) uint32 {
+	return uint32(key)<<16 | uint32(lo)
+}
+
+type roaringIterable struct {
+	r       *Roaring
+	current uint32
+	started bool
+	done    bool
+}
+
+func (it *roaringIterable) Next() bool {
+	if it.done {
+		return false
+	}
+
+	var next uint32
+	ok := false
+	switch {
+	case !it.started:
+		next, ok = it.r.next(0)
+	case it.current < math.MaxUint32:
+		next, ok = it.r.next(it.current + 1)
+	}
+
+	if !ok {
+		it.done = true
+		it.current = 0
+		return false
+	}
+
+	it.started = true
+	it.current = next
+	return true
+}
+
+func (it *roaringIterable) Value() uint32 {
+	if it.done {
+		return 0
+	}
+
+	return it.current
+}
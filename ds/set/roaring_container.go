@@ -0,0 +1,421 @@
+package set
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// arrayMaxCard is the maximum number of items of an array container. An array container with more
+// items would be larger than a bitmap container.
+const arrayMaxCard = 4096
+
+// bitmapWords is the number of words of a bitmap container, which has one bit for each of the
+// 65536 items of a chunk.
+const bitmapWords = 1024
+
+// container holds the low 16 bits of the items of a Roaring bitmap that share the same high 16
+// bits. The methods that modify a container return the container to use instead, which might have
+// a different type, or nil if the container becomes empty.
+type container interface {
+	card() int
+	has(x uint16) bool
+	add(x uint16) container
+	remove(x uint16) container
+
+	// next returns the smallest item that is greater than or equal to x.
+	next(x uint16) (uint16, bool)
+
+	// last returns the largest item.
+	last() uint16
+
+	clone() container
+	bitmap() *bitmapContainer
+}
+
+// arrayContainer holds the items of a sparse chunk in a sorted slice.
+type arrayContainer struct {
+	items []uint16
+}
+
+func (c *arrayContainer) card() int {
+	return len(c.items)
+}
+
+func (c *arrayContainer) has(x uint16) bool {
+	_, ok := slices.BinarySearch(c.items, x)
+	return ok
+}
+
+func (c *arrayContainer) add(x uint16) container {
+	i, ok := slices.BinarySearch(c.items, x)
+	if ok {
+		return c
+	}
+	if len(c.items) >= arrayMaxCard {
+		return c.bitmap().add(x)
+	}
+
+	c.items = slices.Insert(c.items, i, x)
+	return c
+}
+
+func (c *arrayContainer) remove(x uint16) container {
+	i, ok := slices.BinarySearch(c.items, x)
+	if !ok {
+		return c
+	}
+	if len(c.items) == 1 {
+		return nil
+	}
+
+	c.items = slices.Delete(c.items, i, i+1)
+	return c
+}
+
+func (c *arrayContainer) next(x uint16) (uint16, bool) {
+	i, _ := slices.BinarySearch(c.items, x)
+	if i >= len(c.items) {
+		return 0, false
+	}
+
+	return c.items[i], true
+}
+
+func (c *arrayContainer) last() uint16 {
+	return c.items[len(c.items)-1]
+}
+
+func (c *arrayContainer) clone() container {
+	return &arrayContainer{items: slices.Clone(c.items)}
+}
+
+func (c *arrayContainer) bitmap() *bitmapContainer {
+	b := &bitmapContainer{n: len(c.items)}
+	for _, x := range c.items {
+		b.words[x/64] |= 1 << (x % 64)
+	}
+	return b
+}
+
+// bitmapContainer holds the items of a dense chunk in a bitmap.
+type bitmapContainer struct {
+	words [bitmapWords]uint64
+	n     int
+}
+
+func (c *bitmapContainer) card() int {
+	return c.n
+}
+
+func (c *bitmapContainer) has(x uint16) bool {
+	return c.words[x/64]&(1<<(x%64)) != 0
+}
+
+func (c *bitmapContainer) add(x uint16) container {
+	if !c.has(x) {
+		c.words[x/64] |= 1 << (x % 64)
+		c.n++
+	}
+	return c
+}
+
+func (c *bitmapContainer) remove(x uint16) container {
+	if !c.has(x) {
+		return c
+	}
+
+	c.words[x/64] &^= 1 << (x % 64)
+	c.n--
+	return c.compact()
+}
+
+func (c *bitmapContainer) next(x uint16) (uint16, bool) {
+	wi := int(x / 64)
+	if w := c.words[wi] >> (x % 64); w != 0 {
+		return x + uint16(bits.TrailingZeros64(w)), true
+	}
+
+	for wi++; wi < bitmapWords; wi++ {
+		if c.words[wi] != 0 {
+			return uint16(wi*64 + bits.TrailingZeros64(c.words[wi])), true
+		}
+	}
+
+	return 0, false
+}
+
+func (c *bitmapContainer) last() uint16 {
+	for wi := bitmapWords - 1; ; wi-- {
+		if c.words[wi] != 0 {
+			return uint16(wi*64 + bits.Len64(c.words[wi]) - 1)
+		}
+	}
+}
+
+func (c *bitmapContainer) clone() container {
+	b := *c
+	return &b
+}
+
+func (c *bitmapContainer) bitmap() *bitmapContainer {
+	return c
+}
+
+// recount recomputes the number of items after the words have been modified directly.
+func (c *bitmapContainer) recount() {
+	c.n = 0
+	for _, w := range c.words {
+		c.n += bits.OnesCount64(w)
+	}
+}
+
+// compact returns the container as an array container if it is small enough, or nil if it is
+// empty.
+func (c *bitmapContainer) compact() container {
+	switch {
+	case c.n == 0:
+		return nil
+	case c.n > arrayMaxCard:
+		return c
+	}
+
+	items := make([]uint16, 0, c.n)
+	for wi, w := range c.words {
+		for ; w != 0; w &= w - 1 {
+			items = append(items, uint16(wi*64+bits.TrailingZeros64(w)))
+		}
+	}
+	return &arrayContainer{items: items}
+}
+
+// runContainer holds the items of a chunk as sorted runs of consecutive items. It is only created
+// by RunOptimize and when reading a serialized bitmap, and it is converted to an array or a bitmap
+// container when it is modified.
+type runContainer struct {
+	runs []run
+}
+
+// run is the range of items from start to last, inclusive.
+type run struct {
+	start, last uint16
+}
+
+func (c *runContainer) card() int {
+	n := 0
+	for _, r := range c.runs {
+		n += int(r.last-r.start) + 1
+	}
+	return n
+}
+
+// find returns the index of the run containing x, or of the first run after x.
+func (c *runContainer) find(x uint16) int {
+	i, _ := slices.BinarySearchFunc(c.runs, x, func(r run, x uint16) int {
+		switch {
+		case r.last < x:
+			return -1
+		case r.start > x:
+			return 1
+		default:
+			return 0
+		}
+	})
+	return i
+}
+
+func (c *runContainer) has(x uint16) bool {
+	i := c.find(x)
+	return i < len(c.runs) && c.runs[i].start <= x
+}
+
+func (c *runContainer) add(x uint16) container {
+	if c.has(x) {
+		return c
+	}
+
+	return c.bitmap().compact().add(x)
+}
+
+func (c *runContainer) remove(x uint16) container {
+	if !c.has(x) {
+		return c
+	}
+
+	return c.bitmap().compact().remove(x)
+}
+
+func (c *runContainer) next(x uint16) (uint16, bool) {
+	i := c.find(x)
+	if i >= len(c.runs) {
+		return 0, false
+	}
+
+	return max(x, c.runs[i].start), true
+}
+
+func (c *runContainer) last() uint16 {
+	return c.runs[len(c.runs)-1].last
+}
+
+func (c *runContainer) clone() container {
+	return &runContainer{runs: slices.Clone(c.runs)}
+}
+
+func (c *runContainer) bitmap() *bitmapContainer {
+	b := &bitmapContainer{}
+	for _, r := range c.runs {
+		for x := int(r.start); x <= int(r.last); x++ {
+			b.words[x/64] |= 1 << (x % 64)
+		}
+	}
+	b.recount()
+	return b
+}
+
+// countRuns returns the number of runs of consecutive items in the container.
+func countRuns(c container) int {
+	switch c := c.(type) {
+	case *runContainer:
+		return len(c.runs)
+	case *arrayContainer:
+		n := 0
+		for i, x := range c.items {
+			if i == 0 || c.items[i-1]+1 != x {
+				n++
+			}
+		}
+		return n
+	default:
+		b := c.bitmap()
+		n := 0
+		for wi, w := range b.words {
+			// A run starts at every set bit whose previous bit is not set.
+			prev := w << 1
+			if wi > 0 {
+				prev |= b.words[wi-1] >> 63
+			}
+			n += bits.OnesCount64(w &^ prev)
+		}
+		return n
+	}
+}
+
+// toRuns returns the items of the container as runs.
+func toRuns(c container) *runContainer {
+	if r, ok := c.(*runContainer); ok {
+		return r
+	}
+
+	var runs []run
+	for x, ok := c.next(0); ok; {
+		r := run{start: x, last: x}
+		for r.last < 0xFFFF && c.has(r.last+1) {
+			r.last++
+		}
+		runs = append(runs, r)
+
+		if r.last == 0xFFFF {
+			break
+		}
+		x, ok = c.next(r.last + 1)
+	}
+	return &runContainer{runs: runs}
+}
+
+// unionContainers returns the union of the containers a and b, which are not modified.
+func unionContainers(a, b container) container {
+	if x, ok := a.(*arrayContainer); ok {
+		if y, ok := b.(*arrayContainer); ok && len(x.items)+len(y.items) <= arrayMaxCard {
+			return &arrayContainer{items: mergeUnion(x.items, y.items)}
+		}
+	}
+
+	result := a.bitmap()
+	if result == a {
+		result = &bitmapContainer{words: result.words}
+	}
+	other := b.bitmap()
+	for i := range result.words {
+		result.words[i] |= other.words[i]
+	}
+	result.recount()
+	return result.compact()
+}
+
+// intersectContainers returns the intersection of the containers a and b, or nil if it is empty.
+func intersectContainers(a, b container) container {
+	if _, ok := a.(*arrayContainer); !ok {
+		if _, ok := b.(*arrayContainer); ok {
+			a, b = b, a
+		}
+	}
+
+	if x, ok := a.(*arrayContainer); ok {
+		var items []uint16
+		for _, v := range x.items {
+			if b.has(v) {
+				items = append(items, v)
+			}
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return &arrayContainer{items: items}
+	}
+
+	result := &bitmapContainer{words: a.bitmap().words}
+	other := b.bitmap()
+	for i := range result.words {
+		result.words[i] &= other.words[i]
+	}
+	result.recount()
+	return result.compact()
+}
+
+// differenceContainers returns the items of the container a that are not in the container b, or
+// nil if there are none.
+func differenceContainers(a, b container) container {
+	if x, ok := a.(*arrayContainer); ok {
+		var items []uint16
+		for _, v := range x.items {
+			if !b.has(v) {
+				items = append(items, v)
+			}
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return &arrayContainer{items: items}
+	}
+
+	result := &bitmapContainer{words: a.bitmap().words}
+	other := b.bitmap()
+	for i := range result.words {
+		result.words[i] &^= other.words[i]
+	}
+	result.recount()
+	return result.compact()
+}
+
+// mergeUnion returns the sorted union of the sorted slices a and b.
+func mergeUnion(a, b []uint16) []uint16 {
+	items := make([]uint16, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			items = append(items, a[i])
+			i++
+		case a[i] > b[j]:
+			items = append(items, b[j])
+			j++
+		default:
+			items = append(items, a[i])
+			i++
+			j++
+		}
+	}
+
+	items = append(items, a[i:]...)
+	return append(items, b[j:]...)
+}
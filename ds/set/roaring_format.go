@@ -0,0 +1,300 @@
+package set
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The cookies that start a Roaring bitmap in the portable format. The cookie with run containers
+// also holds the number of containers minus one in its high 16 bits.
+const (
+	roaringCookieNoRuns = 12346
+	roaringCookie       = 12347
+)
+
+// roaringNoOffsetThreshold is the number of containers below which a serialized Roaring bitmap
+// with run containers has no offsets header.
+const roaringNoOffsetThreshold = 4
+
+// bitmapContainerSize is the serialized size of a bitmap container in bytes.
+const bitmapContainerSize = bitmapWords * 8
+
+// ErrInvalidFormat is returned when reading a Roaring bitmap from data that is not in the Roaring
+// portable format.
+var ErrInvalidFormat = errors.New("set: invalid roaring bitmap format")
+
+func arrayContainerSize(card int) int {
+	return 2 * card
+}
+
+func runContainerSize(runs int) int {
+	return 2 + 4*runs
+}
+
+// MarshalBinary returns the set serialized in the Roaring portable format. It implements the
+// encoding.BinaryMarshaler interface.
+func (r *Roaring) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := r.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the items of the set with the items of a Roaring bitmap serialized in
+// the Roaring portable format. It implements the encoding.BinaryUnmarshaler interface.
+func (r *Roaring) UnmarshalBinary(data []byte) error {
+	_, err := r.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+// WriteTo writes the set to w in the Roaring portable format and returns the number of bytes
+// written. It implements the io.WriterTo interface.
+//
+// Run containers are written as run containers, and the other containers are written as array
+// containers if they have up to 4096 items and as bitmap containers otherwise, as the format
+// requires.
+func (r *Roaring) WriteTo(w io.Writer) (int64, error) {
+	n := len(r.keys)
+	hasRuns := false
+	for _, c := range r.containers {
+		if _, ok := c.(*runContainer); ok {
+			hasRuns = true
+			break
+		}
+	}
+
+	var header []byte
+	if hasRuns {
+		header = binary.LittleEndian.AppendUint32(header, roaringCookie|uint32(n-1)<<16)
+		runFlags := make([]byte, (n+7)/8)
+		for i, c := range r.containers {
+			if _, ok := c.(*runContainer); ok {
+				runFlags[i/8] |= 1 << (i % 8)
+			}
+		}
+		header = append(header, runFlags...)
+	} else {
+		header = binary.LittleEndian.AppendUint32(header, roaringCookieNoRuns)
+		header = binary.LittleEndian.AppendUint32(header, uint32(n))
+	}
+
+	for i, c := range r.containers {
+		header = binary.LittleEndian.AppendUint16(header, r.keys[i])
+		header = binary.LittleEndian.AppendUint16(header, uint16(c.card()-1))
+	}
+
+	if !hasRuns || n >= roaringNoOffsetThreshold {
+		offset := len(header) + 4*n
+		for _, c := range r.containers {
+			header = binary.LittleEndian.AppendUint32(header, uint32(offset))
+			offset += serializedSize(c)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	written, _ := bw.Write(header)
+	for _, c := range r.containers {
+		buf := appendContainer(make([]byte, 0, serializedSize(c)), c)
+		m, _ := bw.Write(buf)
+		written += m
+	}
+
+	// The buffered writer keeps the first error, so it is enough to check it once.
+	if err := bw.Flush(); err != nil {
+		return int64(written - bw.Buffered()), err
+	}
+	return int64(written), nil
+}
+
+// ReadFrom replaces the items of the set with the items of a Roaring bitmap read from rd in the
+// Roaring portable format, and returns the number of bytes read. It reads exactly the bytes of
+// the bitmap, so more data can follow it in rd. It implements the io.ReaderFrom interface. If the
+// data is not a valid Roaring bitmap, an error wrapping ErrInvalidFormat is returned and the set is
+// left unchanged.
+func (r *Roaring) ReadFrom(rd io.Reader) (int64, error) {
+	cr := &countingReader{r: rd}
+	keys, containers, err := readRoaring(cr)
+	if err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			err = fmt.Errorf("%w: %w", ErrInvalidFormat, io.ErrUnexpectedEOF)
+		}
+		return cr.n, err
+	}
+
+	r.keys = keys
+	r.containers = containers
+	return cr.n, nil
+}
+
+func readRoaring(rd io.Reader) ([]uint16, []container, error) {
+	var cookie uint32
+	if err := binary.Read(rd, binary.LittleEndian, &cookie); err != nil {
+		return nil, nil, err
+	}
+
+	var n int
+	var runFlags []byte
+	switch {
+	case cookie == roaringCookieNoRuns:
+		var count uint32
+		if err := binary.Read(rd, binary.LittleEndian, &count); err != nil {
+			return nil, nil, err
+		}
+		if count > 1<<16 {
+			return nil, nil, fmt.Errorf("%w: too many containers: %d", ErrInvalidFormat, count)
+		}
+		n = int(count)
+	case cookie&0xFFFF == roaringCookie:
+		n = int(cookie>>16) + 1
+		runFlags = make([]byte, (n+7)/8)
+		if _, err := io.ReadFull(rd, runFlags); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, fmt.Errorf("%w: unknown cookie %d", ErrInvalidFormat, cookie)
+	}
+
+	header := make([]uint16, 2*n)
+	if err := binary.Read(rd, binary.LittleEndian, header); err != nil {
+		return nil, nil, err
+	}
+
+	if runFlags == nil || n >= roaringNoOffsetThreshold {
+		// The containers follow each other in order, so the offsets are not needed to read them.
+		if _, err := io.CopyN(io.Discard, rd, 4*int64(n)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	keys := make([]uint16, n)
+	containers := make([]container, n)
+	for i := range n {
+		keys[i] = header[2*i]
+		if i > 0 && keys[i] <= keys[i-1] {
+			return nil, nil, fmt.Errorf("%w: keys are not sorted", ErrInvalidFormat)
+		}
+
+		card := int(header[2*i+1]) + 1
+		isRun := runFlags != nil && runFlags[i/8]&(1<<(i%8)) != 0
+		c, err := readContainer(rd, card, isRun)
+		if err != nil {
+			return nil, nil, err
+		}
+		containers[i] = c
+	}
+
+	return keys, containers, nil
+}
+
+func readContainer(rd io.Reader, card int, isRun bool) (container, error) {
+	switch {
+	case isRun:
+		var count uint16
+		if err := binary.Read(rd, binary.LittleEndian, &count); err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("%w: empty run container", ErrInvalidFormat)
+		}
+
+		pairs := make([]uint16, 2*int(count))
+		if err := binary.Read(rd, binary.LittleEndian, pairs); err != nil {
+			return nil, err
+		}
+
+		runs := make([]run, count)
+		for i := range runs {
+			start, length := pairs[2*i], pairs[2*i+1]
+			if int(start)+int(length) > 0xFFFF {
+				return nil, fmt.Errorf("%w: run out of range", ErrInvalidFormat)
+			}
+			if i > 0 && int(start) <= int(runs[i-1].last)+1 {
+				return nil, fmt.Errorf("%w: runs are not sorted", ErrInvalidFormat)
+			}
+			runs[i] = run{start: start, last: start + length}
+		}
+
+		c := &runContainer{runs: runs}
+		if c.card() != card {
+			return nil, fmt.Errorf("%w: wrong run container cardinality", ErrInvalidFormat)
+		}
+		return c, nil
+	case card <= arrayMaxCard:
+		items := make([]uint16, card)
+		if err := binary.Read(rd, binary.LittleEndian, items); err != nil {
+			return nil, err
+		}
+		for i := 1; i < len(items); i++ {
+			if items[i] <= items[i-1] {
+				return nil, fmt.Errorf("%w: array container is not sorted", ErrInvalidFormat)
+			}
+		}
+		return &arrayContainer{items: items}, nil
+	default:
+		c := &bitmapContainer{}
+		if err := binary.Read(rd, binary.LittleEndian, c.words[:]); err != nil {
+			return nil, err
+		}
+		c.recount()
+		if c.n != card {
+			return nil, fmt.Errorf("%w: wrong bitmap container cardinality", ErrInvalidFormat)
+		}
+		return c, nil
+	}
+}
+
+// serializedSize returns the size of the container in the Roaring portable format in bytes.
+func serializedSize(c container) int {
+	if r, ok := c.(*runContainer); ok {
+		return runContainerSize(len(r.runs))
+	}
+	if card := c.card(); card <= arrayMaxCard {
+		return arrayContainerSize(card)
+	}
+	return bitmapContainerSize
+}
+
+// appendContainer appends the container in the Roaring portable format to buf.
+func appendContainer(buf []byte, c container) []byte {
+	if r, ok := c.(*runContainer); ok {
+		buf = binary.LittleEndian.AppendUint16(buf, uint16(len(r.runs)))
+		for _, rn := range r.runs {
+			buf = binary.LittleEndian.AppendUint16(buf, rn.start)
+			buf = binary.LittleEndian.AppendUint16(buf, rn.last-rn.start)
+		}
+		return buf
+	}
+
+	if c.card() <= arrayMaxCard {
+		for x, ok := c.next(0); ok; x, ok = c.next(x + 1) {
+			buf = binary.LittleEndian.AppendUint16(buf, x)
+			if x == 0xFFFF {
+				break
+			}
+		}
+		return buf
+	}
+
+	for _, w := range c.bitmap().words {
+		buf = binary.LittleEndian.AppendUint64(buf, w)
+	}
+	return buf
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
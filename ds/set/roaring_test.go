@@ -0,0 +1,334 @@
+package set_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/set"
+)
+
+// randomRoaringItems returns random items mixing sparse chunks, dense chunks and long runs, so
+// that the sets use all the container types.
+func randomRoaringItems(r *rand.Rand) []uint32 {
+	var items []uint32
+	for range 1 + r.Intn(4) {
+		key := uint32(r.Intn(8)) << 16
+		switch r.Intn(3) {
+		case 0:
+			for range r.Intn(200) {
+				items = append(items, key|uint32(r.Intn(1<<16)))
+			}
+		case 1:
+			for range 4000 + r.Intn(8000) {
+				items = append(items, key|uint32(r.Intn(1<<14)))
+			}
+		default:
+			start := r.Intn(1 << 16)
+			for x := start; x < min(start+r.Intn(20000), 1<<16); x++ {
+				items = append(items, key|uint32(x))
+			}
+		}
+	}
+	return items
+}
+
+func sortedKeys(m map[uint32]struct{}) []uint32 {
+	items := make([]uint32, 0, len(m))
+	for item := range m {
+		items = append(items, item)
+	}
+	slices.Sort(items)
+	return items
+}
+
+func assertRoaring(t *testing.T, name string, r *set.Roaring, expected map[uint32]struct{}) {
+	t.Helper()
+	if r.Len() != len(expected) {
+		t.Fatalf("%s: expected Len to be %d, got %d", name, len(expected), r.Len())
+	}
+	if got := slices.Collect(r.All()); !slices.Equal(got, sortedKeys(expected)) {
+		t.Fatalf("%s: expected items to be %d sorted items, got %d items", name, len(expected), len(got))
+	}
+}
+
+func TestNewRoaring(t *testing.T) {
+	r := set.NewRoaring()
+	if !r.Empty() || r.Len() != 0 {
+		t.Errorf("NewRoaring: expected set to be empty, got Len %d", r.Len())
+	}
+	if _, ok := r.Min(); ok {
+		t.Error("Min: expected Min to return false for an empty set")
+	}
+	if _, ok := r.Max(); ok {
+		t.Error("Max: expected Max to return false for an empty set")
+	}
+
+	r = set.NewRoaring(5, 1<<20, 3, math.MaxUint32, 5, 70000)
+	if got := slices.Collect(r.All()); !slices.Equal(got, []uint32{3, 5, 70000, 1 << 20, math.MaxUint32}) {
+		t.Errorf("All: expected items in ascending order, got %v", got)
+	}
+	if !r.Contains(3, 70000, math.MaxUint32) || r.Contains(4) || r.Contains(3, 4) {
+		t.Error("Contains: expected Contains to check all the items")
+	}
+	if v, ok := r.Min(); !ok || v != 3 {
+		t.Errorf("Min: expected Min to return (3, true), got (%d, %t)", v, ok)
+	}
+	if v, ok := r.Max(); !ok || v != math.MaxUint32 {
+		t.Errorf("Max: expected Max to return (%d, true), got (%d, %t)", uint32(math.MaxUint32), v, ok)
+	}
+
+	var got []uint32
+	it := r.Iterator()
+	for it.Next() {
+		got = append(got, it.Value())
+	}
+	if !slices.Equal(got, []uint32{3, 5, 70000, 1 << 20, math.MaxUint32}) {
+		t.Errorf("Iterator: expected items in ascending order, got %v", got)
+	}
+	if it.Next() || it.Value() != 0 {
+		t.Error("Iterator: expected Next to return false after the last item")
+	}
+
+	c := r.Copy()
+	r.Delete(3, 4, math.MaxUint32)
+	if r.Len() != 3 || c.Len() != 5 || !c.Contains(3) {
+		t.Errorf("Copy: expected copy to be independent, got Len %d and %d", r.Len(), c.Len())
+	}
+
+	for item := range r.All() {
+		r.Delete(item)
+	}
+	if !r.Empty() {
+		t.Errorf("All: expected deleting during iteration to empty the set, got %v", slices.Collect(r.All()))
+	}
+
+	r.Add(1, 2)
+	r.Clear()
+	if !r.Empty() || r.Contains(1) {
+		t.Error("Clear: expected set to be empty")
+	}
+}
+
+func TestRoaring_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	r := set.NewRoaring()
+	expected := make(map[uint32]struct{})
+	for round := range 20 {
+		for _, item := range randomRoaringItems(rnd) {
+			r.Add(item)
+			expected[item] = struct{}{}
+		}
+		assertRoaring(t, "Add", r, expected)
+
+		if round%3 == 0 {
+			r.RunOptimize()
+			assertRoaring(t, "RunOptimize", r, expected)
+		}
+
+		for _, item := range randomRoaringItems(rnd) {
+			r.Delete(item)
+			delete(expected, item)
+		}
+		assertRoaring(t, "Delete", r, expected)
+
+		for range 100 {
+			item := uint32(rnd.Intn(8 << 16))
+			_, ok := expected[item]
+			if r.Contains(item) != ok {
+				t.Fatalf("Contains %d: expected %t, got %t", item, ok, !ok)
+			}
+		}
+	}
+}
+
+func TestRoaring_Algebra(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	for range 20 {
+		a, b := set.NewRoaring(randomRoaringItems(rnd)...), set.NewRoaring(randomRoaringItems(rnd)...)
+		if rnd.Intn(2) == 0 {
+			a.RunOptimize()
+		}
+		if rnd.Intn(2) == 0 {
+			b.RunOptimize()
+		}
+
+		union := make(map[uint32]struct{})
+		intersection := make(map[uint32]struct{})
+		difference := make(map[uint32]struct{})
+		for item := range a.All() {
+			union[item] = struct{}{}
+			if b.Contains(item) {
+				intersection[item] = struct{}{}
+			} else {
+				difference[item] = struct{}{}
+			}
+		}
+		for item := range b.All() {
+			union[item] = struct{}{}
+		}
+
+		c := a.Clone()
+		c.UnionWith(b)
+		assertRoaring(t, "UnionWith", c, union)
+		c = a.Clone()
+		c.IntersectWith(b)
+		assertRoaring(t, "IntersectWith", c, intersection)
+		c = a.Clone()
+		c.DifferenceWith(b)
+		assertRoaring(t, "DifferenceWith", c, difference)
+
+		u, ok := set.Union[uint32](a, b).(*set.Roaring)
+		if !ok {
+			t.Fatal("Union: expected union of Roaring bitmaps to be a Roaring bitmap")
+		}
+		assertRoaring(t, "Union", u, union)
+		i, ok := set.Intersection[uint32](a, b).(*set.Roaring)
+		if !ok {
+			t.Fatal("Intersection: expected intersection of Roaring bitmaps to be a Roaring bitmap")
+		}
+		assertRoaring(t, "Intersection", i, intersection)
+
+		if !set.AreEqual(set.Union[uint32](a, set.NewNativeSetOf(slices.Collect(b.All())...)), u) {
+			t.Error("Union: expected union with a native set to have the same items")
+		}
+		if !c.Equal(set.NewRoaring(sortedKeys(difference)...)) || c.Equal(a) && len(intersection) > 0 {
+			t.Error("Equal: expected Equal to compare the items")
+		}
+	}
+}
+
+func TestRoaring_RunOptimize(t *testing.T) {
+	r := set.NewRoaring()
+	for x := uint32(100); x < 200000; x++ {
+		r.Add(x)
+	}
+	r.Add(300000)
+
+	before, _ := r.MarshalBinary()
+	if !r.RunOptimize() {
+		t.Fatal("RunOptimize: expected set of long runs to have run containers")
+	}
+	after, _ := r.MarshalBinary()
+	if len(after) >= len(before)/100 {
+		t.Errorf("RunOptimize: expected runs to be much smaller, got %d bytes before and %d after", len(before), len(after))
+	}
+	if r.Len() != 200000-100+1 || !r.Contains(100, 65535, 65536, 199999, 300000) || r.Contains(99, 200000) {
+		t.Errorf("RunOptimize: expected items to be unchanged, got Len %d", r.Len())
+	}
+
+	r.Delete(150000)
+	r.Add(99)
+	if r.Contains(150000) || !r.Contains(99, 149999, 150001) {
+		t.Error("RunOptimize: expected run containers to support Add and Delete")
+	}
+
+	r = set.NewRoaring(1, 5, 9)
+	if r.RunOptimize() {
+		t.Error("RunOptimize: expected set without runs to have no run containers")
+	}
+}
+
+func TestRoaring_Format(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    []uint32
+		optimize bool
+		data     []byte
+	}{
+		{
+			name: "empty",
+			data: []byte{0x3A, 0x30, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:  "arrays",
+			items: []uint32{1, 2, 1<<16 + 5},
+			data: []byte{
+				0x3A, 0x30, 0, 0, 2, 0, 0, 0, // cookie and number of containers
+				0, 0, 1, 0, 1, 0, 0, 0, // keys and cardinalities minus one
+				24, 0, 0, 0, 28, 0, 0, 0, // offsets
+				1, 0, 2, 0, // array container with key 0
+				5, 0, // array container with key 1
+			},
+		},
+		{
+			name:     "runs",
+			items:    []uint32{0, 1, 2, 3, 4, 10},
+			optimize: true,
+			data: []byte{
+				0x3B, 0x30, 0, 0, // cookie with 1 container
+				1,          // run flags
+				0, 0, 5, 0, // key and cardinality minus one
+				2, 0, 0, 0, 4, 0, 10, 0, 0, 0, // runs [0, 4] and [10, 10]
+			},
+		},
+	}
+
+	for _, test := range tests {
+		r := set.NewRoaring(test.items...)
+		if test.optimize {
+			r.RunOptimize()
+		}
+
+		data, err := r.MarshalBinary()
+		if err != nil || !bytes.Equal(data, test.data) {
+			t.Errorf("MarshalBinary %s: expected %v, got %v (error %v)", test.name, test.data, data, err)
+		}
+
+		got := set.NewRoaring(42)
+		if err := got.UnmarshalBinary(test.data); err != nil || !got.Equal(r) {
+			t.Errorf("UnmarshalBinary %s: expected items %v, got %v (error %v)", test.name, test.items, slices.Collect(got.All()), err)
+		}
+	}
+}
+
+func TestRoaring_RoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	for i := range 20 {
+		r := set.NewRoaring(randomRoaringItems(rnd)...)
+		if i%2 == 0 {
+			r.RunOptimize()
+		}
+
+		var buf bytes.Buffer
+		n, err := r.WriteTo(&buf)
+		if err != nil || n != int64(buf.Len()) {
+			t.Fatalf("WriteTo: expected to write %d bytes, got %d (error %v)", buf.Len(), n, err)
+		}
+		size := buf.Len()
+		buf.WriteString("trailing")
+
+		got := set.NewRoaring()
+		n, err = got.ReadFrom(&buf)
+		if err != nil || n != int64(size) {
+			t.Fatalf("ReadFrom: expected to read %d bytes, got %d (error %v)", size, n, err)
+		}
+		if !got.Equal(r) || buf.String() != "trailing" {
+			t.Fatal("ReadFrom: expected read set to be equal to the written set")
+		}
+	}
+}
+
+func TestRoaring_InvalidFormat(t *testing.T) {
+	valid, _ := set.NewRoaring(1, 2, 1<<16+5).MarshalBinary()
+	tests := map[string][]byte{
+		"empty data":     nil,
+		"unknown cookie": {1, 2, 3, 4, 0, 0, 0, 0},
+		"truncated":      valid[:len(valid)-1],
+		"unsorted keys":  {0x3A, 0x30, 0, 0, 2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 24, 0, 0, 0, 26, 0, 0, 0, 1, 0, 1, 0},
+		"unsorted array": {0x3A, 0x30, 0, 0, 1, 0, 0, 0, 0, 0, 1, 0, 16, 0, 0, 0, 2, 0, 1, 0},
+	}
+
+	for name, data := range tests {
+		r := set.NewRoaring(7)
+		if err := r.UnmarshalBinary(data); !errors.Is(err, set.ErrInvalidFormat) {
+			t.Errorf("UnmarshalBinary %s: expected ErrInvalidFormat, got %v", name, err)
+		}
+		if !r.Equal(set.NewRoaring(7)) {
+			t.Errorf("UnmarshalBinary %s: expected set to be unchanged", name)
+		}
+	}
+}
@@ -0,0 +1,492 @@
+package set
+
+import (
+	"cmp"
+	"iter"
+)
+
+// SortedSet represents a set of ordered items implemented as a left-leaning red-black tree. The
+// items are always iterated over in ascending order, so unlike a NativeSet its output is
+// deterministic. Every node also keeps the size of its subtree, so besides lookups, insertions and
+// deletions, the positional operations like IndexOf and Select take O(log(n)) time too.
+//
+// The items are ordered by cmp.Compare, so NaNs are equal to each other and less than any other
+// float.
+type SortedSet[T cmp.Ordered] struct {
+	root *sortedSetNode[T]
+}
+
+type sortedSetNode[T cmp.Ordered] struct {
+	item        T
+	left, right *sortedSetNode[T]
+	red         bool
+
+	// size is the number of nodes in the subtree rooted at the node.
+	size int
+}
+
+// NewSortedSet returns a new sorted set of ints with the given items added to it.
+func NewSortedSet(items ...int) *SortedSet[int] {
+	return NewSortedSetOf(items...)
+}
+
+// NewSortedSetOf returns a new sorted set with the given items added to it.
+func NewSortedSetOf[T cmp.Ordered](items ...T) *SortedSet[T] {
+	s := &SortedSet[T]{}
+	s.Add(items...)
+	return s
+}
+
+// Len returns the number of items in the set.
+func (s *SortedSet[T]) Len() int {
+	return treeSize(s.root)
+}
+
+// Empty checks whether the set is empty.
+func (s *SortedSet[T]) Empty() bool {
+	return s.root == nil
+}
+
+// Clear deletes all the items from the set.
+func (s *SortedSet[T]) Clear() {
+	s.root = nil
+}
+
+// Contains checks whether the set contains all the given items.
+func (s *SortedSet[T]) Contains(items ...T) bool {
+	for _, item := range items {
+		if s.find(item) == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Each iterates over the items of the set in ascending order.
+func (s *SortedSet[T]) Each(fn func(T) bool) {
+	for item := range s.All() {
+		if fn(item) {
+			break
+		}
+	}
+}
+
+// Iterator returns a set.Iterable that can be used to iterate over the set in ascending order.
+func (s *SortedSet[T]) Iterator() Iterable[T] {
+	return &sortedSetIterable[T]{s: s}
+}
+
+// All returns an iterator over the items of the set in ascending order. Items can be added and
+// deleted during iteration: the iteration continues after the current item. Every step searches
+// the tree for the next item, so iterating over the whole set takes O(n*log(n)) time.
+func (s *SortedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := s.min(); n != nil; {
+			// Deleting an item can move another item into its node, so the item is kept to find
+			// the next one.
+			item := n.item
+			if !yield(item) {
+				return
+			}
+			n = s.higher(item)
+		}
+	}
+}
+
+// Add adds the given items to the set.
+func (s *SortedSet[T]) Add(items ...T) {
+	for _, item := range items {
+		s.root = s.insert(s.root, item)
+		s.root.red = false
+	}
+}
+
+// Delete deletes the given items from the set.
+func (s *SortedSet[T]) Delete(items ...T) {
+	for _, item := range items {
+		if s.find(item) == nil {
+			continue
+		}
+
+		if !isRed(s.root.left) && !isRed(s.root.right) {
+			s.root.red = true
+		}
+		s.root = s.delete(s.root, item)
+		if s.root != nil {
+			s.root.red = false
+		}
+	}
+}
+
+// Copy creates a new copy of the set.
+func (s *SortedSet[T]) Copy() Interface[T] {
+	return s.Clone()
+}
+
+// Clone creates a new copy of the set as a SortedSet. It copies the tree as is, taking O(n) time.
+func (s *SortedSet[T]) Clone() *SortedSet[T] {
+	return &SortedSet[T]{root: cloneNode(s.root)}
+}
+
+// Min returns the smallest item of the set. If the set is empty, the second return value is false.
+func (s *SortedSet[T]) Min() (T, bool) {
+	return nodeItem(s.min())
+}
+
+// Max returns the largest item of the set. If the set is empty, the second return value is false.
+func (s *SortedSet[T]) Max() (T, bool) {
+	n := s.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+
+	return nodeItem(n)
+}
+
+// Floor returns the largest item of the set less than or equal to the given item. If there is no
+// such item, the second return value is false.
+func (s *SortedSet[T]) Floor(item T) (T, bool) {
+	var floor *sortedSetNode[T]
+	for n := s.root; n != nil; {
+		switch c := cmp.Compare(item, n.item); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			floor = n
+			n = n.right
+		default:
+			return n.item, true
+		}
+	}
+
+	return nodeItem(floor)
+}
+
+// Ceiling returns the smallest item of the set greater than or equal to the given item. If there
+// is no such item, the second return value is false.
+func (s *SortedSet[T]) Ceiling(item T) (T, bool) {
+	var ceiling *sortedSetNode[T]
+	for n := s.root; n != nil; {
+		switch c := cmp.Compare(item, n.item); {
+		case c < 0:
+			ceiling = n
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.item, true
+		}
+	}
+
+	return nodeItem(ceiling)
+}
+
+// Range returns an iterator over the items of the set in the range [from, to) in ascending order.
+// Like All, items can be added and deleted during iteration.
+func (s *SortedSet[T]) Range(from, to T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		n := s.find(from)
+		if n == nil {
+			n = s.higher(from)
+		}
+
+		for n != nil && cmp.Less(n.item, to) {
+			item := n.item
+			if !yield(item) {
+				return
+			}
+			n = s.higher(item)
+		}
+	}
+}
+
+// Rank returns the number of items of the set less than the given item. If the item is in the set,
+// it is the index of the item in ascending order.
+func (s *SortedSet[T]) Rank(item T) int {
+	rank := 0
+	for n := s.root; n != nil; {
+		switch c := cmp.Compare(item, n.item); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			rank += treeSize(n.left) + 1
+			n = n.right
+		default:
+			return rank + treeSize(n.left)
+		}
+	}
+
+	return rank
+}
+
+// IndexOf returns the index of the given item in the items of the set in ascending order. If the
+// item is not in the set, -1 is returned.
+func (s *SortedSet[T]) IndexOf(item T) int {
+	if s.find(item) == nil {
+		return -1
+	}
+
+	return s.Rank(item)
+}
+
+// Select returns the item with the given index in the items of the set in ascending order, so that
+// IndexOf of the item is k. If k is out of range, the second return value is false.
+func (s *SortedSet[T]) Select(k int) (T, bool) {
+	if k < 0 || k >= s.Len() {
+		return nodeItem[T](nil)
+	}
+
+	n := s.root
+	for {
+		left := treeSize(n.left)
+		switch {
+		case k < left:
+			n = n.left
+		case k > left:
+			k -= left + 1
+			n = n.right
+		default:
+			return n.item, true
+		}
+	}
+}
+
+// find returns the node of the given item, or nil if the item is not in the set.
+func (s *SortedSet[T]) find(item T) *sortedSetNode[T] {
+	for n := s.root; n != nil; {
+		switch c := cmp.Compare(item, n.item); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+
+	return nil
+}
+
+func (s *SortedSet[T]) min() *sortedSetNode[T] {
+	n := s.root
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+
+	return n
+}
+
+// higher returns the node of the smallest item greater than the given item, or nil if there is no
+// such item.
+func (s *SortedSet[T]) higher(item T) *sortedSetNode[T] {
+	var higher *sortedSetNode[T]
+	for n := s.root; n != nil; {
+		if cmp.Less(item, n.item) {
+			higher = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return higher
+}
+
+func (s *SortedSet[T]) insert(h *sortedSetNode[T], item T) *sortedSetNode[T] {
+	if h == nil {
+		return &sortedSetNode[T]{item: item, red: true, size: 1}
+	}
+
+	switch c := cmp.Compare(item, h.item); {
+	case c < 0:
+		h.left = s.insert(h.left, item)
+	case c > 0:
+		h.right = s.insert(h.right, item)
+	default:
+		return h
+	}
+
+	return balance(h)
+}
+
+// delete deletes the item, which must be in the subtree rooted at h, and returns the new root of
+// the subtree.
+func (s *SortedSet[T]) delete(h *sortedSetNode[T], item T) *sortedSetNode[T] {
+	if cmp.Less(item, h.item) {
+		if !isRed(h.left) && !isRed(h.left.left) {
+			h = moveRedLeft(h)
+		}
+		h.left = s.delete(h.left, item)
+		return balance(h)
+	}
+
+	if isRed(h.left) {
+		h = rotateRight(h)
+	}
+	if cmp.Compare(item, h.item) == 0 && h.right == nil {
+		return nil
+	}
+	if !isRed(h.right) && !isRed(h.right.left) {
+		h = moveRedRight(h)
+	}
+
+	if cmp.Compare(item, h.item) == 0 {
+		m := h.right
+		for m.left != nil {
+			m = m.left
+		}
+		h.item = m.item
+		h.right = deleteMin(h.right)
+	} else {
+		h.right = s.delete(h.right, item)
+	}
+
+	return balance(h)
+}
+
+func deleteMin[T cmp.Ordered](h *sortedSetNode[T]) *sortedSetNode[T] {
+	if h.left == nil {
+		return nil
+	}
+
+	if !isRed(h.left) && !isRed(h.left.left) {
+		h = moveRedLeft(h)
+	}
+	h.left = deleteMin(h.left)
+	return balance(h)
+}
+
+func isRed[T cmp.Ordered](n *sortedSetNode[T]) bool {
+	return n != nil && n.red
+}
+
+func treeSize[T cmp.Ordered](n *sortedSetNode[T]) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+}
+
+// nodeItem returns the item of n. If n is nil, it returns the zero value and false.
+func nodeItem[T cmp.Ordered](n *sortedSetNode[T]) (T, bool) {
+	if n == nil {
+		var zero T
+		return zero, false
+	}
+
+	return n.item, true
+}
+
+func rotateLeft[T cmp.Ordered](h *sortedSetNode[T]) *sortedSetNode[T] {
+	x := h.right
+	h.right = x.left
+	x.left = h
+	x.red, h.red = h.red, true
+	x.size = h.size
+	h.size = treeSize(h.left) + treeSize(h.right) + 1
+	return x
+}
+
+func rotateRight[T cmp.Ordered](h *sortedSetNode[T]) *sortedSetNode[T] {
+	x := h.left
+	h.left = x.right
+	x.right = h
+	x.red, h.red = h.red, true
+	x.size = h.size
+	h.size = treeSize(h.left) + treeSize(h.right) + 1
+	return x
+}
+
+func flipColors[T cmp.Ordered](h *sortedSetNode[T]) {
+	h.red = !h.red
+	h.left.red = !h.left.red
+	h.right.red = !h.right.red
+}
+
+// moveRedLeft makes the left child of h or one of its children red, assuming that h is red and
+// both of its children are black.
+func moveRedLeft[T cmp.Ordered](h *sortedSetNode[T]) *sortedSetNode[T] {
+	flipColors(h)
+	if isRed(h.right.left) {
+		h.right = rotateRight(h.right)
+		h = rotateLeft(h)
+		flipColors(h)
+	}
+
+	return h
+}
+
+// moveRedRight makes the right child of h or one of its children red, assuming that h is red and
+// both of its children are black.
+func moveRedRight[T cmp.Ordered](h *sortedSetNode[T]) *sortedSetNode[T] {
+	flipColors(h)
+	if isRed(h.left.left) {
+		h = rotateRight(h)
+		flipColors(h)
+	}
+
+	return h
+}
+
+// balance restores the invariants of the left-leaning red-black tree at h and updates its size.
+func balance[T cmp.Ordered](h *sortedSetNode[T]) *sortedSetNode[T] {
+	if isRed(h.right) && !isRed(h.left) {
+		h = rotateLeft(h)
+	}
+	if isRed(h.left) && isRed(h.left.left) {
+		h = rotateRight(h)
+	}
+	if isRed(h.left) && isRed(h.right) {
+		flipColors(h)
+	}
+
+	h.size = treeSize(h.left) + treeSize(h.right) + 1
+	return h
+}
+
+func cloneNode[T cmp.Ordered](n *sortedSetNode[T]) *sortedSetNode[T] {
+	if n == nil {
+		return nil
+	}
+
+	c := *n
+	c.left, c.right = cloneNode(n.left), cloneNode(n.right)
+	return &c
+}
+
+type sortedSetIterable[T cmp.Ordered] struct {
+	s       *SortedSet[T]
+	current T
+	started bool
+	done    bool
+}
+
+func (it *sortedSetIterable[T]) Next() bool {
+	if it.done {
+		return false
+	}
+
+	var n *sortedSetNode[T]
+	if it.started {
+		n = it.s.higher(it.current)
+	} else {
+		n = it.s.min()
+	}
+
+	if n == nil {
+		var zero T
+		it.done = true
+		it.current = zero
+		return false
+	}
+
+	it.started = true
+	it.current = n.item
+	return true
+}
+
+func (it *sortedSetIterable[T]) Value() T {
+	return it.current
+}
@@ -0,0 +1,171 @@
+package set_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/set"
+)
+
+func TestNewSortedSet(t *testing.T) {
+	testInterfaceHelper(t, func(items ...int) set.Interface[int] {
+		return set.NewSortedSet(items...)
+	})
+}
+
+func TestNewSortedSetOf(t *testing.T) {
+	s := set.NewSortedSetOf("pear", "apple", "fig", "apple")
+	if got := slices.Collect(s.All()); !slices.Equal(got, []string{"apple", "fig", "pear"}) {
+		t.Errorf("All: expected items in ascending order [apple fig pear], got %v", got)
+	}
+
+	var got []string
+	it := s.Iterator()
+	for it.Next() {
+		got = append(got, it.Value())
+	}
+	if !slices.Equal(got, []string{"apple", "fig", "pear"}) {
+		t.Errorf("Iterator: expected items in ascending order [apple fig pear], got %v", got)
+	}
+	if it.Next() || it.Value() != "" {
+		t.Error("Iterator: expected Next to return false after the last item")
+	}
+}
+
+func TestSortedSet_Queries(t *testing.T) {
+	s := set.NewSortedSet(40, 10, 30, 20, 50)
+	if v, ok := s.Min(); !ok || v != 10 {
+		t.Errorf("Min: expected Min to return (10, true), got (%d, %t)", v, ok)
+	}
+	if v, ok := s.Max(); !ok || v != 50 {
+		t.Errorf("Max: expected Max to return (50, true), got (%d, %t)", v, ok)
+	}
+
+	tests := []struct {
+		item                 int
+		floor, ceiling       int
+		hasFloor, hasCeiling bool
+		rank, index          int
+	}{
+		{5, 0, 10, false, true, 0, -1},
+		{10, 10, 10, true, true, 0, 0},
+		{25, 20, 30, true, true, 2, -1},
+		{50, 50, 50, true, true, 4, 4},
+		{55, 50, 0, true, false, 5, -1},
+	}
+	for _, test := range tests {
+		if v, ok := s.Floor(test.item); ok != test.hasFloor || v != test.floor {
+			t.Errorf("Floor %d: expected (%d, %t), got (%d, %t)", test.item, test.floor, test.hasFloor, v, ok)
+		}
+		if v, ok := s.Ceiling(test.item); ok != test.hasCeiling || v != test.ceiling {
+			t.Errorf("Ceiling %d: expected (%d, %t), got (%d, %t)", test.item, test.ceiling, test.hasCeiling, v, ok)
+		}
+		if got := s.Rank(test.item); got != test.rank {
+			t.Errorf("Rank %d: expected %d, got %d", test.item, test.rank, got)
+		}
+		if got := s.IndexOf(test.item); got != test.index {
+			t.Errorf("IndexOf %d: expected %d, got %d", test.item, test.index, got)
+		}
+	}
+
+	for k, expected := range []int{10, 20, 30, 40, 50} {
+		if v, ok := s.Select(k); !ok || v != expected {
+			t.Errorf("Select %d: expected (%d, true), got (%d, %t)", k, expected, v, ok)
+		}
+	}
+	if _, ok := s.Select(5); ok {
+		t.Error("Select 5: expected Select to return false")
+	}
+	if _, ok := s.Select(-1); ok {
+		t.Error("Select -1: expected Select to return false")
+	}
+
+	if got := slices.Collect(s.Range(15, 40)); !slices.Equal(got, []int{20, 30}) {
+		t.Errorf("Range 15, 40: expected [20 30], got %v", got)
+	}
+	if got := slices.Collect(s.Range(10, 11)); !slices.Equal(got, []int{10}) {
+		t.Errorf("Range 10, 11: expected [10], got %v", got)
+	}
+	if got := slices.Collect(s.Range(40, 20)); len(got) != 0 {
+		t.Errorf("Range 40, 20: expected no items, got %v", got)
+	}
+
+	empty := set.NewSortedSet()
+	if _, ok := empty.Min(); ok {
+		t.Error("Min: expected Min to return false for an empty set")
+	}
+	if _, ok := empty.Max(); ok {
+		t.Error("Max: expected Max to return false for an empty set")
+	}
+}
+
+func TestSortedSet_DeleteDuringIteration(t *testing.T) {
+	s := set.NewSortedSet()
+	for i := range 100 {
+		s.Add(i)
+	}
+
+	var got []int
+	for item := range s.All() {
+		got = append(got, item)
+		s.Delete(item, item+1)
+	}
+	if !s.Empty() || len(got) != 50 || got[1] != 2 {
+		t.Errorf("All: expected deleting during iteration to skip the deleted items, got %v", got)
+	}
+
+	s.Add(1, 2, 3, 4, 5)
+	c := s.Clone()
+	for item := range s.Range(2, 5) {
+		s.Delete(item)
+	}
+	if got := slices.Collect(s.All()); !slices.Equal(got, []int{1, 5}) {
+		t.Errorf("Range: expected deleting during iteration to leave [1 5], got %v", got)
+	}
+	if c.Len() != 5 {
+		t.Errorf("Clone: expected clone to be independent, got Len %d", c.Len())
+	}
+}
+
+func TestSortedSet_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := set.NewSortedSet()
+	var expected []int
+	for range 5000 {
+		item := r.Intn(1000)
+		if r.Intn(3) == 0 {
+			s.Delete(item)
+			if i, ok := slices.BinarySearch(expected, item); ok {
+				expected = slices.Delete(expected, i, i+1)
+			}
+		} else {
+			s.Add(item)
+			if i, ok := slices.BinarySearch(expected, item); !ok {
+				expected = slices.Insert(expected, i, item)
+			}
+		}
+
+		if s.Len() != len(expected) {
+			t.Fatalf("Len: expected %d, got %d", len(expected), s.Len())
+		}
+
+		q := r.Intn(1100) - 50
+		i, ok := slices.BinarySearch(expected, q)
+		if got := s.Rank(q); got != i {
+			t.Fatalf("Rank %d: expected %d, got %d", q, i, got)
+		}
+		if ok {
+			if v, _ := s.Select(i); v != q {
+				t.Fatalf("Select %d: expected %d, got %d", i, q, v)
+			}
+		}
+		if v, found := s.Ceiling(q); found != (i < len(expected)) || found && v != expected[i] {
+			t.Fatalf("Ceiling %d: expected index %d of %v, got (%d, %t)", q, i, expected, v, found)
+		}
+	}
+
+	if got := slices.Collect(s.All()); !slices.Equal(got, expected) {
+		t.Errorf("All: expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,108 @@
+package sketch
+
+import (
+	"math"
+
+	"github.com/gpahal/go-algos/internal/hashing"
+)
+
+// CountMin represents a Count-Min sketch that estimates how many times every item was added to it.
+// It has depth rows of width counters, and every item increments one counter of every row chosen
+// by its hash. The estimate of an item is the smallest of its counters: it is never less than the
+// real count, and with a width of e/epsilon and a depth of ln(1/delta), it exceeds the real count
+// by more than epsilon times the total count with a probability of at most delta.
+type CountMin struct {
+	width, depth int
+	counters     []uint64
+	total        uint64
+}
+
+// NewCountMin returns a new empty Count-Min sketch whose estimates exceed the real counts by at
+// most epsilon times the total count with a probability of at least 1-delta. Both epsilon and
+// delta should be between 0 and 1.
+func NewCountMin(epsilon, delta float64) *CountMin {
+	epsilon = min(max(epsilon, 1e-9), 1)
+	delta = min(max(delta, 1e-12), 0.5)
+	width := int(math.Ceil(math.E / epsilon))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+	return NewCountMinWithSize(width, depth)
+}
+
+// NewCountMinWithSize returns a new empty Count-Min sketch with the given width and depth. Both of
+// them are at least 1.
+func NewCountMinWithSize(width, depth int) *CountMin {
+	width, depth = max(width, 1), max(depth, 1)
+	return &CountMin{width: width, depth: depth, counters: make([]uint64, width*depth)}
+}
+
+// Width returns the number of counters of every row of the sketch.
+func (c *CountMin) Width() int {
+	return c.width
+}
+
+// Depth returns the number of rows of the sketch.
+func (c *CountMin) Depth() int {
+	return c.depth
+}
+
+// Total returns the sum of the counts of all the items added to the sketch.
+func (c *CountMin) Total() uint64 {
+	return c.total
+}
+
+// Add adds count occurrences of the item to the sketch and returns the new estimate of its count.
+func (c *CountMin) Add(item []byte, count uint64) uint64 {
+	estimate := uint64(math.MaxUint64)
+	h1, h2 := hashing.Pair(item)
+	for row := range c.depth {
+		i := c.index(h1, h2, row)
+		c.counters[i] += count
+		estimate = min(estimate, c.counters[i])
+	}
+
+	c.total += count
+	return estimate
+}
+
+// Count returns the estimated number of occurrences of the item added to the sketch.
+func (c *CountMin) Count(item []byte) uint64 {
+	estimate := uint64(math.MaxUint64)
+	h1, h2 := hashing.Pair(item)
+	for row := range c.depth {
+		estimate = min(estimate, c.counters[c.index(h1, h2, row)])
+	}
+
+	return estimate
+}
+
+// Merge adds the counts of other to the sketch. If the sketches have different widths or depths,
+// ErrIncompatible is returned and the sketch is not modified.
+func (c *CountMin) Merge(other *CountMin) error {
+	if c.width != other.width || c.depth != other.depth {
+		return ErrIncompatible
+	}
+
+	for i, v := range other.counters {
+		c.counters[i] += v
+	}
+	c.total += other.total
+	return nil
+}
+
+// Clear deletes all the counts from the sketch.
+func (c *CountMin) Clear() {
+	clear(c.counters)
+	c.total = 0
+}
+
+// Copy creates a new copy of the sketch.
+func (c *CountMin) Copy() *CountMin {
+	newSketch := *c
+	newSketch.counters = append([]uint64(nil), c.counters...)
+	return &newSketch
+}
+
+// index returns the index of the counter of the item with the hashes h1 and h2 in the given row.
+func (c *CountMin) index(h1, h2 uint64, row int) int {
+	return row*c.width + int((h1+uint64(row)*h2)%uint64(c.width))
+}
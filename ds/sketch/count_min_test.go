@@ -0,0 +1,76 @@
+package sketch_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/sketch"
+)
+
+func TestNewCountMin(t *testing.T) {
+	c := sketch.NewCountMin(0.01, 0.01)
+	if c.Width() != 272 || c.Depth() != 5 {
+		t.Errorf("NewCountMin 0.01, 0.01: expected width 272 and depth 5, got %d and %d", c.Width(), c.Depth())
+	}
+
+	c = sketch.NewCountMinWithSize(0, 0)
+	if c.Width() != 1 || c.Depth() != 1 {
+		t.Errorf("NewCountMinWithSize 0, 0: expected width 1 and depth 1, got %d and %d", c.Width(), c.Depth())
+	}
+}
+
+func TestCountMin_Count(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	c := sketch.NewCountMin(0.001, 0.01)
+	counts := make(map[int]uint64)
+	for range 100000 {
+		// A skewed distribution where small items are much more frequent.
+		i := int(r.ExpFloat64() * 100)
+		counts[i]++
+		if got := c.Add(item(i), 1); got < counts[i] {
+			t.Fatalf("Add %d: expected estimate to be at least %d, got %d", i, counts[i], got)
+		}
+	}
+
+	if c.Total() != 100000 {
+		t.Errorf("Total: expected 100000, got %d", c.Total())
+	}
+
+	bound := uint64(0.001 * 100000)
+	for i, count := range counts {
+		got := c.Count(item(i))
+		if got < count || got > count+bound {
+			t.Errorf("Count %d: expected between %d and %d, got %d", i, count, count+bound, got)
+		}
+	}
+	if got := c.Count([]byte("missing")); got > bound {
+		t.Errorf("Count: expected missing item to count at most %d, got %d", bound, got)
+	}
+}
+
+func TestCountMin_Merge(t *testing.T) {
+	a, b := sketch.NewCountMin(0.01, 0.01), sketch.NewCountMin(0.01, 0.01)
+	a.Add(item(1), 5)
+	b.Add(item(1), 3)
+	b.Add(item(2), 4)
+
+	merged := a.Copy()
+	if err := merged.Merge(b); err != nil {
+		t.Fatalf("Merge: expected no error, got %v", err)
+	}
+	if merged.Count(item(1)) != 8 || merged.Count(item(2)) != 4 || merged.Total() != 12 {
+		t.Errorf("Merge: expected counts 8 and 4 and total 12, got %d, %d and %d", merged.Count(item(1)), merged.Count(item(2)), merged.Total())
+	}
+	if a.Count(item(1)) != 5 {
+		t.Errorf("Copy: expected copy to be independent, got count %d", a.Count(item(1)))
+	}
+
+	if err := a.Merge(sketch.NewCountMin(0.1, 0.01)); err != sketch.ErrIncompatible {
+		t.Errorf("Merge: expected ErrIncompatible, got %v", err)
+	}
+
+	a.Clear()
+	if a.Count(item(1)) != 0 || a.Total() != 0 {
+		t.Error("Clear: expected sketch to be empty")
+	}
+}
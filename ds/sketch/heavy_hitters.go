@@ -0,0 +1,108 @@
+package sketch
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/gpahal/go-algos/ds/heap"
+)
+
+// HeavyHitter is an item tracked by HeavyHitters with its estimated count.
+type HeavyHitter struct {
+	Item  string
+	Count uint64
+}
+
+// HeavyHitters tracks the k most frequent items of a stream using a Count-Min sketch. The items
+// with the k largest estimated counts seen so far are kept in an indexed priority queue ordered by
+// their counts, and an item replaces the least frequent one kept once its estimate exceeds it.
+// Every item takes O(depth + log(k)) time, and the memory used is that of the sketch and of the k
+// items kept.
+//
+// The counts are the estimates of the sketch, so they may exceed the real counts as described by
+// CountMin, and an item is only kept if it is among the k most frequent items at the time it is
+// added.
+type HeavyHitters struct {
+	cm  *CountMin
+	top *heap.IndexedPriorityQueue[string, uint64]
+	k   int
+}
+
+// NewHeavyHitters returns a new heavy hitters tracker keeping the k most frequent items, using a
+// Count-Min sketch created by NewCountMin with epsilon and delta. If k is less than 1, 1 is used.
+func NewHeavyHitters(k int, epsilon, delta float64) *HeavyHitters {
+	return &HeavyHitters{
+		cm:  NewCountMin(epsilon, delta),
+		top: heap.NewIndexedPriorityQueue[string](cmp.Less[uint64]),
+		k:   max(k, 1),
+	}
+}
+
+// K returns the maximum number of items kept by the tracker.
+func (hh *HeavyHitters) K() int {
+	return hh.k
+}
+
+// Add adds count occurrences of the item to the tracker.
+func (hh *HeavyHitters) Add(item []byte, count uint64) {
+	estimate := hh.cm.Add(item, count)
+	key := string(item)
+	switch {
+	case hh.top.Contains(key):
+		hh.top.Update(key, estimate)
+	case hh.top.Len() < hh.k:
+		hh.top.Push(key, estimate)
+	default:
+		if _, least, _ := hh.top.Peek(); estimate > least {
+			hh.top.Pop()
+			hh.top.Push(key, estimate)
+		}
+	}
+}
+
+// Count returns the estimated number of occurrences of the item, whether it is kept or not.
+func (hh *HeavyHitters) Count(item []byte) uint64 {
+	return hh.cm.Count(item)
+}
+
+// Total returns the sum of the counts of all the items added to the tracker.
+func (hh *HeavyHitters) Total() uint64 {
+	return hh.cm.Total()
+}
+
+// Top returns the items kept by the tracker with their estimated counts, from the most to the least
+// frequent. Items with equal counts are sorted by the items.
+func (hh *HeavyHitters) Top() []HeavyHitter {
+	items := make([]HeavyHitter, 0, hh.top.Len())
+	for item, count := range hh.top.All() {
+		items = append(items, HeavyHitter{Item: item, Count: count})
+	}
+
+	slices.SortFunc(items, func(a, b HeavyHitter) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Item, b.Item)
+	})
+	return items
+}
+
+// Above returns the items kept by the tracker whose estimated counts are at least phi times the
+// total count, from the most to the least frequent. With a phi larger than 1/k, there are fewer
+// than k such items, so they are usually all kept.
+func (hh *HeavyHitters) Above(phi float64) []HeavyHitter {
+	threshold := phi * float64(hh.cm.Total())
+	items := hh.Top()
+	i := 0
+	for i < len(items) && float64(items[i].Count) >= threshold {
+		i++
+	}
+
+	return items[:i]
+}
+
+// Clear deletes all the items and counts from the tracker.
+func (hh *HeavyHitters) Clear() {
+	hh.cm.Clear()
+	hh.top.Clear()
+}
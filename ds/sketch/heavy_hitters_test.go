@@ -0,0 +1,57 @@
+package sketch_test
+
+import (
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/sketch"
+)
+
+func TestHeavyHitters(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	hh := sketch.NewHeavyHitters(3, 0.001, 0.01)
+	if hh.K() != 3 || len(hh.Top()) != 0 {
+		t.Errorf("NewHeavyHitters: expected K 3 and no items, got %d and %v", hh.K(), hh.Top())
+	}
+
+	// The items a, b and c make up 20%, 15% and 10% of the stream, and the rest are rare.
+	for i := range 100000 {
+		switch x := r.Float64(); {
+		case x < 0.2:
+			hh.Add([]byte("a"), 1)
+		case x < 0.35:
+			hh.Add([]byte("b"), 1)
+		case x < 0.45:
+			hh.Add([]byte("c"), 1)
+		default:
+			hh.Add([]byte("rare-"+strconv.Itoa(i)), 1)
+		}
+	}
+
+	top := hh.Top()
+	items := make([]string, len(top))
+	for i, h := range top {
+		items[i] = h.Item
+		if h.Count != hh.Count([]byte(h.Item)) {
+			t.Errorf("Top: expected count of %s to be %d, got %d", h.Item, hh.Count([]byte(h.Item)), h.Count)
+		}
+	}
+	if !slices.Equal(items, []string{"a", "b", "c"}) {
+		t.Errorf("Top: expected items [a b c], got %v", top)
+	}
+
+	above := hh.Above(0.12)
+	if len(above) != 2 || above[0].Item != "a" || above[1].Item != "b" {
+		t.Errorf("Above 0.12: expected items a and b, got %v", above)
+	}
+	if hh.Total() != 100000 {
+		t.Errorf("Total: expected 100000, got %d", hh.Total())
+	}
+
+	hh.Clear()
+	if len(hh.Top()) != 0 || hh.Total() != 0 {
+		t.Error("Clear: expected tracker to be empty")
+	}
+}
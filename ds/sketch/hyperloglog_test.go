@@ -0,0 +1,124 @@
+package sketch_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/sketch"
+)
+
+func item(i int) []byte {
+	return []byte("item-" + strconv.Itoa(i))
+}
+
+func TestNewHyperLogLog(t *testing.T) {
+	tests := []struct {
+		precision, expected int
+	}{
+		{0, sketch.MinPrecision},
+		{14, 14},
+		{30, sketch.MaxPrecision},
+	}
+	for _, test := range tests {
+		if got := sketch.NewHyperLogLog(test.precision).Precision(); got != test.expected {
+			t.Errorf("NewHyperLogLog %d: expected Precision to be %d, got %d", test.precision, test.expected, got)
+		}
+	}
+
+	h := sketch.NewHyperLogLog(14)
+	if h.Count() != 0 {
+		t.Errorf("Count: expected empty sketch to count 0, got %d", h.Count())
+	}
+	if se := h.StandardError(); math.Abs(se-0.008125) > 1e-6 {
+		t.Errorf("StandardError: expected 0.008125, got %g", se)
+	}
+}
+
+func TestHyperLogLog_Count(t *testing.T) {
+	for _, precision := range []int{8, 12, 14} {
+		h := sketch.NewHyperLogLog(precision)
+		n := 0
+		for _, target := range []int{10, 100, 1000, 10000, 100000, 1000000} {
+			for ; n < target; n++ {
+				h.Add(item(n))
+				h.Add(item(n))
+			}
+
+			// Small counts are almost exact in the sparse representation, and large ones should be
+			// within 4 standard errors.
+			tolerance := max(4*h.StandardError(), 0.01)
+			if got := float64(h.Count()); math.Abs(got-float64(n))/float64(n) > tolerance {
+				t.Errorf("Count precision %d: expected about %d, got %g", precision, n, got)
+			}
+		}
+	}
+}
+
+func TestHyperLogLog_Bytes(t *testing.T) {
+	h := sketch.NewHyperLogLog(14)
+	if h.Bytes() != 0 {
+		t.Errorf("Bytes: expected empty sketch to use 0 bytes, got %d", h.Bytes())
+	}
+
+	// The sparse representation never uses more memory than the 16KB of the dense registers.
+	for i := range 10000 {
+		h.Add(item(i))
+		if got := h.Bytes(); got > 1<<14 {
+			t.Fatalf("Bytes: expected at most %d bytes after %d items, got %d", 1<<14, i+1, got)
+		}
+		if i == 999 {
+			if got := h.Bytes(); got >= 1<<14/2 {
+				t.Errorf("Bytes: expected less than %d bytes after 1000 items, got %d", 1<<14/2, got)
+			}
+			if got := h.Count(); got < 990 || got > 1010 {
+				t.Errorf("Count: expected about 1000 in the sparse representation, got %d", got)
+			}
+		}
+	}
+	if got := h.Bytes(); got != 1<<14 {
+		t.Errorf("Bytes: expected dense registers to use %d bytes, got %d", 1<<14, got)
+	}
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	for _, n := range []int{100, 50000} {
+		a, b := sketch.NewHyperLogLog(12), sketch.NewHyperLogLog(12)
+		for i := range n {
+			a.Add(item(i))
+			b.Add(item(i + n/2))
+		}
+
+		union := a.Copy()
+		if err := union.Merge(b); err != nil {
+			t.Fatalf("Merge: expected no error, got %v", err)
+		}
+		expected := float64(n + n/2)
+		if got := float64(union.Count()); math.Abs(got-expected)/expected > 4*union.StandardError() {
+			t.Errorf("Merge %d: expected about %g, got %g", n, expected, got)
+		}
+
+		// Merging a dense sketch into a sparse one and the reverse give the same counts.
+		small := sketch.NewHyperLogLog(12)
+		small.Add(item(-1))
+		dense := a.Copy()
+		dense.Merge(small)
+		small.Merge(a)
+		if dense.Count() != small.Count() {
+			t.Errorf("Merge %d: expected merges in both orders to count %d, got %d", n, dense.Count(), small.Count())
+		}
+	}
+
+	if err := sketch.NewHyperLogLog(12).Merge(sketch.NewHyperLogLog(10)); err != sketch.ErrIncompatible {
+		t.Errorf("Merge: expected ErrIncompatible, got %v", err)
+	}
+
+	h := sketch.NewHyperLogLog(10)
+	for i := range 10000 {
+		h.Add(item(i))
+	}
+	h.Clear()
+	if h.Count() != 0 {
+		t.Errorf("Clear: expected sketch to count 0, got %d", h.Count())
+	}
+}
@@ -0,0 +1,141 @@
+package sketch
+
+import (
+	"math"
+	"slices"
+
+	"github.com/gpahal/go-algos/internal/hashing"
+)
+
+// LSH represents a locality sensitive hashing index of MinHash signatures, which finds the keys of
+// the sets that are likely similar to a given set without comparing it with every set. Every
+// signature of b*r hashes is split into b bands of r hashes, and two sets are candidates if all
+// the hashes of at least one of their bands are equal. Two sets with a similarity s are candidates
+// with a probability of 1-(1-s^r)^b, which rises sharply around the threshold (1/b)^(1/r).
+//
+// The candidates are only likely similar: their similarity can be checked with their signatures or
+// with Jaccard on the sets.
+type LSH struct {
+	bands, rows int
+
+	// buckets maps, for every band, the hash of the band of a signature to the keys of the
+	// signatures inserted with that band.
+	buckets []map[uint64][]string
+	keys    map[string]struct{}
+}
+
+// NewLSH returns a new empty LSH index of signatures with bands*rows hash functions. Both bands
+// and rows are at least 1.
+func NewLSH(bands, rows int) *LSH {
+	bands, rows = max(bands, 1), max(rows, 1)
+	buckets := make([]map[uint64][]string, bands)
+	for i := range buckets {
+		buckets[i] = make(map[uint64][]string)
+	}
+
+	return &LSH{bands: bands, rows: rows, buckets: buckets, keys: make(map[string]struct{})}
+}
+
+// NewLSHWithThreshold returns a new empty LSH index of signatures with k hash functions, split
+// into the bands and rows whose threshold is the closest to the given similarity threshold. Only
+// the splits where the bands times the rows is k are considered.
+func NewLSHWithThreshold(k int, threshold float64) *LSH {
+	k = max(k, 1)
+	bestBands, bestDiff := k, math.Inf(1)
+	for bands := 1; bands <= k; bands++ {
+		if k%bands != 0 {
+			continue
+		}
+
+		if diff := math.Abs(lshThreshold(bands, k/bands) - threshold); diff < bestDiff {
+			bestBands, bestDiff = bands, diff
+		}
+	}
+
+	return NewLSH(bestBands, k/bestBands)
+}
+
+// Bands returns the number of bands of the signatures.
+func (l *LSH) Bands() int {
+	return l.bands
+}
+
+// Rows returns the number of hashes of every band.
+func (l *LSH) Rows() int {
+	return l.rows
+}
+
+// Threshold returns the approximate similarity above which two sets are likely candidates,
+// (1/b)^(1/r).
+func (l *LSH) Threshold() float64 {
+	return lshThreshold(l.bands, l.rows)
+}
+
+// Len returns the number of keys inserted into the index.
+func (l *LSH) Len() int {
+	return len(l.keys)
+}
+
+// Insert inserts the key with the signature of its set into the index. If the key was already
+// inserted, it is also indexed by the new signature. If the signature doesn't have bands*rows
+// hash functions, ErrIncompatible is returned.
+func (l *LSH) Insert(key string, m *MinHash) error {
+	if m.K() != l.bands*l.rows {
+		return ErrIncompatible
+	}
+
+	for band, bucket := range l.buckets {
+		h := l.bandHash(m, band)
+		if !slices.Contains(bucket[h], key) {
+			bucket[h] = append(bucket[h], key)
+		}
+	}
+
+	l.keys[key] = struct{}{}
+	return nil
+}
+
+// Query returns the sorted keys of the sets that are candidates for being similar to the set of
+// the signature. If the signature doesn't have bands*rows hash functions, ErrIncompatible is
+// returned.
+func (l *LSH) Query(m *MinHash) ([]string, error) {
+	if m.K() != l.bands*l.rows {
+		return nil, ErrIncompatible
+	}
+
+	seen := make(map[string]struct{})
+	var keys []string
+	for band, bucket := range l.buckets {
+		for _, key := range bucket[l.bandHash(m, band)] {
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	slices.Sort(keys)
+	return keys, nil
+}
+
+// Clear deletes all the keys from the index.
+func (l *LSH) Clear() {
+	for i := range l.buckets {
+		l.buckets[i] = make(map[uint64][]string)
+	}
+	clear(l.keys)
+}
+
+// bandHash returns the hash of the given band of the signature.
+func (l *LSH) bandHash(m *MinHash, band int) uint64 {
+	h := uint64(band)
+	for _, v := range m.mins[band*l.rows : (band+1)*l.rows] {
+		h = hashing.Mix(h ^ v)
+	}
+
+	return h
+}
+
+func lshThreshold(bands, rows int) float64 {
+	return math.Pow(1/float64(bands), 1/float64(rows))
+}
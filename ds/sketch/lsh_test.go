@@ -0,0 +1,70 @@
+package sketch_test
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/sketch"
+)
+
+// signature returns the MinHash signature with k hash functions of the items [from, to).
+func signature(k, from, to int) *sketch.MinHash {
+	m := sketch.NewMinHash(k)
+	for i := from; i < to; i++ {
+		m.Add(item(i))
+	}
+	return m
+}
+
+func TestNewLSH(t *testing.T) {
+	l := sketch.NewLSH(20, 5)
+	if l.Bands() != 20 || l.Rows() != 5 {
+		t.Errorf("NewLSH 20, 5: expected 20 bands and 5 rows, got %d and %d", l.Bands(), l.Rows())
+	}
+	if th := l.Threshold(); math.Abs(th-0.5493) > 1e-4 {
+		t.Errorf("Threshold: expected about 0.5493, got %g", th)
+	}
+
+	l = sketch.NewLSHWithThreshold(100, 0.8)
+	if l.Bands()*l.Rows() != 100 || math.Abs(l.Threshold()-0.8) > 0.05 {
+		t.Errorf("NewLSHWithThreshold 100, 0.8: expected threshold about 0.8, got %d bands, %d rows and %g", l.Bands(), l.Rows(), l.Threshold())
+	}
+}
+
+func TestLSH_Query(t *testing.T) {
+	l := sketch.NewLSH(20, 5)
+	// The sets have the items [1000*i, 1000*i+1000) for i in [0, 5), so they are disjoint, except
+	// for "near", which shares 90% of its items with "set-2".
+	for i := range 5 {
+		if err := l.Insert("set-"+strconv.Itoa(i), signature(100, 1000*i, 1000*i+1000)); err != nil {
+			t.Fatalf("Insert: expected no error, got %v", err)
+		}
+	}
+	l.Insert("near", signature(100, 2050, 3050))
+	l.Insert("near", signature(100, 2050, 3050))
+	if l.Len() != 6 {
+		t.Errorf("Len: expected 6 keys, got %d", l.Len())
+	}
+
+	got, err := l.Query(signature(100, 2000, 3000))
+	if err != nil || !slices.Equal(got, []string{"near", "set-2"}) {
+		t.Errorf("Query: expected candidates [near set-2], got %v (error %v)", got, err)
+	}
+	if got, _ := l.Query(signature(100, 10000, 11000)); len(got) != 0 {
+		t.Errorf("Query: expected no candidates for a disjoint set, got %v", got)
+	}
+
+	if err := l.Insert("bad", sketch.NewMinHash(64)); err != sketch.ErrIncompatible {
+		t.Errorf("Insert: expected ErrIncompatible, got %v", err)
+	}
+	if _, err := l.Query(sketch.NewMinHash(64)); err != sketch.ErrIncompatible {
+		t.Errorf("Query: expected ErrIncompatible, got %v", err)
+	}
+
+	l.Clear()
+	if got, _ := l.Query(signature(100, 2000, 3000)); len(got) != 0 || l.Len() != 0 {
+		t.Errorf("Clear: expected index to be empty, got %v", got)
+	}
+}
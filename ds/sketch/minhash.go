@@ -0,0 +1,115 @@
+package sketch
+
+import (
+	"math"
+
+	"github.com/gpahal/go-algos/ds/set"
+	"github.com/gpahal/go-algos/internal/hashing"
+)
+
+// MinHash represents a MinHash signature of a set of items, which estimates the Jaccard similarity
+// of two sets, |A∩B|/|A∪B|, from their signatures only. The signature keeps, for each of k hash
+// functions, the smallest hash of the items of the set. Two sets have the same smallest hash for a
+// hash function with a probability equal to their similarity, so the fraction of equal values of
+// their signatures estimates it with a standard error of at most 1/(2*sqrt(k)).
+//
+// Signatures built with the same k can be merged into the signature of the union of their sets,
+// and can be indexed by an LSH to find the similar sets quickly.
+type MinHash struct {
+	mins []uint64
+}
+
+// NewMinHash returns a new MinHash signature of an empty set using k hash functions. If k is less
+// than 1, 1 is used.
+func NewMinHash(k int) *MinHash {
+	m := &MinHash{mins: make([]uint64, max(k, 1))}
+	m.Clear()
+	return m
+}
+
+// K returns the number of hash functions of the signature.
+func (m *MinHash) K() int {
+	return len(m.mins)
+}
+
+// Add adds the item to the set of the signature. Every item takes O(k) time.
+func (m *MinHash) Add(item []byte) {
+	h1, h2 := hashing.Pair(item)
+	for i := range m.mins {
+		m.mins[i] = min(m.mins[i], hashing.Mix(h1+uint64(i)*h2))
+	}
+}
+
+// Similarity returns the estimated Jaccard similarity of the sets of the signature and other. Two
+// empty sets have a similarity of 1. If the signatures have different numbers of hash functions,
+// ErrIncompatible is returned.
+func (m *MinHash) Similarity(other *MinHash) (float64, error) {
+	if len(m.mins) != len(other.mins) {
+		return 0, ErrIncompatible
+	}
+
+	equal := 0
+	for i, v := range m.mins {
+		if v == other.mins[i] {
+			equal++
+		}
+	}
+
+	return float64(equal) / float64(len(m.mins)), nil
+}
+
+// Merge adds the items of the set of other to the set of the signature, so that it becomes the
+// signature of the union of the sets. If the signatures have different numbers of hash functions,
+// ErrIncompatible is returned and the signature is not modified.
+func (m *MinHash) Merge(other *MinHash) error {
+	if len(m.mins) != len(other.mins) {
+		return ErrIncompatible
+	}
+
+	for i, v := range other.mins {
+		m.mins[i] = min(m.mins[i], v)
+	}
+	return nil
+}
+
+// Signature returns a copy of the smallest hashes of the signature.
+func (m *MinHash) Signature() []uint64 {
+	return append([]uint64(nil), m.mins...)
+}
+
+// Clear deletes all the items from the set of the signature.
+func (m *MinHash) Clear() {
+	for i := range m.mins {
+		m.mins[i] = math.MaxUint64
+	}
+}
+
+// Copy creates a new copy of the signature.
+func (m *MinHash) Copy() *MinHash {
+	return &MinHash{mins: m.Signature()}
+}
+
+// Jaccard returns the exact Jaccard similarity of the two sets, |A∩B|/|A∪B|, which can be used to
+// verify the estimates of MinHash signatures or the candidates found by an LSH. Two empty sets have
+// a similarity of 1. It takes O(min(|A|, |B|)) time with sets with O(1) lookups.
+func Jaccard[T comparable](set1, set2 set.Interface[T]) float64 {
+	if set1.Len() > set2.Len() {
+		set1, set2 = set2, set1
+	}
+
+	intersection := 0
+	set1.Each(func(item T) bool {
+		if set2.Contains(item) {
+			intersection++
+		}
+
+		return false
+	})
+
+	union := set1.Len() + set2.Len() - intersection
+	if union == 0 {
+		return 1
+	}
+
+	return float64(intersection) / float64(union)
+}
@@ -0,0 +1,80 @@
+package sketch_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/set"
+	"github.com/gpahal/go-algos/ds/sketch"
+)
+
+// overlapping returns two sets of n items with the given number of shared items, and their MinHash
+// signatures with k hash functions.
+func overlapping(n, shared, k int) (set.Interface[string], set.Interface[string], *sketch.MinHash, *sketch.MinHash) {
+	a, b := set.NewNativeSetOf[string](), set.NewNativeSetOf[string]()
+	ma, mb := sketch.NewMinHash(k), sketch.NewMinHash(k)
+	for i := range n {
+		x := "item-" + strconv.Itoa(i)
+		y := "item-" + strconv.Itoa(i+n-shared)
+		a.Add(x)
+		b.Add(y)
+		ma.Add([]byte(x))
+		mb.Add([]byte(y))
+	}
+
+	return a, b, ma, mb
+}
+
+func TestMinHash_Similarity(t *testing.T) {
+	for _, shared := range []int{0, 200, 500, 800, 1000} {
+		a, b, ma, mb := overlapping(1000, shared, 256)
+		exact := sketch.Jaccard(a, b)
+		if expected := float64(shared) / float64(2000-shared); math.Abs(exact-expected) > 1e-12 {
+			t.Errorf("Jaccard %d: expected %g, got %g", shared, expected, exact)
+		}
+
+		got, err := ma.Similarity(mb)
+		if err != nil || math.Abs(got-exact) > 4/(2*math.Sqrt(256)) {
+			t.Errorf("Similarity %d: expected about %g, got %g (error %v)", shared, exact, got, err)
+		}
+	}
+
+	empty := set.NewNativeSet()
+	if got := sketch.Jaccard(empty, set.NewNativeSet()); got != 1 {
+		t.Errorf("Jaccard: expected empty sets to have similarity 1, got %g", got)
+	}
+	if got, _ := sketch.NewMinHash(16).Similarity(sketch.NewMinHash(16)); got != 1 {
+		t.Errorf("Similarity: expected empty sets to have similarity 1, got %g", got)
+	}
+	if _, err := sketch.NewMinHash(16).Similarity(sketch.NewMinHash(8)); err != sketch.ErrIncompatible {
+		t.Errorf("Similarity: expected ErrIncompatible, got %v", err)
+	}
+}
+
+func TestMinHash_Merge(t *testing.T) {
+	a, b, ma, mb := overlapping(500, 100, 128)
+	union := sketch.NewMinHash(128)
+	for item := range set.Union(a, b).All() {
+		union.Add([]byte(item))
+	}
+
+	merged := ma.Copy()
+	if err := merged.Merge(mb); err != nil {
+		t.Fatalf("Merge: expected no error, got %v", err)
+	}
+	if s, _ := merged.Similarity(union); s != 1 {
+		t.Errorf("Merge: expected merged signature to be the signature of the union, got similarity %g", s)
+	}
+	if s, _ := ma.Similarity(merged); s == 1 {
+		t.Error("Copy: expected copy to be independent")
+	}
+	if err := ma.Merge(sketch.NewMinHash(64)); err != sketch.ErrIncompatible {
+		t.Errorf("Merge: expected ErrIncompatible, got %v", err)
+	}
+
+	ma.Clear()
+	if s, _ := ma.Similarity(sketch.NewMinHash(128)); s != 1 || ma.K() != 128 || len(ma.Signature()) != 128 {
+		t.Error("Clear: expected signature of an empty set")
+	}
+}
@@ -0,0 +1,13 @@
+// Package sketch implements probabilistic sketches that summarize large streams of items in a
+// small, fixed amount of memory: HyperLogLog for counting distinct items, Count-Min for estimating
+// the frequencies of items and finding the most frequent ones, and MinHash with locality
+// sensitive hashing for estimating the Jaccard similarity of sets and finding similar sets.
+//
+// The items are byte slices. Sketches of the same type built with the same parameters can be
+// merged, so a stream can be split between workers whose sketches are combined at the end.
+package sketch
+
+import "errors"
+
+// ErrIncompatible is returned when combining two sketches built with different parameters.
+var ErrIncompatible = errors.New("sketch: incompatible sketches")
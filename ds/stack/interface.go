@@ -1,7 +1,9 @@
 package stack
 
+import "iter"
+
 // Interface is the interface that groups the basic methods of a stack implementation.
-type Interface interface {
+type Interface[T any] interface {
 	// Length returns the number of items in the stack.
 	Len() int
 
@@ -13,15 +15,19 @@ type Interface interface {
 
 	// Top returns the top/last pushed element of the stack. If the stack is empty, second return
 	// value is false.
-	Top() (int, bool)
+	Top() (T, bool)
 
 	// Push pushes the given items to the stack.
-	Push(items ...int)
+	Push(items ...T)
 
 	// Pop pops out an item from the stack in LIFO (Last In First Out) order. If the stack is
 	// empty, second return value is false.
-	Pop() (int, bool)
+	Pop() (T, bool)
+
+	// All returns an iterator over the items of the stack in LIFO (Last In First Out) order,
+	// without popping them. The stack should not be modified during iteration.
+	All() iter.Seq[T]
 
 	// Copy creates a new copy of the stack.
-	Copy() Interface
+	Copy() Interface[T]
 }
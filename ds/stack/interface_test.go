@@ -1,12 +1,13 @@
 package stack_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/gpahal/go-algos/ds/stack"
 )
 
-func testInterfaceHelper(t *testing.T, newFn func(items ...int) stack.Interface) {
+func testInterfaceHelper(t *testing.T, newFn func(items ...int) stack.Interface[int]) {
 	t.Run("New", func(t *testing.T) {
 		newStack := newFn(4, 5, 6)
 		if newStack.Len() != 3 {
@@ -88,6 +89,23 @@ func testInterfaceHelper(t *testing.T, newFn func(items ...int) stack.Interface)
 		}
 	})
 
+	t.Run("All", func(t *testing.T) {
+		newStack := newFn(4, 5, 6)
+		got := slices.Collect(newStack.All())
+		if !slicesEqual(got, []int{6, 5, 4}) {
+			t.Errorf("All: expected values to be [6 5 4], got %v", got)
+		}
+
+		for item := range newStack.All() {
+			if item != 6 {
+				t.Errorf("All: expected first value to be 6, got %d", item)
+			}
+			break
+		}
+
+		assertStackValues(t, "All", newStack, []int{6, 5, 4})
+	})
+
 	t.Run("Copy", func(t *testing.T) {
 		newStack := newFn(4, 5, 6)
 		copiedStack := newStack.Copy()
@@ -98,7 +116,7 @@ func testInterfaceHelper(t *testing.T, newFn func(items ...int) stack.Interface)
 	})
 }
 
-func assertStackValues(t *testing.T, name string, s stack.Interface, expected []int) {
+func assertStackValues(t *testing.T, name string, s stack.Interface[int], expected []int) {
 	t.Helper()
 
 	var got []int
@@ -132,3 +150,27 @@ func slicesEqual(arr1, arr2 []int) bool {
 
 	return true
 }
+
+func testInterfaceOfHelper(t *testing.T, newFn func(items ...string) stack.Interface[string]) {
+	newStack := newFn("a", "b")
+	newStack.Push("c")
+	copiedStack := newStack.Copy()
+
+	var got []string
+	for {
+		val, ok := newStack.Pop()
+		if !ok {
+			break
+		}
+
+		got = append(got, val)
+	}
+	if len(got) != 3 || got[0] != "c" || got[1] != "b" || got[2] != "a" {
+		t.Errorf("Pop: expected Stack values to be [c b a], got %v", got)
+	}
+
+	val, ok := copiedStack.Top()
+	if !ok || val != "c" {
+		t.Errorf("Top: expected Top to return (c, true), got (%s, %t)", val, ok)
+	}
+}
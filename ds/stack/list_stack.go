@@ -1,67 +1,82 @@
 package stack
 
 import (
+	"iter"
+
 	"github.com/gpahal/go-algos/ds/list"
 )
 
 // ListStack represents a stack instance implemented as a singly linked list.
-type ListStack struct {
-	l *list.SinglyLinkedList
+type ListStack[T comparable] struct {
+	l *list.SinglyLinkedList[T]
+}
+
+// NewListStack returns a new list stack instance of ints with the given items pushed into it.
+func NewListStack(items ...int) Interface[int] {
+	return NewListStackOf(items...)
 }
 
-// NewListStack returns a new list stack instance with the given items pushed into it.
-func NewListStack(items ...int) Interface {
-	s := &ListStack{l: &list.SinglyLinkedList{}}
+// NewListStackOf returns a new list stack instance with the given items pushed into it.
+func NewListStackOf[T comparable](items ...T) Interface[T] {
+	s := &ListStack[T]{l: &list.SinglyLinkedList[T]{}}
 	s.Push(items...)
 	return s
 }
 
 // Len returns the number of items in the stack.
-func (s *ListStack) Len() int {
+func (s *ListStack[T]) Len() int {
 	return s.l.Len()
 }
 
 // Empty checks whether the stack is empty.
-func (s *ListStack) Empty() bool {
+func (s *ListStack[T]) Empty() bool {
 	return s.l.Empty()
 }
 
 // Clear deletes all the items from the stack.
-func (s *ListStack) Clear() {
+func (s *ListStack[T]) Clear() {
 	s.l.Clear()
 }
 
 // Top returns the top/last pushed element of the stack. If the stack is empty, second return
 // value is false.
-func (s *ListStack) Top() (int, bool) {
+func (s *ListStack[T]) Top() (T, bool) {
 	el := s.l.First()
 	if el == nil {
-		return 0, false
+		var zero T
+		return zero, false
 	}
 
 	return el.Value, true
 }
 
 // Push pushes the given items to the stack.
-func (s *ListStack) Push(items ...int) {
+func (s *ListStack[T]) Push(items ...T) {
 	s.l.PushFront(items...)
 }
 
 // Pop pops out an item from the stack in LIFO (Last In First Out) order. If the stack is empty,
 // second return value is false.
-func (s *ListStack) Pop() (int, bool) {
+func (s *ListStack[T]) Pop() (T, bool) {
 	el := s.l.PopFront()
 	if el == nil {
-		return 0, false
+		var zero T
+		return zero, false
 	}
 
 	return el.Value, true
 }
 
+// All returns an iterator over the items of the stack in LIFO (Last In First Out) order, without
+// popping them. The stack should not be modified during iteration.
+func (s *ListStack[T]) All() iter.Seq[T] {
+	return s.l.All()
+}
+
 // Copy creates a new copy of the stack.
-func (s *ListStack) Copy() Interface {
-	var arr []int
-	s.l.Each(func(item int) bool {
+func (s *ListStack[T]) Copy() Interface[T] {
+	var arr []T
+	s.l.Each(func(item T) bool {
 		arr = append(arr, item)
 		return false
 	})
@@ -71,5 +86,5 @@ func (s *ListStack) Copy() Interface {
 		arr[i], arr[j] = arr[j], arr[i]
 	}
 
-	return NewListStack(arr...)
+	return NewListStackOf(arr...)
 }
@@ -9,3 +9,7 @@ import (
 func TestListStack(t *testing.T) {
 	testInterfaceHelper(t, stack.NewListStack)
 }
+
+func TestListStackOf(t *testing.T) {
+	testInterfaceOfHelper(t, stack.NewListStackOf[string])
+}
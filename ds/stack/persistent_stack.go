@@ -0,0 +1,62 @@
+package stack
+
+import (
+	"iter"
+
+	"github.com/gpahal/go-algos/ds/list"
+)
+
+// PersistentStack represents an immutable stack implemented as a list.PersistentList. Push and Pop
+// return a new version of the stack in O(1) time and leave the original unchanged, sharing the
+// items they have in common with it.
+//
+// Unlike the implementations of Interface, which need Copy to take a snapshot, a persistent stack
+// is its own snapshot: it can be kept or passed to other goroutines as is. The zero value is an
+// empty stack.
+type PersistentStack[T any] struct {
+	l list.PersistentList[T]
+}
+
+// NewPersistentStack returns a new persistent stack of ints with the given items pushed into it.
+func NewPersistentStack(items ...int) PersistentStack[int] {
+	return NewPersistentStackOf(items...)
+}
+
+// NewPersistentStackOf returns a new persistent stack with the given items pushed into it.
+func NewPersistentStackOf[T any](items ...T) PersistentStack[T] {
+	return PersistentStack[T]{}.Push(items...)
+}
+
+// Len returns the number of items in the stack.
+func (s PersistentStack[T]) Len() int {
+	return s.l.Len()
+}
+
+// Empty checks whether the stack is empty.
+func (s PersistentStack[T]) Empty() bool {
+	return s.l.Empty()
+}
+
+// Top returns the top/last pushed element of the stack. If the stack is empty, second return
+// value is false.
+func (s PersistentStack[T]) Top() (T, bool) {
+	return s.l.First()
+}
+
+// Push returns a new stack with the given items pushed to the stack. The stack itself is not
+// modified.
+func (s PersistentStack[T]) Push(items ...T) PersistentStack[T] {
+	return PersistentStack[T]{l: s.l.PushFront(items...)}
+}
+
+// Pop returns a new stack without the top item, and the top item. If the stack is empty, the last
+// return value is false. The stack itself is not modified.
+func (s PersistentStack[T]) Pop() (PersistentStack[T], T, bool) {
+	l, item, ok := s.l.PopFront()
+	return PersistentStack[T]{l: l}, item, ok
+}
+
+// All returns an iterator over the items of the stack in LIFO (Last In First Out) order.
+func (s PersistentStack[T]) All() iter.Seq[T] {
+	return s.l.All()
+}
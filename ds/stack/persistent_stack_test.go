@@ -0,0 +1,45 @@
+package stack_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gpahal/go-algos/ds/stack"
+)
+
+func TestPersistentStack(t *testing.T) {
+	var empty stack.PersistentStack[int]
+	if !empty.Empty() {
+		t.Errorf("Empty: expected the zero value to be empty, got Len %d", empty.Len())
+	}
+	if _, _, ok := empty.Pop(); ok {
+		t.Error("Pop: expected Pop of an empty stack to return false, got true")
+	}
+
+	s1 := stack.NewPersistentStack(4, 5, 6)
+	s2 := s1.Push(7)
+	s3, item, ok := s1.Pop()
+	if !ok || item != 6 {
+		t.Errorf("Pop: expected Pop to return 6, true, got %d, %v", item, ok)
+	}
+	if top, ok := s2.Top(); !ok || top != 7 {
+		t.Errorf("Top: expected Top to return 7, true, got %d, %v", top, ok)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		s        stack.PersistentStack[int]
+		expected []int
+	}{
+		{"New", s1, []int{6, 5, 4}},
+		{"Push", s2, []int{7, 6, 5, 4}},
+		{"Pop", s3, []int{5, 4}},
+	} {
+		if tc.s.Len() != len(tc.expected) {
+			t.Errorf("%s: expected Len to be %d, got %d", tc.name, len(tc.expected), tc.s.Len())
+		}
+		if got := slices.Collect(tc.s.All()); !slices.Equal(got, tc.expected) {
+			t.Errorf("%s: expected Stack values to be %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
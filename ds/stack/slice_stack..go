@@ -1,48 +1,56 @@
 package stack
 
+import "iter"
+
 // SliceStack represents a stack instance implemented using a slice.
-type SliceStack struct {
-	arr []int
+type SliceStack[T any] struct {
+	arr []T
+}
+
+// NewSliceStack returns a new slice stack instance of ints with the given items pushed into it.
+func NewSliceStack(items ...int) Interface[int] {
+	return newSliceStack(items...)
 }
 
-// NewSliceStack returns a new slice stack instance with the given items pushed into it.
-func NewSliceStack(items ...int) Interface {
+// NewSliceStackOf returns a new slice stack instance with the given items pushed into it.
+func NewSliceStackOf[T any](items ...T) Interface[T] {
 	return newSliceStack(items...)
 }
 
-func newSliceStack(items ...int) *SliceStack {
-	s := &SliceStack{}
+func newSliceStack[T any](items ...T) *SliceStack[T] {
+	s := &SliceStack[T]{}
 	s.Push(items...)
 	return s
 }
 
 // Len returns the number of items in the stack.
-func (s *SliceStack) Len() int {
+func (s *SliceStack[T]) Len() int {
 	return len(s.arr)
 }
 
 // Empty checks whether the stack is empty.
-func (s *SliceStack) Empty() bool {
+func (s *SliceStack[T]) Empty() bool {
 	return len(s.arr) == 0
 }
 
 // Clear deletes all the items from the stack.
-func (s *SliceStack) Clear() {
+func (s *SliceStack[T]) Clear() {
 	s.arr = nil
 }
 
 // Top returns the top/last pushed element of the stack. If the stack is empty, second return
 // value is false.
-func (s *SliceStack) Top() (int, bool) {
+func (s *SliceStack[T]) Top() (T, bool) {
 	if len(s.arr) == 0 {
-		return 0, false
+		var zero T
+		return zero, false
 	}
 
 	return s.arr[len(s.arr)-1], true
 }
 
 // Push pushes the given items to the stack.
-func (s *SliceStack) Push(items ...int) {
+func (s *SliceStack[T]) Push(items ...T) {
 	if len(items) == 0 {
 		return
 	}
@@ -52,9 +60,10 @@ func (s *SliceStack) Push(items ...int) {
 
 // Pop pops out an item from the stack in LIFO (Last In First Out) order. If the stack is empty,
 // second return value is false.
-func (s *SliceStack) Pop() (int, bool) {
+func (s *SliceStack[T]) Pop() (T, bool) {
 	if len(s.arr) == 0 {
-		return 0, false
+		var zero T
+		return zero, false
 	}
 
 	v := s.arr[len(s.arr)-1]
@@ -62,14 +71,26 @@ func (s *SliceStack) Pop() (int, bool) {
 	return v, true
 }
 
+// All returns an iterator over the items of the stack in LIFO (Last In First Out) order, without
+// popping them. The stack should not be modified during iteration.
+func (s *SliceStack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(s.arr) - 1; i >= 0; i-- {
+			if !yield(s.arr[i]) {
+				return
+			}
+		}
+	}
+}
+
 // Copy creates a new copy of the stack.
-func (s *SliceStack) Copy() Interface {
-	newStack := newSliceStack()
+func (s *SliceStack[T]) Copy() Interface[T] {
+	newStack := newSliceStack[T]()
 	if len(s.arr) == 0 {
 		return newStack
 	}
 
-	newStack.arr = make([]int, len(s.arr))
+	newStack.arr = make([]T, len(s.arr))
 	copy(newStack.arr, s.arr)
 	return newStack
 }
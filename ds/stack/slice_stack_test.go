@@ -9,3 +9,7 @@ import (
 func TestSliceStack(t *testing.T) {
 	testInterfaceHelper(t, stack.NewSliceStack)
 }
+
+func TestSliceStackOf(t *testing.T) {
+	testInterfaceOfHelper(t, stack.NewSliceStackOf[string])
+}
@@ -0,0 +1,37 @@
+// Package hashing implements the deterministic hashes of byte slices shared by the probabilistic
+// data structures, like the filters of ds/filter and the sketches of ds/sketch. The hashes don't
+// depend on the process, so the structures built from them can be serialized and merged across
+// processes.
+package hashing
+
+// Sum64 returns the 64-bit FNV-1a hash of the item mixed by the SplitMix64 finalizer, so that all
+// of its bits are well distributed.
+func Sum64(item []byte) uint64 {
+	return Mix(fnv1a(item))
+}
+
+// Pair returns two 64-bit hashes of the item for double hashing. The first one is Sum64, and the
+// second one mixes the FNV-1a hash with a different offset, so the two hashes are independent
+// enough to derive any number of hashes as h1 + i*h2.
+func Pair(item []byte) (uint64, uint64) {
+	h := fnv1a(item)
+	return Mix(h), Mix(h + 0x9E3779B97F4A7C15)
+}
+
+// Mix is the finalizer of the SplitMix64 generator. It spreads every bit of x over all the bits
+// of the result.
+func Mix(x uint64) uint64 {
+	x = (x ^ x>>30) * 0xBF58476D1CE4E5B9
+	x = (x ^ x>>27) * 0x94D049BB133111EB
+	return x ^ x>>31
+}
+
+func fnv1a(item []byte) uint64 {
+	h := uint64(14695981039346656037)
+	for _, b := range item {
+		h ^= uint64(b)
+		h *= 1099511628211
+	}
+
+	return h
+}
@@ -0,0 +1,37 @@
+package hashing_test
+
+import (
+	"testing"
+
+	"github.com/gpahal/go-algos/internal/hashing"
+)
+
+func TestSum64(t *testing.T) {
+	if got, expected := hashing.Sum64(nil), hashing.Mix(14695981039346656037); got != expected {
+		t.Errorf("Sum64: expected %d, got %d", expected, got)
+	}
+	if hashing.Sum64([]byte("a")) == hashing.Sum64([]byte("b")) {
+		t.Errorf("Sum64: expected different hashes of different items")
+	}
+}
+
+func TestPair(t *testing.T) {
+	for _, item := range []string{"", "a", "hello world"} {
+		h1, h2 := hashing.Pair([]byte(item))
+		if expected := hashing.Sum64([]byte(item)); h1 != expected {
+			t.Errorf("Pair: expected first hash %d, got %d", expected, h1)
+		}
+		if h1 == h2 {
+			t.Errorf("Pair: expected different hashes, got %d twice", h1)
+		}
+	}
+}
+
+func TestMix(t *testing.T) {
+	if got := hashing.Mix(0); got != 0 {
+		t.Errorf("Mix: expected 0, got %d", got)
+	}
+	if hashing.Mix(1) == hashing.Mix(2) {
+		t.Errorf("Mix: expected different results for different inputs")
+	}
+}